@@ -4,29 +4,43 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/extconfig"
 	"golang.org/x/exp/slices"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	listerAppsv1 "k8s.io/client-go/listers/apps/v1"
 	listerAutoscalingv2 "k8s.io/client-go/listers/autoscaling/v2"
+	listerBatchv1 "k8s.io/client-go/listers/batch/v1"
 	listerCorev1 "k8s.io/client-go/listers/core/v1"
+	listerPolicyv1 "k8s.io/client-go/listers/policy/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -34,6 +48,8 @@ import (
 
 var K8S *Client
 
+const eventReportingController = "steadybit.com/extension-kubernetes"
+
 type Client struct {
 	Distribution string
 	permissions  *PermissionCheckResult
@@ -69,7 +85,7 @@ type Client struct {
 	}
 
 	event struct {
-		informer cache.SharedIndexInformer
+		store *eventStore
 	}
 
 	node struct {
@@ -82,9 +98,46 @@ type Client struct {
 		informer cache.SharedIndexInformer
 	}
 
-	handlers struct {
-		sync.Mutex
-		l []chan<- interface{}
+	pdb struct {
+		lister   listerPolicyv1.PodDisruptionBudgetLister
+		informer cache.SharedIndexInformer
+	}
+
+	namespace struct {
+		lister   listerCorev1.NamespaceLister
+		informer cache.SharedIndexInformer
+	}
+
+	resourceQuota struct {
+		lister   listerCorev1.ResourceQuotaLister
+		informer cache.SharedIndexInformer
+	}
+
+	job struct {
+		lister   listerBatchv1.JobLister
+		informer cache.SharedIndexInformer
+	}
+
+	cronJob struct {
+		lister   listerBatchv1.CronJobLister
+		informer cache.SharedIndexInformer
+	}
+
+	replicationController struct {
+		lister   listerCorev1.ReplicationControllerLister
+		informer cache.SharedIndexInformer
+	}
+
+	// customResources by their lower case kind
+	customResources map[string]*customResource
+	dynamicClient   dynamic.Interface
+
+	clientset kubernetes.Interface
+	metrics   metricsclient.Interface
+
+	subscriptions struct {
+		sync.RWMutex
+		l []*Subscription
 	}
 	resourceEventHandler cache.ResourceEventHandlerFuncs
 }
@@ -138,34 +191,12 @@ func (c *Client) DeploymentByNamespaceAndName(namespace string, name string) *ap
 }
 
 func (c *Client) ServicesByPod(pod *corev1.Pod) []*corev1.Service {
-	services, err := c.service.lister.Services(pod.Namespace).List(labels.Everything())
-	if err != nil {
-		log.Error().Err(err).Msgf("Error while fetching services")
-		return []*corev1.Service{}
-	}
-	var result []*corev1.Service
-	for _, service := range services {
-		match := service.Spec.Selector != nil
-		for key, value := range service.Spec.Selector {
-			if value != pod.ObjectMeta.Labels[key] {
-				match = false
-			}
-		}
-		if match {
-			result = append(result, service)
-		}
-	}
-	return result
+	return c.ServicesMatchingToPodLabels(pod.Namespace, pod.ObjectMeta.Labels)
 }
 
 func (c *Client) ServicesMatchingToPodLabels(namespace string, labelSelector map[string]string) []*corev1.Service {
-	services, err := c.service.lister.Services(namespace).List(labels.Everything())
-	if err != nil {
-		log.Error().Err(err).Msgf("Error while fetching services")
-		return []*corev1.Service{}
-	}
 	var result []*corev1.Service
-	for _, service := range services {
+	for _, service := range c.servicesBySelectorLabels(namespace, labelSelector) {
 		match := service.Spec.Selector != nil
 		for key, value := range service.Spec.Selector {
 			if value != labelSelector[key] {
@@ -215,17 +246,46 @@ func (c *Client) StatefulSetByNamespaceAndName(namespace string, name string) *a
 	return item
 }
 
-func (c *Client) NodesReadyCount() int {
-	nodes := c.Nodes()
-	nodeCountReady := 0
-	for _, node := range nodes {
-		for _, condition := range node.Status.Conditions {
-			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
-				nodeCountReady = nodeCountReady + 1
-			}
-		}
+func (c *Client) Jobs() []*batchv1.Job {
+	if c.job.lister == nil {
+		return []*batchv1.Job{}
+	}
+	jobs, err := c.job.lister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching jobs")
+		return []*batchv1.Job{}
+	}
+	return jobs
+}
+
+func (c *Client) JobByNamespaceAndName(namespace string, name string) *batchv1.Job {
+	if c.job.lister == nil {
+		return nil
+	}
+	item, err := c.job.lister.Jobs(namespace).Get(name)
+	logGetError(fmt.Sprintf("job %s/%s", namespace, name), err)
+	return item
+}
+
+func (c *Client) CronJobs() []*batchv1.CronJob {
+	if c.cronJob.lister == nil {
+		return []*batchv1.CronJob{}
+	}
+	cronJobs, err := c.cronJob.lister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching cronjobs")
+		return []*batchv1.CronJob{}
+	}
+	return cronJobs
+}
+
+func (c *Client) CronJobByNamespaceAndName(namespace string, name string) *batchv1.CronJob {
+	if c.cronJob.lister == nil {
+		return nil
 	}
-	return nodeCountReady
+	item, err := c.cronJob.lister.CronJobs(namespace).Get(name)
+	logGetError(fmt.Sprintf("cronjob %s/%s", namespace, name), err)
+	return item
 }
 
 func (c *Client) Nodes() []*corev1.Node {
@@ -237,31 +297,267 @@ func (c *Client) Nodes() []*corev1.Node {
 	return nodes
 }
 
-func (c *Client) Events(since time.Time) *[]corev1.Event {
-	events := c.event.informer.GetIndexer().List()
-	//filter events by time
-	result := filterEvents(events, since)
-	//sort events by time
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].LastTimestamp.Time.Before(result[j].LastTimestamp.Time)
-	})
+func (c *Client) NodesBySelector(selector labels.Selector) []*corev1.Node {
+	nodes, err := c.node.lister.List(selector)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching nodes")
+		return []*corev1.Node{}
+	}
+	return nodes
+}
+
+func (c *Client) NodeByName(name string) *corev1.Node {
+	item, err := c.node.lister.Get(name)
+	logGetError(fmt.Sprintf("node %s", name), err)
+	return item
+}
+
+func (c *Client) Namespaces() []*corev1.Namespace {
+	if c.namespace.lister == nil {
+		return []*corev1.Namespace{}
+	}
+	namespaces, err := c.namespace.lister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching namespaces")
+		return []*corev1.Namespace{}
+	}
+	return namespaces
+}
+
+func (c *Client) NamespaceByName(name string) *corev1.Namespace {
+	if c.namespace.lister == nil {
+		return nil
+	}
+	item, err := c.namespace.lister.Get(name)
+	logGetError(fmt.Sprintf("namespace %s", name), err)
+	return item
+}
+
+func (c *Client) ResourceQuotas() []*corev1.ResourceQuota {
+	if c.resourceQuota.lister == nil {
+		return []*corev1.ResourceQuota{}
+	}
+	quotas, err := c.resourceQuota.lister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching resource quotas")
+		return []*corev1.ResourceQuota{}
+	}
+	return quotas
+}
+
+// Events returns the events observed after the given time, sorted by their timestamp. Only events within the
+// configured retention window are available.
+func (c *Client) Events(since time.Time) *[]eventsv1.Event {
+	result := c.event.store.since(since)
 	return &result
 }
 
+// EventsRegarding returns the events about the given object observed after the given time, sorted by their timestamp.
+func (c *Client) EventsRegarding(kind string, namespace string, name string, since time.Time) []eventsv1.Event {
+	return c.event.store.regarding(kind, namespace, name, since)
+}
+
 func (c *Client) HorizontalPodAutoscalerByNamespaceAndDeployment(namespace string, reference string) *autoscalingv2.HorizontalPodAutoscaler {
+	return c.HorizontalPodAutoscalerByNamespaceAndTarget(namespace, "Deployment", reference)
+}
+
+// HorizontalPodAutoscalerByNamespaceAndTarget returns the autoscaler scaling the workload of the given kind and name.
+func (c *Client) HorizontalPodAutoscalerByNamespaceAndTarget(namespace string, kind string, reference string) *autoscalingv2.HorizontalPodAutoscaler {
 	hpas, err := c.hpa.lister.HorizontalPodAutoscalers(namespace).List(labels.Everything())
 	if err != nil {
 		log.Error().Err(err).Msgf("Error while fetching horizontal pod autoscalers")
 		return nil
 	}
 	for _, hpa := range hpas {
-		if hpa.Spec.ScaleTargetRef.Kind == "Deployment" && hpa.Spec.ScaleTargetRef.Name == reference {
+		if hpa.Spec.ScaleTargetRef.Kind == kind && hpa.Spec.ScaleTargetRef.Name == reference {
 			return hpa
 		}
 	}
 	return nil
 }
 
+func (c *Client) PodDisruptionBudgetsMatchingToPodLabels(namespace string, podLabels map[string]string) []*policyv1.PodDisruptionBudget {
+	pdbs, err := c.pdb.lister.PodDisruptionBudgets(namespace).List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching pod disruption budgets")
+		return []*policyv1.PodDisruptionBudget{}
+	}
+	var result []*policyv1.PodDisruptionBudget
+	for _, pdb := range pdbs {
+		// a nil selector selects no pods, an empty selector (policy/v1) selects all pods of the namespace
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Invalid selector of pod disruption budget %s/%s", pdb.Namespace, pdb.Name)
+			continue
+		}
+		if selector.Matches(labels.Set(podLabels)) {
+			result = append(result, pdb)
+		}
+	}
+	return result
+}
+
+func (c *Client) PodDisruptionBudgetByNamespaceAndName(namespace string, name string) *policyv1.PodDisruptionBudget {
+	item, err := c.pdb.lister.PodDisruptionBudgets(namespace).Get(name)
+	logGetError(fmt.Sprintf("pod disruption budget %s/%s", namespace, name), err)
+	return item
+}
+
+// PodMetricsByNamespaceAndName fetches the current resource usage of a pod from the metrics.k8s.io API. Resource usage
+// isn't cached, as it changes with every scrape of the metrics-server.
+func (c *Client) PodMetricsByNamespaceAndName(namespace string, name string) (*metricsv1beta1.PodMetrics, error) {
+	if c.metrics == nil {
+		return nil, errors.New("metrics.k8s.io API is not available")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return c.metrics.MetricsV1beta1().PodMetricses(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// NodeMetricsByName fetches the current resource usage of a node from the metrics.k8s.io API.
+func (c *Client) NodeMetricsByName(name string) (*metricsv1beta1.NodeMetrics, error) {
+	if c.metrics == nil {
+		return nil, errors.New("metrics.k8s.io API is not available")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return c.metrics.MetricsV1beta1().NodeMetricses().Get(ctx, name, metav1.GetOptions{})
+}
+
+// ContainerLogs fetches the logs of a container. Logs aren't cached, use the options to fetch only the recent lines.
+func (c *Client) ContainerLogs(ctx context.Context, namespace string, pod string, options *corev1.PodLogOptions) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return c.clientset.CoreV1().Pods(namespace).GetLogs(pod, options).DoRaw(ctx)
+}
+
+// CreateEvent reports an event about the given object, which is shown by `kubectl describe`. Nodes aren't namespaced,
+// their events are reported in the default namespace.
+func (c *Client) CreateEvent(kind string, namespace string, name string, eventType string, reason string, action string, note string) error {
+	regarding, err := c.objectReference(kind, namespace, name)
+	if err != nil {
+		return err
+	}
+	eventNamespace := namespace
+	if eventNamespace == "" {
+		eventNamespace = metav1.NamespaceDefault
+	}
+	hostname, _ := os.Hostname()
+	now := time.Now()
+	event := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", name, now.UnixNano()),
+			Namespace: eventNamespace,
+		},
+		EventTime:           metav1.NewMicroTime(now),
+		ReportingController: eventReportingController,
+		ReportingInstance:   hostname,
+		Action:              action,
+		Reason:              reason,
+		Regarding:           regarding,
+		Note:                note,
+		Type:                eventType,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = c.clientset.EventsV1().Events(eventNamespace).Create(ctx, event, metav1.CreateOptions{})
+	return err
+}
+
+// AnnotateObject sets the annotation of the given object. A nil value removes the annotation.
+func (c *Client) AnnotateObject(kind string, namespace string, name string, key string, value *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	switch kind {
+	case "Deployment":
+		_, err = c.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = c.clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case "Pod":
+		_, err = c.clientset.CoreV1().Pods(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case "Node":
+		_, err = c.clientset.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case "CronJob":
+		_, err = c.clientset.BatchV1().CronJobs(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	default:
+		if cr, ok := c.customResources[strings.ToLower(kind)]; ok && c.dynamicClient != nil {
+			_, err = c.dynamicClient.Resource(cr.definition.GroupVersionResource()).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		} else {
+			err = fmt.Errorf("annotating %s objects is not supported", kind)
+		}
+	}
+	return err
+}
+
+// objectReference resolves the uid of the object, `kubectl describe` only shows events referencing the uid.
+func (c *Client) objectReference(kind string, namespace string, name string) (corev1.ObjectReference, error) {
+	ref := corev1.ObjectReference{Kind: kind, Namespace: namespace, Name: name}
+	var meta *metav1.ObjectMeta
+	switch kind {
+	case "Deployment":
+		ref.APIVersion = "apps/v1"
+		if deployment := c.DeploymentByNamespaceAndName(namespace, name); deployment != nil {
+			meta = &deployment.ObjectMeta
+		}
+	case "StatefulSet":
+		ref.APIVersion = "apps/v1"
+		if statefulSet := c.StatefulSetByNamespaceAndName(namespace, name); statefulSet != nil {
+			meta = &statefulSet.ObjectMeta
+		}
+	case "DaemonSet":
+		ref.APIVersion = "apps/v1"
+		if daemonSet := c.DaemonSetByNamespaceAndName(namespace, name); daemonSet != nil {
+			meta = &daemonSet.ObjectMeta
+		}
+	case "Pod":
+		ref.APIVersion = "v1"
+		if pod := c.PodByNamespaceAndName(namespace, name); pod != nil {
+			meta = &pod.ObjectMeta
+		}
+	case "Node":
+		ref.APIVersion = "v1"
+		if node := c.NodeByName(name); node != nil {
+			meta = &node.ObjectMeta
+		}
+	case "Job":
+		ref.APIVersion = "batch/v1"
+		if job := c.JobByNamespaceAndName(namespace, name); job != nil {
+			meta = &job.ObjectMeta
+		}
+	case "CronJob":
+		ref.APIVersion = "batch/v1"
+		if cronJob := c.CronJobByNamespaceAndName(namespace, name); cronJob != nil {
+			meta = &cronJob.ObjectMeta
+		}
+	default:
+		if definition := c.CustomResourceDefinition(kind); definition != nil {
+			ref.APIVersion = definition.GroupVersionResource().GroupVersion().String()
+			if object := c.CustomResourceByNamespaceAndName(kind, namespace, name); object != nil {
+				objectMeta := CustomResourceObjectMeta(object)
+				objectMeta.ResourceVersion = object.GetResourceVersion()
+				meta = &objectMeta
+			}
+		}
+	}
+	if meta == nil {
+		return ref, fmt.Errorf("%s %s/%s not found", strings.ToLower(kind), namespace, name)
+	}
+	ref.UID = meta.UID
+	ref.ResourceVersion = meta.ResourceVersion
+	return ref, nil
+}
+
 func logGetError(resource string, err error) {
 	if err != nil {
 		var t *k8sErrors.StatusError
@@ -271,20 +567,69 @@ func logGetError(resource string, err error) {
 	}
 }
 
-func filterEvents(events []interface{}, since time.Time) []corev1.Event {
-	var filtered []corev1.Event
-	for _, event := range events {
-		if event.(*corev1.Event).LastTimestamp.Time.After(since) {
-			filtered = append(filtered, *event.(*corev1.Event))
-		}
+// EventTimestamp returns when the event was observed most recently. Components using the events.k8s.io API set the
+// event time and the series, components using the core API only set the (now deprecated) first and last timestamps.
+func EventTimestamp(event *eventsv1.Event) time.Time {
+	var result time.Time
+	if event.Series != nil && event.Series.LastObservedTime.Time.After(result) {
+		result = event.Series.LastObservedTime.Time
+	}
+	if event.EventTime.Time.After(result) {
+		result = event.EventTime.Time
+	}
+	if event.DeprecatedLastTimestamp.Time.After(result) {
+		result = event.DeprecatedLastTimestamp.Time
+	}
+	if event.DeprecatedFirstTimestamp.Time.After(result) {
+		result = event.DeprecatedFirstTimestamp.Time
+	}
+	if result.IsZero() {
+		return event.CreationTimestamp.Time
+	}
+	return result
+}
+
+// EventCount returns how often the event was observed.
+func EventCount(event *eventsv1.Event) int32 {
+	if event.Series != nil && event.Series.Count > 0 {
+		return event.Series.Count
+	}
+	if event.DeprecatedCount > 0 {
+		return event.DeprecatedCount
 	}
-	return filtered
+	return 1
 }
 
 func PrepareClient(stopCh <-chan struct{}) {
-	clientset, rootApiPath := createClientset()
+	clientset, config := createClientset()
 	permissions := checkPermissions(clientset)
-	K8S = CreateClient(clientset, stopCh, rootApiPath, permissions)
+	K8S = CreateClient(clientset, stopCh, config.APIPath, permissions)
+	if permissions.CanReadResourceMetrics() {
+		metricsClientset, err := metricsclient.NewForConfig(config)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Could not create kubernetes metrics client")
+		}
+		K8S.SetMetricsClient(metricsClientset)
+	}
+	customResources := extconfig.Config.DiscoveryCustomResources
+	if !extconfig.Config.DiscoveryDisabledRollout && permissions.CanReadRollouts() && !customResources.Contains(RolloutCustomResource.Kind) && isCustomResourceServed(clientset, RolloutCustomResource) {
+		customResources = append(slices.Clone(customResources), RolloutCustomResource)
+	}
+	if K8S.Distribution == DistributionOpenShift {
+		customResources = appendOpenShiftCustomResources(clientset, permissions, customResources)
+	}
+	if len(customResources) > 0 {
+		dynamicClient, err := dynamic.NewForConfig(config)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Could not create kubernetes dynamic client")
+		}
+		K8S.WatchCustomResources(dynamicClient, stopCh, customResources)
+	}
+}
+
+// SetMetricsClient sets the client used to fetch resource usage from the metrics.k8s.io API (metrics-server).
+func (c *Client) SetMetricsClient(metrics metricsclient.Interface) {
+	c.metrics = metrics
 }
 
 // CreateClient is visible for testing
@@ -292,9 +637,10 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	client := &Client{
 		Distribution: "kubernetes",
 		permissions:  permissions,
+		clientset:    clientset,
 	}
-	if isOpenShift(rootApiPath) {
-		client.Distribution = "openshift"
+	if isOpenShift(clientset, rootApiPath) {
+		client.Distribution = DistributionOpenShift
 	}
 
 	factory := informers.NewSharedInformerFactory(clientset, 0)
@@ -315,6 +661,7 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	daemonSets := factory.Apps().V1().DaemonSets()
 	client.daemonSet.informer = daemonSets.Informer()
 	client.daemonSet.lister = daemonSets.Lister()
+	addIndexers(client.daemonSet.informer, cache.Indexers{ownerUidIndex: indexByOwnerUid})
 	informerSyncList = append(informerSyncList, client.daemonSet.informer.HasSynced)
 	if err := client.daemonSet.informer.SetTransform(transformDaemonSet); err != nil {
 		log.Fatal().Err(err).Msg("Failed to add daemonSet transformer")
@@ -326,6 +673,7 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	deployments := factory.Apps().V1().Deployments()
 	client.deployment.informer = deployments.Informer()
 	client.deployment.lister = deployments.Lister()
+	addIndexers(client.deployment.informer, cache.Indexers{ownerUidIndex: indexByOwnerUid})
 	informerSyncList = append(informerSyncList, client.deployment.informer.HasSynced)
 	if err := client.deployment.informer.SetTransform(transformDeployment); err != nil {
 		log.Fatal().Err(err).Msg("Failed to add deployment transformer")
@@ -337,6 +685,7 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	pods := factory.Core().V1().Pods()
 	client.pod.informer = pods.Informer()
 	client.pod.lister = pods.Lister()
+	addIndexers(client.pod.informer, cache.Indexers{nodeNameIndex: indexPodsByNodeName, ownerUidIndex: indexByOwnerUid})
 	informerSyncList = append(informerSyncList, client.pod.informer.HasSynced)
 	if err := client.pod.informer.SetTransform(transformPod); err != nil {
 		log.Fatal().Err(err).Msg("Failed to add pod transformer")
@@ -348,6 +697,7 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	replicaSets := factory.Apps().V1().ReplicaSets()
 	client.replicaSet.informer = replicaSets.Informer()
 	client.replicaSet.lister = replicaSets.Lister()
+	addIndexers(client.replicaSet.informer, cache.Indexers{ownerUidIndex: indexByOwnerUid})
 	informerSyncList = append(informerSyncList, client.replicaSet.informer.HasSynced)
 	if err := client.replicaSet.informer.SetTransform(transformReplicaSet); err != nil {
 		log.Fatal().Err(err).Msg("Failed to add replicaSet transformer")
@@ -359,6 +709,7 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	services := factory.Core().V1().Services()
 	client.service.informer = services.Informer()
 	client.service.lister = services.Lister()
+	addIndexers(client.service.informer, cache.Indexers{selectorLabelIndex: indexServicesBySelectorLabel})
 	informerSyncList = append(informerSyncList, client.service.informer.HasSynced)
 	if err := client.service.informer.SetTransform(transformService); err != nil {
 		log.Fatal().Err(err).Msg("Failed to add service transformer")
@@ -370,6 +721,7 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	statefulSets := factory.Apps().V1().StatefulSets()
 	client.statefulSet.informer = statefulSets.Informer()
 	client.statefulSet.lister = statefulSets.Lister()
+	addIndexers(client.statefulSet.informer, cache.Indexers{ownerUidIndex: indexByOwnerUid})
 	informerSyncList = append(informerSyncList, client.statefulSet.informer.HasSynced)
 	if err := client.statefulSet.informer.SetTransform(transformStatefulSet); err != nil {
 		log.Fatal().Err(err).Msg("Failed to add statefulSet transformer")
@@ -402,13 +754,100 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 		}
 	}
 
-	events := factory.Core().V1().Events()
-	client.event.informer = events.Informer()
-	informerSyncList = append(informerSyncList, client.event.informer.HasSynced)
-	if err := client.event.informer.SetTransform(transformEvents); err != nil {
-		log.Fatal().Err(err).Msg("Failed to add events transformer")
+	if permissions.CanReadPodDisruptionBudgets() {
+		pdb := factory.Policy().V1().PodDisruptionBudgets()
+		client.pdb.informer = pdb.Informer()
+		client.pdb.lister = pdb.Lister()
+		informerSyncList = append(informerSyncList, client.pdb.informer.HasSynced)
+		if err := client.pdb.informer.SetTransform(transformPodDisruptionBudget); err != nil {
+			log.Fatal().Err(err).Msg("Failed to add pdb transformer")
+		}
+		if _, err := client.pdb.informer.AddEventHandler(client.resourceEventHandler); err != nil {
+			log.Fatal().Msg("failed to add pdb event handler")
+		}
+	}
+
+	if permissions.CanReadNamespaces() {
+		namespaces := factory.Core().V1().Namespaces()
+		client.namespace.informer = namespaces.Informer()
+		client.namespace.lister = namespaces.Lister()
+		informerSyncList = append(informerSyncList, client.namespace.informer.HasSynced)
+		if err := client.namespace.informer.SetTransform(transformNamespace); err != nil {
+			log.Fatal().Err(err).Msg("Failed to add namespace transformer")
+		}
+		if _, err := client.namespace.informer.AddEventHandler(client.resourceEventHandler); err != nil {
+			log.Fatal().Msg("failed to add namespace event handler")
+		}
+	}
+
+	if permissions.CanReadResourceQuotas() {
+		resourceQuotas := factory.Core().V1().ResourceQuotas()
+		client.resourceQuota.informer = resourceQuotas.Informer()
+		client.resourceQuota.lister = resourceQuotas.Lister()
+		informerSyncList = append(informerSyncList, client.resourceQuota.informer.HasSynced)
+		if err := client.resourceQuota.informer.SetTransform(transformResourceQuota); err != nil {
+			log.Fatal().Err(err).Msg("Failed to add resourceQuota transformer")
+		}
+		if _, err := client.resourceQuota.informer.AddEventHandler(client.resourceEventHandler); err != nil {
+			log.Fatal().Msg("failed to add resourceQuota event handler")
+		}
+	}
+
+	if permissions.CanReadJobs() {
+		jobs := factory.Batch().V1().Jobs()
+		client.job.informer = jobs.Informer()
+		client.job.lister = jobs.Lister()
+		addIndexers(client.job.informer, cache.Indexers{ownerUidIndex: indexByOwnerUid})
+		informerSyncList = append(informerSyncList, client.job.informer.HasSynced)
+		if err := client.job.informer.SetTransform(transformJob); err != nil {
+			log.Fatal().Err(err).Msg("Failed to add job transformer")
+		}
+		if _, err := client.job.informer.AddEventHandler(client.resourceEventHandler); err != nil {
+			log.Fatal().Msg("failed to add job event handler")
+		}
+	}
+
+	if permissions.CanReadCronJobs() {
+		cronJobs := factory.Batch().V1().CronJobs()
+		client.cronJob.informer = cronJobs.Informer()
+		client.cronJob.lister = cronJobs.Lister()
+		informerSyncList = append(informerSyncList, client.cronJob.informer.HasSynced)
+		if err := client.cronJob.informer.SetTransform(transformCronJob); err != nil {
+			log.Fatal().Err(err).Msg("Failed to add cronJob transformer")
+		}
+		if _, err := client.cronJob.informer.AddEventHandler(client.resourceEventHandler); err != nil {
+			log.Fatal().Msg("failed to add cronJob event handler")
+		}
+	}
+
+	// on OpenShift, deployment configs own their pods through replication controllers
+	if client.Distribution == DistributionOpenShift && permissions.CanReadReplicationControllers() {
+		replicationControllers := factory.Core().V1().ReplicationControllers()
+		client.replicationController.informer = replicationControllers.Informer()
+		client.replicationController.lister = replicationControllers.Lister()
+		addIndexers(client.replicationController.informer, cache.Indexers{ownerUidIndex: indexByOwnerUid})
+		informerSyncList = append(informerSyncList, client.replicationController.informer.HasSynced)
+		if err := client.replicationController.informer.SetTransform(transformReplicationController); err != nil {
+			log.Fatal().Err(err).Msg("Failed to add replicationController transformer")
+		}
+		if _, err := client.replicationController.informer.AddEventHandler(client.resourceEventHandler); err != nil {
+			log.Fatal().Msg("failed to add replicationController event handler")
+		}
 	}
 
+	// events aren't cached by an informer, as it would keep all events of the cluster in memory
+	client.event.store = newEventStore(extconfig.Config.EventRetention)
+	eventReflector := cache.NewReflectorWithOptions(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (k8sRuntime.Object, error) {
+			return clientset.EventsV1().Events(metav1.NamespaceAll).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return clientset.EventsV1().Events(metav1.NamespaceAll).Watch(context.Background(), options)
+		},
+	}, &eventsv1.Event{}, client.event.store, cache.ReflectorOptions{Name: "events"})
+	informerSyncList = append(informerSyncList, client.event.store.hasSynced)
+	go eventReflector.Run(stopCh)
+
 	defer runtime.HandleCrash()
 	go factory.Start(stopCh)
 
@@ -421,35 +860,7 @@ func CreateClient(clientset kubernetes.Interface, stopCh <-chan struct{}, rootAp
 	return client
 }
 
-func (c *Client) doNotify(event interface{}) {
-	c.handlers.Lock()
-	defer c.handlers.Unlock()
-	for _, ch := range c.handlers.l {
-		ch <- event
-	}
-}
-
-func (c *Client) Notify(ch chan<- interface{}) {
-	c.handlers.Lock()
-	defer c.handlers.Unlock()
-	if !slices.Contains(c.handlers.l, ch) {
-		c.handlers.l = append(c.handlers.l, ch)
-	}
-}
-
-func (c *Client) StopNotify(ch chan<- interface{}) {
-	c.handlers.Lock()
-	defer c.handlers.Unlock()
-	c.handlers.l = slices.DeleteFunc(c.handlers.l, func(e chan<- interface{}) bool {
-		return e == ch
-	})
-}
-
-func isOpenShift(rootApiPath string) bool {
-	return rootApiPath == "/oapi" || rootApiPath == "oapi"
-}
-
-func createClientset() (*kubernetes.Clientset, string) {
+func createClientset() (*kubernetes.Clientset, *rest.Config) {
 	config, err := rest.InClusterConfig()
 	if err == nil {
 		log.Info().Msgf("Extension is running inside a cluster, config found")
@@ -484,7 +895,7 @@ func createClientset() (*kubernetes.Clientset, string) {
 
 	log.Info().Msgf("Cluster connected! Kubernetes Server Version %+v", info)
 
-	return clientset, config.APIPath
+	return clientset, config
 }
 
 func IsExcludedFromDiscovery(objectMeta metav1.ObjectMeta) bool {
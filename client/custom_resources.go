@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"strings"
+	"time"
+)
+
+// customResourceSyncTimeout limits how long the startup waits for custom resources, the informer of a custom resource
+// without installed definition never syncs.
+const customResourceSyncTimeout = 30 * time.Second
+
+type customResource struct {
+	definition extconfig.CustomResource
+	lister     cache.GenericLister
+	informer   cache.SharedIndexInformer
+}
+
+// WatchCustomResources starts a dynamic informer for each of the given custom resources the extension is permitted to read.
+func (c *Client) WatchCustomResources(dynamicClient dynamic.Interface, stopCh <-chan struct{}, definitions []extconfig.CustomResource) {
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 0)
+	customResources := make(map[string]*customResource, len(definitions))
+	var informerSyncList []cache.InformerSynced
+
+	for _, definition := range definitions {
+		if !c.permissions.CanReadCustomResource(definition) {
+			log.Warn().Msgf("Missing permissions to watch custom resource %s.%s, it won't be discovered.", definition.Resource, definition.Group)
+			continue
+		}
+
+		genericInformer := factory.ForResource(definition.GroupVersionResource())
+		cr := &customResource{
+			definition: definition,
+			lister:     genericInformer.Lister(),
+			informer:   genericInformer.Informer(),
+		}
+		if err := cr.informer.SetTransform(transformCustomResource); err != nil {
+			log.Fatal().Err(err).Msgf("Failed to add %s transformer", definition.Resource)
+		}
+		if _, err := cr.informer.AddEventHandler(c.resourceEventHandler); err != nil {
+			log.Fatal().Msgf("failed to add %s event handler", definition.Resource)
+		}
+		customResources[definition.AttributeName()] = cr
+		informerSyncList = append(informerSyncList, cr.informer.HasSynced)
+	}
+	c.customResources = customResources
+	c.dynamicClient = dynamicClient
+
+	if len(informerSyncList) == 0 {
+		return
+	}
+	go factory.Start(stopCh)
+
+	ctx, cancel := context.WithTimeout(context.Background(), customResourceSyncTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	log.Info().Msgf("Start custom resource cache sync.")
+	if !cache.WaitForCacheSync(ctx.Done(), informerSyncList...) {
+		log.Warn().Msg("Timed out waiting for custom resource caches to sync. Are the custom resource definitions installed?")
+		return
+	}
+	log.Info().Msgf("Custom resource caches synced.")
+}
+
+// CustomResourceDefinition returns the configuration of the watched custom resource with the given kind.
+func (c *Client) CustomResourceDefinition(kind string) *extconfig.CustomResource {
+	if cr, ok := c.customResources[strings.ToLower(kind)]; ok {
+		return &cr.definition
+	}
+	return nil
+}
+
+func (c *Client) CustomResources(kind string) []*unstructured.Unstructured {
+	cr, ok := c.customResources[strings.ToLower(kind)]
+	if !ok {
+		return []*unstructured.Unstructured{}
+	}
+	objects, err := cr.lister.List(labels.Everything())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching %s", cr.definition.Resource)
+		return []*unstructured.Unstructured{}
+	}
+	result := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, object := range objects {
+		if u, ok := object.(*unstructured.Unstructured); ok {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
+func (c *Client) CustomResourceByNamespaceAndName(kind string, namespace string, name string) *unstructured.Unstructured {
+	cr, ok := c.customResources[strings.ToLower(kind)]
+	if !ok {
+		return nil
+	}
+	object, err := cr.lister.ByNamespace(namespace).Get(name)
+	logGetError(cr.definition.Resource+" "+namespace+"/"+name, err)
+	if u, ok := object.(*unstructured.Unstructured); ok {
+		return u
+	}
+	return nil
+}
+
+// CustomResourceObjectMeta returns the metadata of a custom resource, as used by the owner resolution and the discovery.
+func CustomResourceObjectMeta(u *unstructured.Unstructured) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            u.GetName(),
+		Namespace:       u.GetNamespace(),
+		UID:             u.GetUID(),
+		Labels:          u.GetLabels(),
+		OwnerReferences: u.GetOwnerReferences(),
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sync"
+)
+
+// IsExcludedFromDiscovery tells whether the object is excluded from discovery, either by its own labels (see
+// IsExcludedFromDiscovery), the configured label selector or because its namespace is excluded. The configured rules
+// are applied even if the discovery excludes by label are disabled.
+func (c *Client) IsExcludedFromDiscovery(objectMeta metav1.ObjectMeta) bool {
+	if !extconfig.Config.DisableDiscoveryExcludes && IsExcludedFromDiscovery(objectMeta) {
+		return true
+	}
+	if matchesSelector(extconfig.Config.DiscoveryExcludeSelector, objectMeta.Labels) {
+		return true
+	}
+	return objectMeta.Namespace != "" && c.IsNamespaceExcludedFromDiscovery(objectMeta.Namespace)
+}
+
+// IsNamespaceExcludedFromDiscovery tells whether the whole namespace is excluded from discovery by the configured name
+// patterns and namespace label selectors or because the namespace is labeled with steadybit.com/discovery-disabled.
+// The namespace labels are only known, if the extension is permitted to read namespaces.
+func (c *Client) IsNamespaceExcludedFromDiscovery(name string) bool {
+	config := &extconfig.Config
+	if extconfig.MatchesAny(config.DiscoveryExcludeNamespaces, name) {
+		return true
+	}
+	if len(config.DiscoveryIncludeNamespaces) > 0 && !extconfig.MatchesAny(config.DiscoveryIncludeNamespaces, name) {
+		return true
+	}
+
+	namespace := c.NamespaceByName(name)
+	if namespace == nil {
+		return config.DiscoveryIncludeNamespaceSelector != "" && c.namespace.lister != nil
+	}
+	if !config.DisableDiscoveryExcludes && IsExcludedFromDiscovery(namespace.ObjectMeta) {
+		return true
+	}
+	if matchesSelector(config.DiscoveryExcludeNamespaceSelector, namespace.Labels) {
+		return true
+	}
+	return config.DiscoveryIncludeNamespaceSelector != "" && !matchesSelector(config.DiscoveryIncludeNamespaceSelector, namespace.Labels)
+}
+
+// parsedSelectors caches the configured label selectors.
+var parsedSelectors sync.Map
+
+// matchesSelector tells whether the labels match the label selector. An empty or invalid selector matches nothing.
+func matchesSelector(selector string, objectLabels map[string]string) bool {
+	if selector == "" {
+		return false
+	}
+	parsed, ok := parsedSelectors.Load(selector)
+	if !ok {
+		s, err := labels.Parse(selector)
+		if err != nil {
+			log.Error().Err(err).Msgf("Invalid label selector %s, ignoring it.", selector)
+			s = labels.Nothing()
+		}
+		parsed, _ = parsedSelectors.LoadOrStore(selector, s)
+	}
+	return parsed.(labels.Selector).Matches(labels.Set(objectLabels))
+}
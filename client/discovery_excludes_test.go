@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func Test_IsExcludedFromDiscovery(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient := CreateClient(testclient.NewSimpleClientset(
+		namespace("kube-system", map[string]string{"steadybit.com/discovery-disabled": "true"}),
+		namespace("shop", map[string]string{"team": "shop"}),
+		namespace("dev-shop", map[string]string{"team": "shop", "env": "dev"}),
+		namespace("monitoring", nil),
+	), stopCh, "", MockAllPermitted())
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, k8sClient.Namespaces(), 4)
+	}, 1*time.Second, 100*time.Millisecond)
+
+	checkout := metav1.ObjectMeta{Namespace: "shop", Name: "checkout", Labels: map[string]string{"app": "checkout"}}
+	canary := metav1.ObjectMeta{Namespace: "shop", Name: "canary", Labels: map[string]string{"app": "checkout", "track": "canary"}}
+	disabled := metav1.ObjectMeta{Namespace: "shop", Name: "disabled", Labels: map[string]string{"steadybit.com/discovery-disabled": "true"}}
+	devCheckout := metav1.ObjectMeta{Namespace: "dev-shop", Name: "checkout"}
+	coreDns := metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns"}
+	prometheus := metav1.ObjectMeta{Namespace: "monitoring", Name: "prometheus"}
+	node := metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"track": "canary"}}
+
+	tests := []struct {
+		name      string
+		configure func(config *extconfig.Specification)
+		excluded  []metav1.ObjectMeta
+		included  []metav1.ObjectMeta
+	}{
+		{
+			name:      "labels of the object and namespace",
+			configure: func(config *extconfig.Specification) {},
+			excluded:  []metav1.ObjectMeta{disabled, coreDns},
+			included:  []metav1.ObjectMeta{checkout, canary, devCheckout, prometheus, node},
+		},
+		{
+			name: "labels disabled",
+			configure: func(config *extconfig.Specification) {
+				config.DisableDiscoveryExcludes = true
+			},
+			included: []metav1.ObjectMeta{disabled, coreDns, checkout},
+		},
+		{
+			name: "excluded namespaces",
+			configure: func(config *extconfig.Specification) {
+				config.DiscoveryExcludeNamespaces = []string{"dev-*", "monitoring"}
+			},
+			excluded: []metav1.ObjectMeta{devCheckout, prometheus, coreDns},
+			included: []metav1.ObjectMeta{checkout, node},
+		},
+		{
+			name: "included namespaces",
+			configure: func(config *extconfig.Specification) {
+				config.DiscoveryIncludeNamespaces = []string{"/^(dev-)?shop$/"}
+			},
+			excluded: []metav1.ObjectMeta{prometheus, coreDns},
+			included: []metav1.ObjectMeta{checkout, devCheckout, node},
+		},
+		{
+			name: "excluded namespace selector",
+			configure: func(config *extconfig.Specification) {
+				config.DiscoveryExcludeNamespaceSelector = "env=dev"
+			},
+			excluded: []metav1.ObjectMeta{devCheckout},
+			included: []metav1.ObjectMeta{checkout, prometheus, node},
+		},
+		{
+			name: "included namespace selector",
+			configure: func(config *extconfig.Specification) {
+				config.DiscoveryIncludeNamespaceSelector = "team=shop,env notin (dev)"
+			},
+			excluded: []metav1.ObjectMeta{devCheckout, prometheus, {Namespace: "unknown", Name: "pod"}},
+			included: []metav1.ObjectMeta{checkout, node},
+		},
+		{
+			name: "excluded selector",
+			configure: func(config *extconfig.Specification) {
+				config.DiscoveryExcludeSelector = "track=canary"
+			},
+			excluded: []metav1.ObjectMeta{canary, node},
+			included: []metav1.ObjectMeta{checkout, devCheckout},
+		},
+		{
+			name: "excluded selector applied with labels disabled",
+			configure: func(config *extconfig.Specification) {
+				config.DisableDiscoveryExcludes = true
+				config.DiscoveryExcludeSelector = "track=canary"
+			},
+			excluded: []metav1.ObjectMeta{canary},
+			included: []metav1.ObjectMeta{disabled, coreDns},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			original := extconfig.Config
+			defer func() { extconfig.Config = original }()
+			tt.configure(&extconfig.Config)
+
+			// Then
+			for _, meta := range tt.excluded {
+				assert.True(t, k8sClient.IsExcludedFromDiscovery(meta), "%s/%s should be excluded", meta.Namespace, meta.Name)
+			}
+			for _, meta := range tt.included {
+				assert.False(t, k8sClient.IsExcludedFromDiscovery(meta), "%s/%s should be included", meta.Namespace, meta.Name)
+			}
+		})
+	}
+}
+
+func Test_IsNamespaceExcludedFromDiscovery(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient := CreateClient(testclient.NewSimpleClientset(
+		namespace("kube-system", map[string]string{"steadybit.com/discovery-disabled": "true"}),
+		namespace("shop", map[string]string{"team": "shop"}),
+	), stopCh, "", MockAllPermitted())
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, k8sClient.Namespaces(), 2)
+	}, 1*time.Second, 100*time.Millisecond)
+	extconfig.Config.DiscoveryExcludeSelector = "team=shop"
+	defer func() { extconfig.Config.DiscoveryExcludeSelector = "" }()
+
+	// Then
+	assert.True(t, k8sClient.IsNamespaceExcludedFromDiscovery("kube-system"))
+	assert.False(t, k8sClient.IsNamespaceExcludedFromDiscovery("shop"), "the selector for objects doesn't apply to namespaces")
+}
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"fmt"
+	eventsv1 "k8s.io/api/events/v1"
+	"k8s.io/client-go/tools/cache"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultEventRetention is used if no event retention is configured.
+const defaultEventRetention = 15 * time.Minute
+
+// eventStore is a cache.Store for events, which only retains the events observed within the retention window. The
+// events are kept sorted by their timestamp and indexed by the object they are regarding, so that queries don't need
+// to scan all events.
+type eventStore struct {
+	sync.RWMutex
+	retention time.Duration
+	now       func() time.Time
+	synced    bool
+	byKey     map[string]*eventsv1.Event
+	byObject  map[string]map[string]*eventsv1.Event
+	// byTime is sorted ascending by EventTimestamp
+	byTime []*eventsv1.Event
+}
+
+var _ cache.Store = (*eventStore)(nil)
+
+func newEventStore(retention time.Duration) *eventStore {
+	if retention <= 0 {
+		retention = defaultEventRetention
+	}
+	return &eventStore{
+		retention: retention,
+		now:       time.Now,
+		byKey:     make(map[string]*eventsv1.Event),
+		byObject:  make(map[string]map[string]*eventsv1.Event),
+	}
+}
+
+func eventObjectKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+func regardingKey(event *eventsv1.Event) string {
+	return eventObjectKey(event.Regarding.Kind, event.Regarding.Namespace, event.Regarding.Name)
+}
+
+func (s *eventStore) Add(obj interface{}) error {
+	return s.Update(obj)
+}
+
+func (s *eventStore) Update(obj interface{}) error {
+	event, key, err := s.toEvent(obj)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.remove(key)
+	s.insert(key, event)
+	s.prune()
+	return nil
+}
+
+func (s *eventStore) Delete(obj interface{}) error {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.remove(key)
+	return nil
+}
+
+func (s *eventStore) List() []interface{} {
+	s.RLock()
+	defer s.RUnlock()
+	result := make([]interface{}, 0, len(s.byTime))
+	for _, event := range s.byTime {
+		result = append(result, event)
+	}
+	return result
+}
+
+func (s *eventStore) ListKeys() []string {
+	s.RLock()
+	defer s.RUnlock()
+	result := make([]string, 0, len(s.byKey))
+	for key := range s.byKey {
+		result = append(result, key)
+	}
+	return result
+}
+
+func (s *eventStore) Get(obj interface{}) (item interface{}, exists bool, err error) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return s.GetByKey(key)
+}
+
+func (s *eventStore) GetByKey(key string) (item interface{}, exists bool, err error) {
+	s.RLock()
+	defer s.RUnlock()
+	event, exists := s.byKey[key]
+	if !exists {
+		return nil, false, nil
+	}
+	return event, true, nil
+}
+
+func (s *eventStore) Replace(list []interface{}, _ string) error {
+	s.Lock()
+	defer s.Unlock()
+	s.byKey = make(map[string]*eventsv1.Event, len(list))
+	s.byObject = make(map[string]map[string]*eventsv1.Event)
+	s.byTime = nil
+	for _, obj := range list {
+		event, key, err := s.toEvent(obj)
+		if err != nil {
+			return err
+		}
+		s.remove(key)
+		s.insert(key, event)
+	}
+	s.prune()
+	s.synced = true
+	return nil
+}
+
+func (s *eventStore) Resync() error {
+	return nil
+}
+
+func (s *eventStore) hasSynced() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.synced
+}
+
+// since returns the events observed after the given time, sorted by their timestamp.
+func (s *eventStore) since(since time.Time) []eventsv1.Event {
+	s.RLock()
+	defer s.RUnlock()
+	since = s.retained(since)
+	i := sort.Search(len(s.byTime), func(i int) bool {
+		return EventTimestamp(s.byTime[i]).After(since)
+	})
+	result := make([]eventsv1.Event, 0, len(s.byTime)-i)
+	for _, event := range s.byTime[i:] {
+		result = append(result, *event)
+	}
+	return result
+}
+
+// regarding returns the events about the given object observed after the given time, sorted by their timestamp.
+func (s *eventStore) regarding(kind, namespace, name string, since time.Time) []eventsv1.Event {
+	s.RLock()
+	defer s.RUnlock()
+	since = s.retained(since)
+	var result []eventsv1.Event
+	for _, event := range s.byObject[eventObjectKey(kind, namespace, name)] {
+		if EventTimestamp(event).After(since) {
+			result = append(result, *event)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return EventTimestamp(&result[i]).Before(EventTimestamp(&result[j]))
+	})
+	return result
+}
+
+// retained limits the given time to the retention window. Events are only pruned on writes, so expired events are
+// still present if there were no writes for a while.
+func (s *eventStore) retained(since time.Time) time.Time {
+	if cutoff := s.now().Add(-s.retention); since.Before(cutoff) {
+		return cutoff
+	}
+	return since
+}
+
+func (s *eventStore) toEvent(obj interface{}) (*eventsv1.Event, string, error) {
+	transformed, err := transformEvents(obj)
+	if err != nil {
+		return nil, "", err
+	}
+	event, ok := transformed.(*eventsv1.Event)
+	if !ok {
+		return nil, "", fmt.Errorf("unexpected object of type %T in event store", obj)
+	}
+	key, err := cache.MetaNamespaceKeyFunc(event)
+	if err != nil {
+		return nil, "", err
+	}
+	return event, key, nil
+}
+
+// insert must be called with the lock held. Events outside the retention window are dropped.
+func (s *eventStore) insert(key string, event *eventsv1.Event) {
+	timestamp := EventTimestamp(event)
+	if timestamp.Before(s.now().Add(-s.retention)) {
+		return
+	}
+
+	s.byKey[key] = event
+	objectKey := regardingKey(event)
+	if s.byObject[objectKey] == nil {
+		s.byObject[objectKey] = make(map[string]*eventsv1.Event)
+	}
+	s.byObject[objectKey][key] = event
+
+	// events usually arrive in order, so inserting at the end is the common case
+	i := sort.Search(len(s.byTime), func(i int) bool {
+		return EventTimestamp(s.byTime[i]).After(timestamp)
+	})
+	s.byTime = append(s.byTime, nil)
+	copy(s.byTime[i+1:], s.byTime[i:])
+	s.byTime[i] = event
+}
+
+// remove must be called with the lock held.
+func (s *eventStore) remove(key string) {
+	event, ok := s.byKey[key]
+	if !ok {
+		return
+	}
+	delete(s.byKey, key)
+
+	objectKey := regardingKey(event)
+	delete(s.byObject[objectKey], key)
+	if len(s.byObject[objectKey]) == 0 {
+		delete(s.byObject, objectKey)
+	}
+
+	timestamp := EventTimestamp(event)
+	i := sort.Search(len(s.byTime), func(i int) bool {
+		return !EventTimestamp(s.byTime[i]).Before(timestamp)
+	})
+	for ; i < len(s.byTime); i++ {
+		if s.byTime[i] == event {
+			copy(s.byTime[i:], s.byTime[i+1:])
+			s.byTime[len(s.byTime)-1] = nil
+			s.byTime = s.byTime[:len(s.byTime)-1]
+			return
+		}
+	}
+}
+
+// prune must be called with the lock held. It drops all events which are older than the retention window.
+func (s *eventStore) prune() {
+	cutoff := s.now().Add(-s.retention)
+	n := sort.Search(len(s.byTime), func(i int) bool {
+		return !EventTimestamp(s.byTime[i]).Before(cutoff)
+	})
+	for i, event := range s.byTime[:n] {
+		// clear the reference so that the pruned event can be garbage collected
+		s.byTime[i] = nil
+		key, _ := cache.MetaNamespaceKeyFunc(event)
+		delete(s.byKey, key)
+		objectKey := regardingKey(event)
+		delete(s.byObject[objectKey], key)
+		if len(s.byObject[objectKey]) == 0 {
+			delete(s.byObject, objectKey)
+		}
+	}
+	s.byTime = s.byTime[n:]
+}
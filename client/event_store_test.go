@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+var eventStoreNow = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestEventStore() *eventStore {
+	store := newEventStore(10 * time.Minute)
+	store.now = func() time.Time { return eventStoreNow }
+	return store
+}
+
+func testEvent(name string, pod string, age time.Duration) *eventsv1.Event {
+	return &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          name,
+			Namespace:     "shop",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}},
+		},
+		EventTime: metav1.NewMicroTime(eventStoreNow.Add(-age)),
+		Regarding: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: pod},
+		Note:      name,
+	}
+}
+
+func eventNames(events []eventsv1.Event) []string {
+	var names []string
+	for _, event := range events {
+		names = append(names, event.Name)
+	}
+	return names
+}
+
+func TestEventStoreReturnsEventsSinceSortedByTimestamp(t *testing.T) {
+	// Given
+	store := newTestEventStore()
+	require.NoError(t, store.Replace([]interface{}{
+		testEvent("b", "checkout", 2*time.Minute),
+		testEvent("a", "checkout", 5*time.Minute),
+	}, "1"))
+	require.NoError(t, store.Add(testEvent("d", "cart", 30*time.Second)))
+	require.NoError(t, store.Add(testEvent("c", "cart", 3*time.Minute)))
+
+	// When
+	events := store.since(eventStoreNow.Add(-4 * time.Minute))
+
+	// Then
+	assert.Equal(t, []string{"c", "b", "d"}, eventNames(events))
+	assert.Nil(t, events[0].ManagedFields)
+	assert.True(t, store.hasSynced())
+}
+
+func TestEventStoreDropsEventsOutsideOfRetention(t *testing.T) {
+	// Given
+	store := newTestEventStore()
+	require.NoError(t, store.Replace([]interface{}{
+		testEvent("expired", "checkout", 15*time.Minute),
+		testEvent("retained", "checkout", 5*time.Minute),
+	}, "1"))
+
+	// When
+	require.NoError(t, store.Add(testEvent("late", "checkout", 11*time.Minute)))
+
+	// Then
+	assert.Equal(t, []string{"retained"}, eventNames(store.since(time.Time{})))
+	assert.Equal(t, []string{"shop/retained"}, store.ListKeys())
+}
+
+func TestEventStorePrunesExpiredEvents(t *testing.T) {
+	// Given
+	store := newTestEventStore()
+	require.NoError(t, store.Replace([]interface{}{
+		testEvent("old", "checkout", 8*time.Minute),
+		testEvent("new", "checkout", 1*time.Minute),
+	}, "1"))
+
+	// When
+	eventStoreNow = eventStoreNow.Add(5 * time.Minute)
+	defer func() { eventStoreNow = eventStoreNow.Add(-5 * time.Minute) }()
+
+	// Then
+	assert.Equal(t, []string{"new"}, eventNames(store.since(time.Time{})))
+	assert.Len(t, store.List(), 2)
+
+	// When
+	require.NoError(t, store.Add(testEvent("other", "cart", 0)))
+
+	// Then
+	assert.Len(t, store.List(), 2)
+	_, exists, err := store.GetByKey("shop/old")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, []string{"new"}, eventNames(store.regarding("Pod", "shop", "checkout", time.Time{})))
+}
+
+func TestEventStoreUpdatesAndDeletesEvents(t *testing.T) {
+	// Given
+	store := newTestEventStore()
+	require.NoError(t, store.Replace([]interface{}{
+		testEvent("a", "checkout", 5*time.Minute),
+		testEvent("b", "checkout", 3*time.Minute),
+		testEvent("c", "cart", 1*time.Minute),
+	}, "1"))
+
+	// When
+	updated := testEvent("a", "checkout", 5*time.Minute)
+	updated.Series = &eventsv1.EventSeries{Count: 2, LastObservedTime: metav1.NewMicroTime(eventStoreNow)}
+	require.NoError(t, store.Update(updated))
+	require.NoError(t, store.Delete(testEvent("b", "checkout", 3*time.Minute)))
+
+	// Then
+	events := store.since(eventStoreNow.Add(-2 * time.Minute))
+	assert.Equal(t, []string{"c", "a"}, eventNames(events))
+	assert.Equal(t, int32(2), EventCount(&events[1]))
+	assert.Len(t, store.List(), 2)
+}
+
+func TestEventStoreReturnsEventsRegardingObject(t *testing.T) {
+	// Given
+	store := newTestEventStore()
+	require.NoError(t, store.Replace([]interface{}{
+		testEvent("a", "checkout", 5*time.Minute),
+		testEvent("b", "cart", 3*time.Minute),
+		testEvent("c", "checkout", 1*time.Minute),
+	}, "1"))
+
+	// When
+	events := store.regarding("Pod", "shop", "checkout", eventStoreNow.Add(-10*time.Minute))
+
+	// Then
+	assert.Equal(t, []string{"a", "c"}, eventNames(events))
+	assert.Empty(t, store.regarding("Pod", "other", "checkout", time.Time{}))
+}
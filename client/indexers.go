@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/rs/zerolog/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	nodeNameIndex        = "nodeName"
+	ownerUidIndex        = "ownerUid"
+	selectorLabelIndex   = "selectorLabel"
+	emptySelectorLabelId = "*"
+)
+
+// indexPodsByNodeName indexes the pods by the name of the node they are scheduled on.
+func indexPodsByNodeName(obj interface{}) ([]string, error) {
+	if pod, ok := obj.(*corev1.Pod); ok && pod.Spec.NodeName != "" {
+		return []string{pod.Spec.NodeName}, nil
+	}
+	return nil, nil
+}
+
+// indexByOwnerUid indexes any object by the uids of its owners.
+func indexByOwnerUid(obj interface{}) ([]string, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, nil
+	}
+	ownerReferences := accessor.GetOwnerReferences()
+	if len(ownerReferences) == 0 {
+		return nil, nil
+	}
+	uids := make([]string, 0, len(ownerReferences))
+	for _, ref := range ownerReferences {
+		uids = append(uids, string(ref.UID))
+	}
+	return uids, nil
+}
+
+// indexServicesBySelectorLabel indexes the services by each key/value pair of their selector, prefixed with the namespace.
+// A service may only match a pod, if the pod has at least one of these labels.
+func indexServicesBySelectorLabel(obj interface{}) ([]string, error) {
+	service, ok := obj.(*corev1.Service)
+	if !ok || service.Spec.Selector == nil {
+		return nil, nil
+	}
+	if len(service.Spec.Selector) == 0 {
+		// an empty, but present selector matches all pods of the namespace
+		return []string{selectorLabelKey(service.Namespace, emptySelectorLabelId, "")}, nil
+	}
+	keys := make([]string, 0, len(service.Spec.Selector))
+	for key, value := range service.Spec.Selector {
+		keys = append(keys, selectorLabelKey(service.Namespace, key, value))
+	}
+	return keys, nil
+}
+
+func selectorLabelKey(namespace string, key string, value string) string {
+	return namespace + "/" + key + "=" + value
+}
+
+func addIndexers(informer cache.SharedIndexInformer, indexers cache.Indexers) {
+	if err := informer.AddIndexers(indexers); err != nil {
+		log.Fatal().Err(err).Msg("Failed to add indexers")
+	}
+}
+
+func byIndex[T any](informer cache.SharedIndexInformer, indexName string, key string) []T {
+	if informer == nil {
+		return nil
+	}
+	objects, err := informer.GetIndexer().ByIndex(indexName, key)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while fetching objects of index %s for %s", indexName, key)
+		return nil
+	}
+	result := make([]T, 0, len(objects))
+	for _, obj := range objects {
+		if typed, ok := obj.(T); ok {
+			result = append(result, typed)
+		}
+	}
+	return result
+}
+
+// PodsByNodeName returns the pods scheduled on the given node.
+func (c *Client) PodsByNodeName(nodeName string) []*corev1.Pod {
+	return byIndex[*corev1.Pod](c.pod.informer, nodeNameIndex, nodeName)
+}
+
+// PodsByOwnerUid returns the pods owned by the given uid, either directly or through the replica sets, stateful sets,
+// daemon sets, deployments, jobs or replication controllers owned by it.
+func (c *Client) PodsByOwnerUid(uid types.UID) []*corev1.Pod {
+	var result []*corev1.Pod
+	seen := map[*corev1.Pod]bool{}
+	visited := map[types.UID]bool{}
+	pending := []types.UID{uid}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if current == "" || visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		for _, pod := range byIndex[*corev1.Pod](c.pod.informer, ownerUidIndex, string(current)) {
+			if !seen[pod] {
+				seen[pod] = true
+				result = append(result, pod)
+			}
+		}
+		for _, informer := range c.podOwnerInformers() {
+			for _, owned := range byIndex[interface{}](informer, ownerUidIndex, string(current)) {
+				if accessor, err := meta.Accessor(owned); err == nil {
+					pending = append(pending, accessor.GetUID())
+				}
+			}
+		}
+	}
+	return result
+}
+
+// podOwnerInformers are the informers of the built-in workloads, which may own pods and are owned by other resources.
+func (c *Client) podOwnerInformers() []cache.SharedIndexInformer {
+	return []cache.SharedIndexInformer{
+		c.replicaSet.informer,
+		c.statefulSet.informer,
+		c.daemonSet.informer,
+		c.deployment.informer,
+		c.job.informer,
+		c.replicationController.informer,
+	}
+}
+
+// servicesBySelectorLabels returns the candidate services of a namespace, which select at least one of the given labels.
+func (c *Client) servicesBySelectorLabels(namespace string, labels map[string]string) []*corev1.Service {
+	candidates := byIndex[*corev1.Service](c.service.informer, selectorLabelIndex, selectorLabelKey(namespace, emptySelectorLabelId, ""))
+	seen := make(map[*corev1.Service]bool, len(candidates))
+	for _, service := range candidates {
+		seen[service] = true
+	}
+	for key, value := range labels {
+		for _, service := range byIndex[*corev1.Service](c.service.informer, selectorLabelIndex, selectorLabelKey(namespace, key, value)) {
+			if !seen[service] {
+				seen[service] = true
+				candidates = append(candidates, service)
+			}
+		}
+	}
+	return candidates
+}
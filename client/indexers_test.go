@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func Test_PodsByNodeName(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient := CreateClient(testclient.NewSimpleClientset(
+		pod("shop", "checkout-1", "node-1", nil),
+		pod("shop", "checkout-2", "node-2", nil),
+		pod("other", "gateway", "node-1", nil),
+		pod("other", "pending", "", nil),
+	), stopCh, "", MockAllPermitted())
+
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, k8sClient.Pods(), 4)
+	}, 1*time.Second, 100*time.Millisecond)
+
+	// Then
+	assert.ElementsMatch(t, []string{"checkout-1", "gateway"}, podNames(k8sClient.PodsByNodeName("node-1")))
+	assert.ElementsMatch(t, []string{"checkout-2"}, podNames(k8sClient.PodsByNodeName("node-2")))
+	assert.Empty(t, k8sClient.PodsByNodeName("node-3"))
+}
+
+func Test_PodsByOwnerUid(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            "checkout-5f8d9",
+		Namespace:       "shop",
+		UID:             "replicaset-uid",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "checkout", UID: "deployment-uid"}},
+	}}
+	k8sClient := CreateClient(testclient.NewSimpleClientset(
+		replicaSet,
+		pod("shop", "checkout-5f8d9-x1y2z", "node-1", &metav1.OwnerReference{Kind: "ReplicaSet", Name: "checkout-5f8d9", UID: "replicaset-uid"}),
+		pod("shop", "checkout-5f8d9-a1b2c", "node-2", &metav1.OwnerReference{Kind: "ReplicaSet", Name: "checkout-5f8d9", UID: "replicaset-uid"}),
+		pod("shop", "job-x1y2z", "node-1", &metav1.OwnerReference{Kind: "Job", Name: "job", UID: "job-uid"}),
+		pod("shop", "standalone", "node-1", nil),
+	), stopCh, "", MockAllPermitted())
+
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, k8sClient.Pods(), 4)
+		assert.NotNil(c, k8sClient.ReplicaSetByNamespaceAndName("shop", "checkout-5f8d9"))
+	}, 1*time.Second, 100*time.Millisecond)
+
+	// Then
+	assert.ElementsMatch(t, []string{"checkout-5f8d9-x1y2z", "checkout-5f8d9-a1b2c"}, podNames(k8sClient.PodsByOwnerUid("deployment-uid")))
+	assert.ElementsMatch(t, []string{"checkout-5f8d9-x1y2z", "checkout-5f8d9-a1b2c"}, podNames(k8sClient.PodsByOwnerUid("replicaset-uid")))
+	assert.ElementsMatch(t, []string{"job-x1y2z"}, podNames(k8sClient.PodsByOwnerUid("job-uid")))
+	assert.Empty(t, k8sClient.PodsByOwnerUid("unknown-uid"))
+	assert.Empty(t, k8sClient.PodsByOwnerUid(""))
+}
+
+func Test_ServicesMatchingToPodLabels(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient := CreateClient(testclient.NewSimpleClientset(
+		service("shop", "checkout", map[string]string{"app": "checkout"}),
+		service("shop", "checkout-canary", map[string]string{"app": "checkout", "track": "canary"}),
+		service("shop", "everything", map[string]string{}),
+		service("shop", "external", nil),
+		service("other", "checkout", map[string]string{"app": "checkout"}),
+	), stopCh, "", MockAllPermitted())
+
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, k8sClient.ServicesMatchingToPodLabels("other", map[string]string{"app": "checkout"}), 1)
+	}, 1*time.Second, 100*time.Millisecond)
+
+	// Then
+	assert.ElementsMatch(t, []string{"checkout", "everything"}, serviceNames(k8sClient.ServicesMatchingToPodLabels("shop", map[string]string{"app": "checkout", "track": "stable"})))
+	assert.ElementsMatch(t, []string{"checkout", "checkout-canary", "everything"}, serviceNames(k8sClient.ServicesMatchingToPodLabels("shop", map[string]string{"app": "checkout", "track": "canary"})))
+	assert.ElementsMatch(t, []string{"everything"}, serviceNames(k8sClient.ServicesMatchingToPodLabels("shop", nil)))
+	assert.ElementsMatch(t, []string{"checkout", "everything"}, serviceNames(k8sClient.ServicesByPod(pod("shop", "checkout-1", "node-1", nil).(*corev1.Pod))))
+}
+
+func pod(namespace string, name string, nodeName string, owner *metav1.OwnerReference) runtime.Object {
+	var ownerReferences []metav1.OwnerReference
+	if owner != nil {
+		ownerReferences = []metav1.OwnerReference{*owner}
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			UID:             types.UID(namespace + "/" + name),
+			Labels:          map[string]string{"app": "checkout"},
+			OwnerReferences: ownerReferences,
+		},
+		Spec: corev1.PodSpec{NodeName: nodeName},
+	}
+}
+
+func service(namespace string, name string, selector map[string]string) runtime.Object {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       corev1.ServiceSpec{Selector: selector},
+	}
+}
+
+func podNames(pods []*corev1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, p := range pods {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func serviceNames(services []*corev1.Service) []string {
+	names := make([]string, 0, len(services))
+	for _, s := range services {
+		names = append(names, s.Name)
+	}
+	return names
+}
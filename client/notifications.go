@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/rs/zerolog/log"
+	"k8s.io/client-go/tools/cache"
+	"reflect"
+	"slices"
+	"sync/atomic"
+)
+
+// Subscription receives the objects changed in the caches of the client. The informers never wait for a subscriber:
+// a coalescing subscription (the default) keeps at most one pending notification, which is replaced by the most recent
+// change, a buffered subscription queues up to the given number of notifications and drops the ones exceeding it.
+type Subscription struct {
+	name     string
+	types    []reflect.Type
+	coalesce bool
+	ch       chan interface{}
+
+	delivered atomic.Uint64
+	coalesced atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+// SubscriptionStats count the notifications of a subscription.
+type SubscriptionStats struct {
+	// Delivered notifications were queued for the subscriber.
+	Delivered uint64 `json:"delivered"`
+	// Coalesced notifications were replaced by a more recent one before the subscriber received them.
+	Coalesced uint64 `json:"coalesced"`
+	// Dropped notifications exceeded the buffer of a buffered subscription.
+	Dropped uint64 `json:"dropped"`
+}
+
+type SubscriptionOpt func(s *Subscription)
+
+// WithTypes restricts the subscription to changes of the given types, e.g. reflect.TypeOf(corev1.Pod{}).
+func WithTypes(types ...reflect.Type) SubscriptionOpt {
+	return func(s *Subscription) {
+		s.types = append(s.types, types...)
+	}
+}
+
+// WithBuffer queues up to size notifications for the subscriber instead of coalescing them.
+func WithBuffer(size int) SubscriptionOpt {
+	return func(s *Subscription) {
+		s.coalesce = false
+		s.ch = make(chan interface{}, size)
+	}
+}
+
+// C returns the channel of the notifications, which is closed by Unsubscribe.
+func (s *Subscription) C() <-chan interface{} {
+	return s.ch
+}
+
+func (s *Subscription) Stats() SubscriptionStats {
+	return SubscriptionStats{
+		Delivered: s.delivered.Load(),
+		Coalesced: s.coalesced.Load(),
+		Dropped:   s.dropped.Load(),
+	}
+}
+
+func (s *Subscription) accepts(event interface{}) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+	eventType := reflect.TypeOf(event)
+	if eventType.Kind() == reflect.Pointer {
+		eventType = eventType.Elem()
+	}
+	return slices.Contains(s.types, eventType)
+}
+
+func (s *Subscription) offer(event interface{}) {
+	select {
+	case s.ch <- event:
+		s.delivered.Add(1)
+	default:
+		if s.coalesce {
+			s.replacePending(event)
+		} else if dropped := s.dropped.Add(1); dropped == 1 || dropped%1000 == 0 {
+			log.Warn().Msgf("Subscriber %s is too slow, dropped %d notifications so far.", s.name, dropped)
+		}
+	}
+}
+
+// replacePending drains the pending notification of a coalescing subscription and queues the given one instead.
+func (s *Subscription) replacePending(event interface{}) {
+	select {
+	case <-s.ch:
+		s.coalesced.Add(1)
+	default:
+		// the subscriber received the pending notification in the meantime
+	}
+	select {
+	case s.ch <- event:
+		s.delivered.Add(1)
+	default:
+		// a concurrent notification took the slot, it is at least as recent as this one
+		s.coalesced.Add(1)
+	}
+}
+
+// Subscribe registers a new subscription for changes in the caches of the client.
+func (c *Client) Subscribe(name string, opts ...SubscriptionOpt) *Subscription {
+	s := &Subscription{
+		name:     name,
+		coalesce: true,
+		ch:       make(chan interface{}, 1),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	c.subscriptions.Lock()
+	defer c.subscriptions.Unlock()
+	c.subscriptions.l = append(c.subscriptions.l, s)
+	return s
+}
+
+// Unsubscribe removes the subscription and closes its channel.
+func (c *Client) Unsubscribe(s *Subscription) {
+	c.subscriptions.Lock()
+	defer c.subscriptions.Unlock()
+	if i := slices.Index(c.subscriptions.l, s); i >= 0 {
+		c.subscriptions.l = slices.Delete(c.subscriptions.l, i, i+1)
+		close(s.ch)
+	}
+}
+
+// SubscriptionStats returns the stats of all subscriptions by their name.
+func (c *Client) SubscriptionStats() map[string]SubscriptionStats {
+	c.subscriptions.RLock()
+	defer c.subscriptions.RUnlock()
+	result := make(map[string]SubscriptionStats, len(c.subscriptions.l))
+	for _, s := range c.subscriptions.l {
+		stats := s.Stats()
+		if existing, ok := result[s.name]; ok {
+			stats.Delivered += existing.Delivered
+			stats.Coalesced += existing.Coalesced
+			stats.Dropped += existing.Dropped
+		}
+		result[s.name] = stats
+	}
+	return result
+}
+
+func (c *Client) doNotify(event interface{}) {
+	// deletions missed by the watch are delivered as tombstones
+	if tombstone, ok := event.(cache.DeletedFinalStateUnknown); ok {
+		event = tombstone.Obj
+	}
+
+	c.subscriptions.RLock()
+	defer c.subscriptions.RUnlock()
+	for _, s := range c.subscriptions.l {
+		if s.accepts(event) {
+			s.offer(event)
+		}
+	}
+}
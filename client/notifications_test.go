@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_InformersProgressWithStalledSubscriber(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	clientset := testclient.NewSimpleClientset()
+	k8sClient := CreateClient(clientset, stopCh, "", MockAllPermitted())
+	stalledCoalescing := k8sClient.Subscribe("stalled-coalescing")
+	stalledBuffered := k8sClient.Subscribe("stalled-buffered", WithBuffer(10))
+	active := k8sClient.Subscribe("active", WithTypes(reflect.TypeOf(corev1.Pod{})), WithBuffer(100))
+	received := make(chan interface{}, 100)
+	go func() {
+		for event := range active.C() {
+			received <- event
+		}
+	}()
+
+	// When
+	for i := 0; i < 50; i++ {
+		_, err := clientset.CoreV1().Pods("default").Create(context.Background(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i), Namespace: "default"},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	// Then
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, k8sClient.Pods(), 50)
+		assert.Len(c, received, 50)
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, SubscriptionStats{Delivered: 50, Coalesced: 49}, stalledCoalescing.Stats())
+	assert.Equal(t, "pod-49", (<-stalledCoalescing.C()).(*corev1.Pod).Name)
+	assert.Equal(t, SubscriptionStats{Delivered: 10, Dropped: 40}, stalledBuffered.Stats())
+	assert.Equal(t, SubscriptionStats{Delivered: 50}, active.Stats())
+	assert.Equal(t, map[string]SubscriptionStats{
+		"stalled-coalescing": {Delivered: 50, Coalesced: 49},
+		"stalled-buffered":   {Delivered: 10, Dropped: 40},
+		"active":             {Delivered: 50},
+	}, k8sClient.SubscriptionStats())
+}
+
+func Test_SubscriptionWithTypes(t *testing.T) {
+	// Given
+	k8sClient := &Client{}
+	subscription := k8sClient.Subscribe("pods", WithTypes(reflect.TypeOf(corev1.Pod{})), WithBuffer(10))
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}}
+	deletedPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "deleted-pod"}}
+
+	// When
+	k8sClient.doNotify(pod)
+	k8sClient.doNotify(&appsv1.Deployment{})
+	k8sClient.doNotify(cache.DeletedFinalStateUnknown{Key: "default/deleted-pod", Obj: deletedPod})
+
+	// Then
+	assert.Equal(t, pod, <-subscription.C())
+	assert.Equal(t, deletedPod, <-subscription.C())
+	assert.Empty(t, subscription.C())
+	assert.Equal(t, SubscriptionStats{Delivered: 2}, subscription.Stats())
+}
+
+func Test_CoalescingSubscriptionKeepsMostRecentChange(t *testing.T) {
+	// Given
+	k8sClient := &Client{}
+	subscription := k8sClient.Subscribe("pods")
+	outdated := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", ResourceVersion: "1"}}
+	recent := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", ResourceVersion: "2"}}
+
+	// When
+	k8sClient.doNotify(outdated)
+	k8sClient.doNotify(recent)
+
+	// Then
+	assert.Equal(t, recent, <-subscription.C())
+	assert.Empty(t, subscription.C())
+	assert.Equal(t, SubscriptionStats{Delivered: 2, Coalesced: 1}, subscription.Stats())
+}
+
+func Test_Unsubscribe(t *testing.T) {
+	// Given
+	k8sClient := &Client{}
+	subscription := k8sClient.Subscribe("pods")
+	other := k8sClient.Subscribe("other")
+
+	// When
+	k8sClient.Unsubscribe(subscription)
+	k8sClient.Unsubscribe(subscription)
+	k8sClient.doNotify(&corev1.Pod{})
+
+	// Then
+	_, open := <-subscription.C()
+	assert.False(t, open)
+	assert.Len(t, other.C(), 1)
+}
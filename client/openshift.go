@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"slices"
+	"time"
+)
+
+const DistributionOpenShift = "openshift"
+
+// DeploymentConfigCustomResource and RouteCustomResource are watched on OpenShift without being declared in
+// discovery.customResources.
+var (
+	DeploymentConfigCustomResource = extconfig.CustomResource{
+		Group:        "apps.openshift.io",
+		Version:      "v1",
+		Resource:     "deploymentconfigs",
+		Kind:         "DeploymentConfig",
+		ReplicasPath: "spec.replicas",
+		SelectorPath: "spec.selector",
+	}
+	RouteCustomResource = extconfig.CustomResource{
+		Group:    "route.openshift.io",
+		Version:  "v1",
+		Resource: "routes",
+		Kind:     "Route",
+	}
+)
+
+// DeploymentConfig is the subset of the OpenShift DeploymentConfig used by the extension, converted from the
+// unstructured objects of the dynamic informer.
+type DeploymentConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DeploymentConfigSpec   `json:"spec,omitempty"`
+	Status            DeploymentConfigStatus `json:"status,omitempty"`
+}
+
+type DeploymentConfigSpec struct {
+	Replicas        int32                   `json:"replicas"`
+	Selector        map[string]string       `json:"selector,omitempty"`
+	Template        *corev1.PodTemplateSpec `json:"template,omitempty"`
+	MinReadySeconds int32                   `json:"minReadySeconds,omitempty"`
+	Paused          bool                    `json:"paused,omitempty"`
+	Strategy        struct {
+		Type string `json:"type,omitempty"`
+	} `json:"strategy,omitempty"`
+}
+
+type DeploymentConfigStatus struct {
+	LatestVersion       int64 `json:"latestVersion,omitempty"`
+	ObservedGeneration  int64 `json:"observedGeneration,omitempty"`
+	Replicas            int32 `json:"replicas,omitempty"`
+	UpdatedReplicas     int32 `json:"updatedReplicas,omitempty"`
+	AvailableReplicas   int32 `json:"availableReplicas,omitempty"`
+	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
+	ReadyReplicas       int32 `json:"readyReplicas,omitempty"`
+}
+
+// Route is the subset of the OpenShift Route used by the extension.
+type Route struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              RouteSpec `json:"spec,omitempty"`
+}
+
+type RouteSpec struct {
+	Host              string           `json:"host,omitempty"`
+	Path              string           `json:"path,omitempty"`
+	To                RouteTargetRef   `json:"to"`
+	AlternateBackends []RouteTargetRef `json:"alternateBackends,omitempty"`
+	TLS               *struct {
+		Termination string `json:"termination,omitempty"`
+	} `json:"tls,omitempty"`
+}
+
+type RouteTargetRef struct {
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// isOpenShift detects OpenShift by the legacy API path or the apps.openshift.io API serving deployment configs.
+func isOpenShift(clientset kubernetes.Interface, rootApiPath string) bool {
+	return rootApiPath == "/oapi" || rootApiPath == "oapi" || isCustomResourceServed(clientset, DeploymentConfigCustomResource)
+}
+
+// appendOpenShiftCustomResources adds the deployment configs and routes to the watched custom resources, as far as
+// they are permitted and not configured already.
+func appendOpenShiftCustomResources(clientset kubernetes.Interface, permissions *PermissionCheckResult, customResources extconfig.CustomResources) extconfig.CustomResources {
+	result := slices.Clone(customResources)
+	if !extconfig.Config.DiscoveryDisabledDeploymentConfig && permissions.CanReadDeploymentConfigs() && !result.Contains(DeploymentConfigCustomResource.Kind) && isCustomResourceServed(clientset, DeploymentConfigCustomResource) {
+		result = append(result, DeploymentConfigCustomResource)
+	}
+	if permissions.CanReadRoutes() && !result.Contains(RouteCustomResource.Kind) && isCustomResourceServed(clientset, RouteCustomResource) {
+		result = append(result, RouteCustomResource)
+	}
+	return result
+}
+
+func (c *Client) DeploymentConfigs() []*DeploymentConfig {
+	objects := c.CustomResources(DeploymentConfigCustomResource.Kind)
+	result := make([]*DeploymentConfig, 0, len(objects))
+	for _, object := range objects {
+		var deploymentConfig DeploymentConfig
+		if convertUnstructured(object, &deploymentConfig) {
+			result = append(result, &deploymentConfig)
+		}
+	}
+	return result
+}
+
+func (c *Client) DeploymentConfigByNamespaceAndName(namespace string, name string) *DeploymentConfig {
+	object := c.CustomResourceByNamespaceAndName(DeploymentConfigCustomResource.Kind, namespace, name)
+	if object == nil {
+		return nil
+	}
+	var deploymentConfig DeploymentConfig
+	if !convertUnstructured(object, &deploymentConfig) {
+		return nil
+	}
+	return &deploymentConfig
+}
+
+// RoutesMatchingToServices returns the routes sending traffic to one of the given services.
+func (c *Client) RoutesMatchingToServices(namespace string, services []*corev1.Service) []*Route {
+	if len(services) == 0 {
+		return []*Route{}
+	}
+	serviceNames := make(map[string]bool, len(services))
+	for _, service := range services {
+		serviceNames[service.Name] = true
+	}
+	var result []*Route
+	for _, object := range c.CustomResources(RouteCustomResource.Kind) {
+		if object.GetNamespace() != namespace {
+			continue
+		}
+		var route Route
+		if !convertUnstructured(object, &route) {
+			continue
+		}
+		backends := append([]RouteTargetRef{route.Spec.To}, route.Spec.AlternateBackends...)
+		for _, backend := range backends {
+			if (backend.Kind == "" || backend.Kind == "Service") && serviceNames[backend.Name] {
+				result = append(result, &route)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// InstantiateDeploymentConfig starts a new rollout of the deployment config, like `oc rollout latest` does.
+func (c *Client) InstantiateDeploymentConfig(namespace string, name string) error {
+	if c.dynamicClient == nil {
+		return fmt.Errorf("deployment configs are not watched")
+	}
+	request := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": DeploymentConfigCustomResource.GroupVersionResource().GroupVersion().String(),
+		"kind":       "DeploymentRequest",
+		"name":       name,
+		"latest":     true,
+		"force":      true,
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := c.dynamicClient.Resource(DeploymentConfigCustomResource.GroupVersionResource()).Namespace(namespace).Create(ctx, request, metav1.CreateOptions{}, "instantiate")
+	return err
+}
+
+func (c *Client) ReplicationControllerByNamespaceAndName(namespace string, name string) *corev1.ReplicationController {
+	if c.replicationController.lister == nil {
+		return nil
+	}
+	item, err := c.replicationController.lister.ReplicationControllers(namespace).Get(name)
+	logGetError(fmt.Sprintf("replication controller %s/%s", namespace, name), err)
+	return item
+}
+
+func convertUnstructured(object *unstructured.Unstructured, target interface{}) bool {
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.UnstructuredContent(), target); err != nil {
+		log.Warn().Err(err).Msgf("Failed to convert %s %s/%s", object.GetKind(), object.GetNamespace(), object.GetName())
+		return false
+	}
+	return true
+}
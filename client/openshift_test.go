@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"context"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func Test_isOpenShift(t *testing.T) {
+	tests := []struct {
+		name        string
+		rootApiPath string
+		resources   []*metav1.APIResourceList
+		want        bool
+	}{
+		{
+			name: "plain kubernetes",
+			want: false,
+		},
+		{
+			name:        "legacy api path",
+			rootApiPath: "/oapi",
+			want:        true,
+		},
+		{
+			name: "deployment configs served",
+			resources: []*metav1.APIResourceList{{
+				GroupVersion: "apps.openshift.io/v1",
+				APIResources: []metav1.APIResource{{Name: "deploymentconfigs", Kind: "DeploymentConfig"}},
+			}},
+			want: true,
+		},
+		{
+			name: "other resources of the group served",
+			resources: []*metav1.APIResourceList{{
+				GroupVersion: "apps.openshift.io/v1",
+				APIResources: []metav1.APIResource{{Name: "deploymentconfigs/scale", Kind: "Scale"}},
+			}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			clientset := testclient.NewSimpleClientset()
+			clientset.Resources = tt.resources
+
+			// When
+			result := isOpenShift(clientset, tt.rootApiPath)
+
+			// Then
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func Test_RoutesMatchingToServices(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient := CreateClient(testclient.NewSimpleClientset(), stopCh, "", MockAllPermitted())
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{RouteCustomResource.GroupVersionResource(): "RouteList"},
+		route("shop", "checkout", "Service", "checkout", "checkout.apps.example.com"),
+		route("shop", "checkout-canary", "", "checkout-canary", ""),
+		route("shop", "other", "Service", "other", "other.apps.example.com"),
+		route("other", "checkout", "Service", "checkout", "checkout.other.example.com"),
+	)
+	k8sClient.WatchCustomResources(dynamicClient, stopCh, []extconfig.CustomResource{RouteCustomResource})
+	services := []*corev1.Service{
+		{ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "checkout-canary", Namespace: "shop"}},
+	}
+
+	// When
+	routes := k8sClient.RoutesMatchingToServices("shop", services)
+
+	// Then
+	names := make([]string, 0, len(routes))
+	for _, r := range routes {
+		names = append(names, r.Name)
+	}
+	assert.ElementsMatch(t, []string{"checkout", "checkout-canary"}, names)
+	assert.Empty(t, k8sClient.RoutesMatchingToServices("shop", nil))
+}
+
+func route(namespace string, name string, kind string, service string, host string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "route.openshift.io/v1",
+		"kind":       "Route",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"host": host,
+			"to":   map[string]interface{}{"kind": kind, "name": service},
+		},
+	}}
+}
+
+func Test_OwnerReferencesOfDeploymentConfigPods(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	clientset := testclient.NewSimpleClientset()
+	k8sClient := CreateClient(clientset, stopCh, "/oapi", MockAllPermitted())
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{DeploymentConfigCustomResource.GroupVersionResource(): "DeploymentConfigList"},
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps.openshift.io/v1",
+			"kind":       "DeploymentConfig",
+			"metadata":   map[string]interface{}{"name": "checkout", "namespace": "shop"},
+		}},
+	)
+	k8sClient.WatchCustomResources(dynamicClient, stopCh, []extconfig.CustomResource{DeploymentConfigCustomResource})
+	_, err := clientset.CoreV1().ReplicationControllers("shop").Create(context.Background(), &corev1.ReplicationController{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-4",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "DeploymentConfig", Name: "checkout"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	pod := metav1.ObjectMeta{
+		Name:            "checkout-4-x1y2z",
+		Namespace:       "shop",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicationController", Name: "checkout-4"}},
+	}
+
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.NotNil(c, k8sClient.ReplicationControllerByNamespaceAndName("shop", "checkout-4"))
+	}, 1*time.Second, 100*time.Millisecond)
+	owners := OwnerReferences(k8sClient, &pod)
+
+	// Then
+	assert.Equal(t, DistributionOpenShift, k8sClient.Distribution)
+	assert.Equal(t, []OwnerReference{
+		{Name: "checkout-4", Kind: "replicationcontroller"},
+		{Name: "checkout", Kind: "deploymentconfig"},
+	}, owners.OwnerRefs)
+}
@@ -81,11 +81,55 @@ func getResource(k8s *Client, kind string, namespace string, name string) (*Owne
 		if deployment != nil {
 			return extutil.Ptr(OwnerReference{Name: deployment.Name, Kind: strings.ToLower(kind)}), extutil.Ptr(deployment.ObjectMeta), deployment, nil
 		}
+	} else if strings.EqualFold("job", kind) {
+		job := k8s.JobByNamespaceAndName(namespace, name)
+		if job != nil {
+			return extutil.Ptr(OwnerReference{Name: job.Name, Kind: strings.ToLower(kind)}), extutil.Ptr(job.ObjectMeta), nil, nil
+		}
+	} else if strings.EqualFold("cronjob", kind) {
+		cronJob := k8s.CronJobByNamespaceAndName(namespace, name)
+		if cronJob != nil {
+			return extutil.Ptr(OwnerReference{Name: cronJob.Name, Kind: strings.ToLower(kind)}), extutil.Ptr(cronJob.ObjectMeta), nil, nil
+		}
+	} else if strings.EqualFold("replicationcontroller", kind) {
+		replicationController := k8s.ReplicationControllerByNamespaceAndName(namespace, name)
+		if replicationController != nil {
+			return extutil.Ptr(OwnerReference{Name: replicationController.Name, Kind: strings.ToLower(kind)}), extutil.Ptr(replicationController.ObjectMeta), nil, nil
+		}
 	} else if strings.EqualFold("statefulset", kind) {
 		statefulset := k8s.StatefulSetByNamespaceAndName(namespace, name)
 		if statefulset != nil {
 			return extutil.Ptr(OwnerReference{Name: statefulset.Name, Kind: strings.ToLower(kind)}), extutil.Ptr(statefulset.ObjectMeta), nil, nil
 		}
+	} else if customResource := k8s.CustomResourceByNamespaceAndName(kind, namespace, name); customResource != nil {
+		return extutil.Ptr(OwnerReference{Name: customResource.GetName(), Kind: strings.ToLower(kind)}), extutil.Ptr(CustomResourceObjectMeta(customResource)), nil, nil
 	}
 	return nil, nil, nil, nil
 }
+
+// OwnerReferenceCache resolves the owner references of many objects, e.g. of all pods in a discovery run. Objects
+// usually share their owners, the owners of an owner are therefore resolved only once. The cache doesn't observe
+// changes and should only be used for a single run.
+type OwnerReferenceCache struct {
+	k8s    *Client
+	owners map[string][]OwnerReference
+}
+
+func NewOwnerReferenceCache(k8s *Client) *OwnerReferenceCache {
+	return &OwnerReferenceCache{k8s: k8s, owners: make(map[string][]OwnerReference)}
+}
+
+// OwnerReferences returns the same owner references as the OwnerReferences function.
+func (c *OwnerReferenceCache) OwnerReferences(meta *metav1.ObjectMeta) []OwnerReference {
+	var result []OwnerReference
+	for _, ref := range meta.GetOwnerReferences() {
+		key := meta.Namespace + "/" + strings.ToLower(ref.Kind) + "/" + ref.Name
+		owners, ok := c.owners[key]
+		if !ok {
+			owners = OwnerReferences(c.k8s, &metav1.ObjectMeta{Namespace: meta.Namespace, OwnerReferences: []metav1.OwnerReference{ref}}).OwnerRefs
+			c.owners[key] = owners
+		}
+		result = append(result, owners...)
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+)
+
+func Test_OwnerReferenceCacheResolvesSharedOwnersOnce(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient := CreateClient(testclient.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-5d8f",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "checkout"}},
+		}},
+	), stopCh, "", MockAllPermitted())
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "checkout-5d8f"}},
+		}}
+	}
+	pods := []*corev1.Pod{pod("checkout-5d8f-x2x4k"), pod("checkout-5d8f-b7h2m"), {ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "shop"}}}
+	cache := NewOwnerReferenceCache(k8sClient)
+
+	// When
+	var owners [][]OwnerReference
+	for _, p := range pods {
+		owners = append(owners, cache.OwnerReferences(&p.ObjectMeta))
+	}
+
+	// Then
+	for i, p := range pods {
+		assert.Equal(t, OwnerReferences(k8sClient, &p.ObjectMeta).OwnerRefs, owners[i])
+	}
+	assert.Equal(t, []OwnerReference{{Name: "checkout-5d8f", Kind: "replicaset"}, {Name: "checkout", Kind: "deployment"}}, owners[0])
+	assert.Len(t, cache.owners, 1)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/extconfig"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -47,10 +48,17 @@ var requiredPermissions = []requiredPermission{
 	{group: "apps", resource: "daemonsets", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: false},
 	{group: "apps", resource: "statefulsets", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: false},
 	{group: "autoscaling", resource: "horizontalpodautoscalers", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: "policy", resource: "poddisruptionbudgets", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: "metrics.k8s.io", resource: "pods", verbs: []string{"get", "list"}, allowGracefulFailure: true},
+	{group: "metrics.k8s.io", resource: "nodes", verbs: []string{"get", "list"}, allowGracefulFailure: true},
 	{group: "", resource: "services", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: false},
 	{group: "", resource: "pods", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: false},
 	{group: "", resource: "nodes", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: false},
-	{group: "", resource: "events", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: false},
+	{group: "events.k8s.io", resource: "events", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: false},
+	{group: "", resource: "namespaces", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: "", resource: "resourcequotas", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: "batch", resource: "jobs", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: "batch", resource: "cronjobs", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
 	{group: "apps", resource: "deployments", verbs: []string{"patch"}, allowGracefulFailure: true},
 	{group: "apps", resource: "deployments", subresource: "scale", verbs: []string{"get", "update", "patch"}, allowGracefulFailure: true},
 	{group: "apps", resource: "statefulsets", subresource: "scale", verbs: []string{"get", "update", "patch"}, allowGracefulFailure: true},
@@ -58,6 +66,34 @@ var requiredPermissions = []requiredPermission{
 	{group: "", resource: "pods", subresource: "eviction", verbs: []string{"create"}, allowGracefulFailure: true},
 	{group: "", resource: "nodes", verbs: []string{"patch"}, allowGracefulFailure: true},
 	{group: "", resource: "pods", subresource: "exec", verbs: []string{"create"}, allowGracefulFailure: true},
+	{group: "", resource: "pods", subresource: "log", verbs: []string{"get"}, allowGracefulFailure: true},
+	{group: "events.k8s.io", resource: "events", verbs: []string{"create"}, allowGracefulFailure: true},
+	{group: "", resource: "pods", verbs: []string{"patch"}, allowGracefulFailure: true},
+	{group: "apps", resource: "statefulsets", verbs: []string{"patch"}, allowGracefulFailure: true},
+	{group: "batch", resource: "cronjobs", verbs: []string{"patch"}, allowGracefulFailure: true},
+	{group: "argoproj.io", resource: "rollouts", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: "argoproj.io", resource: "rollouts", verbs: []string{"patch"}, allowGracefulFailure: true},
+	{group: "argoproj.io", resource: "rollouts", subresource: "status", verbs: []string{"patch"}, allowGracefulFailure: true},
+	{group: "", resource: "replicationcontrollers", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: "apps.openshift.io", resource: "deploymentconfigs", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+	{group: "apps.openshift.io", resource: "deploymentconfigs", verbs: []string{"patch"}, allowGracefulFailure: true},
+	{group: "apps.openshift.io", resource: "deploymentconfigs", subresource: "scale", verbs: []string{"get", "update", "patch"}, allowGracefulFailure: true},
+	{group: "apps.openshift.io", resource: "deploymentconfigs", subresource: "instantiate", verbs: []string{"create"}, allowGracefulFailure: true},
+	{group: "route.openshift.io", resource: "routes", verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true},
+}
+
+// allRequiredPermissions adds the permissions to watch the configured custom resources to the required permissions.
+func allRequiredPermissions() []requiredPermission {
+	result := make([]requiredPermission, 0, len(requiredPermissions)+len(extconfig.Config.DiscoveryCustomResources))
+	result = append(result, requiredPermissions...)
+	for _, customResource := range extconfig.Config.DiscoveryCustomResources {
+		result = append(result, customResourcePermission(customResource))
+	}
+	return result
+}
+
+func customResourcePermission(customResource extconfig.CustomResource) requiredPermission {
+	return requiredPermission{group: customResource.Group, resource: customResource.Resource, verbs: []string{"get", "list", "watch"}, allowGracefulFailure: true}
 }
 
 func checkPermissions(client *kubernetes.Clientset) *PermissionCheckResult {
@@ -65,7 +101,7 @@ func checkPermissions(client *kubernetes.Clientset) *PermissionCheckResult {
 	reviews := client.AuthorizationV1().SelfSubjectAccessReviews()
 	errors := false
 
-	for _, p := range requiredPermissions {
+	for _, p := range allRequiredPermissions() {
 		for _, verb := range p.verbs {
 			sar := authorizationv1.SelfSubjectAccessReview{
 				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
@@ -141,6 +177,118 @@ func (p *PermissionCheckResult) CanReadHorizontalPodAutoscalers() bool {
 		"autoscaling/horizontalpodautoscalers/watch"})
 }
 
+func (p *PermissionCheckResult) CanReadPodDisruptionBudgets() bool {
+	return p.hasPermissions([]string{
+		"policy/poddisruptionbudgets/get",
+		"policy/poddisruptionbudgets/list",
+		"policy/poddisruptionbudgets/watch",
+	})
+}
+
+func (p *PermissionCheckResult) CanReadNamespaces() bool {
+	return p.hasPermissions([]string{
+		"namespaces/get",
+		"namespaces/list",
+		"namespaces/watch",
+	})
+}
+
+func (p *PermissionCheckResult) CanReadResourceQuotas() bool {
+	return p.hasPermissions([]string{
+		"resourcequotas/get",
+		"resourcequotas/list",
+		"resourcequotas/watch",
+	})
+}
+
+func (p *PermissionCheckResult) CanReadJobs() bool {
+	return p.hasPermissions([]string{
+		"batch/jobs/get",
+		"batch/jobs/list",
+		"batch/jobs/watch",
+	})
+}
+
+func (p *PermissionCheckResult) CanReadCronJobs() bool {
+	return p.hasPermissions([]string{
+		"batch/cronjobs/get",
+		"batch/cronjobs/list",
+		"batch/cronjobs/watch",
+	})
+}
+
+func (p *PermissionCheckResult) CanReadCustomResource(customResource extconfig.CustomResource) bool {
+	permission := customResourcePermission(customResource)
+	return p.hasPermissions([]string{
+		permission.Key("get"),
+		permission.Key("list"),
+		permission.Key("watch"),
+	})
+}
+
+func (p *PermissionCheckResult) CanReadRollouts() bool {
+	return p.CanReadCustomResource(RolloutCustomResource)
+}
+
+func (p *PermissionCheckResult) CanReadReplicationControllers() bool {
+	return p.hasPermissions([]string{
+		"replicationcontrollers/get",
+		"replicationcontrollers/list",
+		"replicationcontrollers/watch",
+	})
+}
+
+func (p *PermissionCheckResult) CanReadDeploymentConfigs() bool {
+	return p.CanReadCustomResource(DeploymentConfigCustomResource)
+}
+
+func (p *PermissionCheckResult) CanReadRoutes() bool {
+	return p.CanReadCustomResource(RouteCustomResource)
+}
+
+func (p *PermissionCheckResult) CanCreateEvents() bool {
+	return p.hasPermissions([]string{
+		"events.k8s.io/events/create",
+	})
+}
+
+// IsAnnotatePermitted checks whether objects of the given kind can be annotated.
+func (p *PermissionCheckResult) IsAnnotatePermitted(kind string) bool {
+	switch kind {
+	case "Deployment":
+		return p.hasPermissions([]string{"apps/deployments/patch"})
+	case "StatefulSet":
+		return p.hasPermissions([]string{"apps/statefulsets/patch"})
+	case "Pod":
+		return p.hasPermissions([]string{"pods/patch"})
+	case "Node":
+		return p.hasPermissions([]string{"nodes/patch"})
+	case "CronJob":
+		return p.hasPermissions([]string{"batch/cronjobs/patch"})
+	case "Rollout":
+		return p.hasPermissions([]string{"argoproj.io/rollouts/patch"})
+	case "DeploymentConfig":
+		return p.hasPermissions([]string{"apps.openshift.io/deploymentconfigs/patch"})
+	default:
+		return false
+	}
+}
+
+func (p *PermissionCheckResult) CanReadPodLogs() bool {
+	return p.hasPermissions([]string{
+		"pods/log/get",
+	})
+}
+
+func (p *PermissionCheckResult) CanReadResourceMetrics() bool {
+	return p.hasPermissions([]string{
+		"metrics.k8s.io/pods/get",
+		"metrics.k8s.io/pods/list",
+		"metrics.k8s.io/nodes/get",
+		"metrics.k8s.io/nodes/list",
+	})
+}
+
 func (p *PermissionCheckResult) IsRolloutRestartPermitted() bool {
 	return p.hasPermissions([]string{
 		"apps/deployments/patch",
@@ -163,6 +311,42 @@ func (p *PermissionCheckResult) IsScaleStatefulSetPermitted() bool {
 	})
 }
 
+func (p *PermissionCheckResult) IsSuspendCronJobPermitted() bool {
+	return p.hasPermissions([]string{
+		"batch/cronjobs/get",
+		"batch/cronjobs/patch",
+	})
+}
+
+func (p *PermissionCheckResult) IsRestartRolloutPermitted() bool {
+	return p.hasPermissions([]string{
+		"argoproj.io/rollouts/get",
+		"argoproj.io/rollouts/patch",
+	})
+}
+
+func (p *PermissionCheckResult) IsAbortRolloutPermitted() bool {
+	return p.hasPermissions([]string{
+		"argoproj.io/rollouts/get",
+		"argoproj.io/rollouts/status/patch",
+	})
+}
+
+func (p *PermissionCheckResult) IsScaleDeploymentConfigPermitted() bool {
+	return p.hasPermissions([]string{
+		"apps.openshift.io/deploymentconfigs/scale/get",
+		"apps.openshift.io/deploymentconfigs/scale/update",
+		"apps.openshift.io/deploymentconfigs/scale/patch",
+	})
+}
+
+func (p *PermissionCheckResult) IsRolloutDeploymentConfigPermitted() bool {
+	return p.hasPermissions([]string{
+		"apps.openshift.io/deploymentconfigs/get",
+		"apps.openshift.io/deploymentconfigs/instantiate/create",
+	})
+}
+
 func (p *PermissionCheckResult) IsDeletePodPermitted() bool {
 	return p.hasPermissions([]string{
 		"pods/delete",
@@ -190,7 +374,7 @@ func (p *PermissionCheckResult) IsCrashLoopPodPermitted() bool {
 
 func MockAllPermitted() *PermissionCheckResult {
 	result := make(map[string]PermissionCheckOutcome)
-	for _, p := range requiredPermissions {
+	for _, p := range allRequiredPermissions() {
 		for _, verb := range p.verbs {
 			result[p.Key(verb)] = OK
 		}
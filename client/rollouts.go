@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// RolloutCustomResource is the Argo Rollout, it is watched without being declared in discovery.customResources.
+var RolloutCustomResource = extconfig.CustomResource{
+	Group:        "argoproj.io",
+	Version:      "v1alpha1",
+	Resource:     "rollouts",
+	Kind:         "Rollout",
+	ReplicasPath: "spec.replicas",
+	SelectorPath: "spec.selector",
+}
+
+// Rollout is the subset of the Argo Rollout used by the extension. The Argo Rollouts module isn't a dependency, the
+// rollouts are converted from the unstructured objects of the dynamic informer.
+type Rollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              RolloutSpec   `json:"spec,omitempty"`
+	Status            RolloutStatus `json:"status,omitempty"`
+}
+
+type RolloutSpec struct {
+	Replicas        *int32                 `json:"replicas,omitempty"`
+	Selector        *metav1.LabelSelector  `json:"selector,omitempty"`
+	Template        corev1.PodTemplateSpec `json:"template,omitempty"`
+	WorkloadRef     *RolloutWorkloadRef    `json:"workloadRef,omitempty"`
+	MinReadySeconds int32                  `json:"minReadySeconds,omitempty"`
+	Strategy        RolloutStrategy        `json:"strategy,omitempty"`
+	Paused          bool                   `json:"paused,omitempty"`
+	RestartAt       *metav1.Time           `json:"restartAt,omitempty"`
+}
+
+// RolloutWorkloadRef references a deployment providing the pod template instead of spec.template.
+type RolloutWorkloadRef struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+type RolloutStrategy struct {
+	BlueGreen map[string]interface{} `json:"blueGreen,omitempty"`
+	Canary    map[string]interface{} `json:"canary,omitempty"`
+}
+
+type RolloutStatus struct {
+	Abort             bool         `json:"abort,omitempty"`
+	Phase             string       `json:"phase,omitempty"`
+	Message           string       `json:"message,omitempty"`
+	Replicas          int32        `json:"replicas,omitempty"`
+	UpdatedReplicas   int32        `json:"updatedReplicas,omitempty"`
+	ReadyReplicas     int32        `json:"readyReplicas,omitempty"`
+	AvailableReplicas int32        `json:"availableReplicas,omitempty"`
+	RestartedAt       *metav1.Time `json:"restartedAt,omitempty"`
+}
+
+func (c *Client) Rollouts() []*Rollout {
+	objects := c.CustomResources(RolloutCustomResource.Kind)
+	result := make([]*Rollout, 0, len(objects))
+	for _, object := range objects {
+		if rollout := toRollout(object); rollout != nil {
+			result = append(result, rollout)
+		}
+	}
+	return result
+}
+
+func (c *Client) RolloutByNamespaceAndName(namespace string, name string) *Rollout {
+	object := c.CustomResourceByNamespaceAndName(RolloutCustomResource.Kind, namespace, name)
+	if object == nil {
+		return nil
+	}
+	return toRollout(object)
+}
+
+func toRollout(object *unstructured.Unstructured) *Rollout {
+	var rollout Rollout
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.UnstructuredContent(), &rollout); err != nil {
+		log.Warn().Err(err).Msgf("Failed to convert rollout %s/%s", object.GetNamespace(), object.GetName())
+		return nil
+	}
+	return &rollout
+}
+
+// isCustomResourceServed checks whether the custom resource definition is installed, the informer of a missing
+// definition would delay the startup until the sync times out.
+func isCustomResourceServed(clientset kubernetes.Interface, definition extconfig.CustomResource) bool {
+	resources, err := clientset.Discovery().ServerResourcesForGroupVersion(definition.GroupVersionResource().GroupVersion().String())
+	if err != nil {
+		log.Debug().Err(err).Msgf("%s is not served by the cluster.", definition.Kind)
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == definition.Resource {
+			return true
+		}
+	}
+	return false
+}
@@ -1,16 +1,54 @@
 package client
 
 import (
+	"github.com/steadybit/extension-kubernetes/extconfig"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"strings"
 )
 
+// retainAnnotations keeps only the annotations configured to be discovered, as annotations (e.g. the last applied
+// configuration) may be large.
+func retainAnnotations(annotations map[string]string) map[string]string {
+	if len(annotations) == 0 || len(extconfig.Config.DiscoveryAnnotations) == 0 {
+		return nil
+	}
+	var retained map[string]string
+	for key, value := range annotations {
+		if isDiscoveredAnnotation(key) {
+			if retained == nil {
+				retained = make(map[string]string)
+			}
+			retained[key] = value
+		}
+	}
+	return retained
+}
+
+// isDiscoveredAnnotation matches the key against the configured annotation keys, or prefixes ending with a wildcard.
+func isDiscoveredAnnotation(key string) bool {
+	for _, discovered := range extconfig.Config.DiscoveryAnnotations {
+		if prefix, isPrefix := strings.CutSuffix(discovered, "*"); isPrefix {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == discovered {
+			return true
+		}
+	}
+	return false
+}
+
 func transformDaemonSet(i interface{}) (interface{}, error) {
 	if d, ok := i.(*appsv1.DaemonSet); ok {
-		d.ObjectMeta.Annotations = nil
+		d.ObjectMeta.Annotations = retainAnnotations(d.ObjectMeta.Annotations)
 		d.ObjectMeta.ManagedFields = nil
-		d.Status = appsv1.DaemonSetStatus{}
+		d.Status.Conditions = nil
 		return d, nil
 	}
 	return i, nil
@@ -18,7 +56,7 @@ func transformDaemonSet(i interface{}) (interface{}, error) {
 
 func transformDeployment(i interface{}) (interface{}, error) {
 	if d, ok := i.(*appsv1.Deployment); ok {
-		d.ObjectMeta.Annotations = nil
+		d.ObjectMeta.Annotations = retainAnnotations(d.ObjectMeta.Annotations)
 		d.ObjectMeta.ManagedFields = nil
 		d.Status.Conditions = nil
 		return d, nil
@@ -28,13 +66,22 @@ func transformDeployment(i interface{}) (interface{}, error) {
 
 func transformPod(i interface{}) (interface{}, error) {
 	if pod, ok := i.(*corev1.Pod); ok {
-		pod.ObjectMeta.Annotations = nil
+		pod.ObjectMeta.Annotations = retainAnnotations(pod.ObjectMeta.Annotations)
 		pod.ObjectMeta.ManagedFields = nil
 
 		newPodSpec := corev1.PodSpec{
-			NodeName:   pod.Spec.NodeName,
-			HostPID:    pod.Spec.HostPID,
-			Containers: make([]corev1.Container, 0, len(pod.Spec.Containers)),
+			NodeName:           pod.Spec.NodeName,
+			HostPID:            pod.Spec.HostPID,
+			PriorityClassName:  pod.Spec.PriorityClassName,
+			ServiceAccountName: pod.Spec.ServiceAccountName,
+			Containers:         make([]corev1.Container, 0, len(pod.Spec.Containers)),
+		}
+		// init containers are only kept to tell sidecars (restartPolicy Always) from init containers
+		for _, container := range pod.Spec.InitContainers {
+			newPodSpec.InitContainers = append(newPodSpec.InitContainers, corev1.Container{
+				Name:          container.Name,
+				RestartPolicy: container.RestartPolicy,
+			})
 		}
 		for _, container := range pod.Spec.Containers {
 			newPodSpec.Containers = append(newPodSpec.Containers, corev1.Container{
@@ -49,9 +96,20 @@ func transformPod(i interface{}) (interface{}, error) {
 			})
 		}
 		pod.Spec = newPodSpec
-		pod.Status = corev1.PodStatus{
+		status := corev1.PodStatus{
+			Phase:             pod.Status.Phase,
+			QOSClass:          pod.Status.QOSClass,
+			PodIP:             pod.Status.PodIP,
+			PodIPs:            pod.Status.PodIPs,
+			StartTime:         pod.Status.StartTime,
 			ContainerStatuses: pod.Status.ContainerStatuses,
 		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady {
+				status.Conditions = []corev1.PodCondition{{Type: condition.Type, Status: condition.Status}}
+			}
+		}
+		pod.Status = status
 		return pod, nil
 	}
 	return i, nil
@@ -84,16 +142,16 @@ func transformService(i interface{}) (interface{}, error) {
 
 func transformStatefulSet(i interface{}) (interface{}, error) {
 	if s, ok := i.(*appsv1.StatefulSet); ok {
-		s.ObjectMeta.Annotations = nil
+		s.ObjectMeta.Annotations = retainAnnotations(s.ObjectMeta.Annotations)
 		s.ObjectMeta.ManagedFields = nil
-		s.Status = appsv1.StatefulSetStatus{}
+		s.Status.Conditions = nil
 		return s, nil
 	}
 	return i, nil
 }
 
 func transformEvents(i interface{}) (interface{}, error) {
-	if event, ok := i.(*corev1.Event); ok {
+	if event, ok := i.(*eventsv1.Event); ok {
 		event.ObjectMeta.ManagedFields = nil
 		return event, nil
 	}
@@ -102,18 +160,42 @@ func transformEvents(i interface{}) (interface{}, error) {
 
 func transformNodes(i interface{}) (interface{}, error) {
 	if node, ok := i.(*corev1.Node); ok {
-		node.ObjectMeta.Annotations = nil
+		node.ObjectMeta.Annotations = retainAnnotations(node.ObjectMeta.Annotations)
 		node.ObjectMeta.ManagedFields = nil
-		node.Spec = corev1.NodeSpec{}
+		node.Spec = corev1.NodeSpec{
+			Unschedulable: node.Spec.Unschedulable,
+			Taints:        node.Spec.Taints,
+		}
 		node.Status = corev1.NodeStatus{
-			Conditions: node.Status.Conditions,
-			Addresses:  node.Status.Addresses,
+			Conditions:  node.Status.Conditions,
+			Addresses:   node.Status.Addresses,
+			Capacity:    node.Status.Capacity,
+			Allocatable: node.Status.Allocatable,
+			NodeInfo: corev1.NodeSystemInfo{
+				KubeletVersion:          node.Status.NodeInfo.KubeletVersion,
+				ContainerRuntimeVersion: node.Status.NodeInfo.ContainerRuntimeVersion,
+				OSImage:                 node.Status.NodeInfo.OSImage,
+				KernelVersion:           node.Status.NodeInfo.KernelVersion,
+				OperatingSystem:         node.Status.NodeInfo.OperatingSystem,
+				Architecture:            node.Status.NodeInfo.Architecture,
+			},
 		}
 		return node, nil
 	}
 	return i, nil
 }
 
+func transformPodDisruptionBudget(i interface{}) (interface{}, error) {
+	if pdb, ok := i.(*policyv1.PodDisruptionBudget); ok {
+		pdb.ObjectMeta.Annotations = nil
+		pdb.ObjectMeta.ManagedFields = nil
+		pdb.Status.Conditions = nil
+		pdb.Status.DisruptedPods = nil
+		return pdb, nil
+	}
+	return i, nil
+}
+
 func transformHPA(i interface{}) (interface{}, error) {
 	if hpa, ok := i.(*autoscalingv1.HorizontalPodAutoscaler); ok {
 		hpa.ObjectMeta.Annotations = nil
@@ -122,3 +204,66 @@ func transformHPA(i interface{}) (interface{}, error) {
 	}
 	return i, nil
 }
+
+func transformNamespace(i interface{}) (interface{}, error) {
+	if ns, ok := i.(*corev1.Namespace); ok {
+		ns.ObjectMeta.Annotations = nil
+		ns.ObjectMeta.ManagedFields = nil
+		ns.Spec = corev1.NamespaceSpec{}
+		ns.Status.Conditions = nil
+		return ns, nil
+	}
+	return i, nil
+}
+
+func transformResourceQuota(i interface{}) (interface{}, error) {
+	if quota, ok := i.(*corev1.ResourceQuota); ok {
+		quota.ObjectMeta.Annotations = nil
+		quota.ObjectMeta.ManagedFields = nil
+		quota.Spec = corev1.ResourceQuotaSpec{
+			Hard: quota.Spec.Hard,
+		}
+		return quota, nil
+	}
+	return i, nil
+}
+
+func transformJob(i interface{}) (interface{}, error) {
+	if job, ok := i.(*batchv1.Job); ok {
+		job.ObjectMeta.Annotations = nil
+		job.ObjectMeta.ManagedFields = nil
+		job.Spec.Template.Spec = corev1.PodSpec{}
+		job.Status.UncountedTerminatedPods = nil
+		return job, nil
+	}
+	return i, nil
+}
+
+func transformCronJob(i interface{}) (interface{}, error) {
+	if cronJob, ok := i.(*batchv1.CronJob); ok {
+		cronJob.ObjectMeta.Annotations = nil
+		cronJob.ObjectMeta.ManagedFields = nil
+		cronJob.Spec.JobTemplate.Spec.Template.Spec = corev1.PodSpec{}
+		return cronJob, nil
+	}
+	return i, nil
+}
+
+func transformReplicationController(i interface{}) (interface{}, error) {
+	if replicationController, ok := i.(*corev1.ReplicationController); ok {
+		replicationController.ObjectMeta.Annotations = nil
+		replicationController.ObjectMeta.ManagedFields = nil
+		replicationController.Spec.Template = nil
+		return replicationController, nil
+	}
+	return i, nil
+}
+
+func transformCustomResource(i interface{}) (interface{}, error) {
+	if u, ok := i.(*unstructured.Unstructured); ok {
+		u.SetAnnotations(nil)
+		u.SetManagedFields(nil)
+		return u, nil
+	}
+	return i, nil
+}
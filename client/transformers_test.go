@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package client
+
+import (
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_retainAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		"example.com/owner":                                "team-shop",
+		"oncall.example.com/rotation":                      "shop-primary",
+		"oncall.example.com/escalation":                    "shop-secondary",
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+	}
+	tests := []struct {
+		name        string
+		discovered  []string
+		annotations map[string]string
+		want        map[string]string
+	}{
+		{
+			name:        "nothing configured",
+			annotations: annotations,
+			want:        nil,
+		},
+		{
+			name:        "keys",
+			discovered:  []string{"example.com/owner", "oncall.example.com"},
+			annotations: annotations,
+			want:        map[string]string{"example.com/owner": "team-shop"},
+		},
+		{
+			name:        "prefixes",
+			discovered:  []string{"oncall.example.com/*"},
+			annotations: annotations,
+			want:        map[string]string{"oncall.example.com/rotation": "shop-primary", "oncall.example.com/escalation": "shop-secondary"},
+		},
+		{
+			name:        "nothing matching",
+			discovered:  []string{"other.example.com/*"},
+			annotations: annotations,
+			want:        nil,
+		},
+		{
+			name:       "no annotations",
+			discovered: []string{"*"},
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			extconfig.Config.DiscoveryAnnotations = tt.discovered
+			defer func() { extconfig.Config.DiscoveryAnnotations = nil }()
+
+			// When
+			retained := retainAnnotations(tt.annotations)
+
+			// Then
+			assert.Equal(t, tt.want, retained)
+		})
+	}
+}
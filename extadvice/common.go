@@ -38,6 +38,9 @@ var ProbesContent embed.FS
 //go:embed single_replica/*
 var SingleReplicaContent embed.FS
 
+//go:embed pod_disruption_budget/*
+var PodDisruptionBudgetContent embed.FS
+
 //go:embed host_podantiaffinity/*
 var HostPodantiaffinityContent embed.FS
 
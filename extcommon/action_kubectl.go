@@ -9,6 +9,7 @@ import (
 	extension_kit "github.com/steadybit/extension-kit"
 	"github.com/steadybit/extension-kit/extcmd"
 	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"os"
@@ -27,13 +28,16 @@ type KubectlOpts struct {
 	LogTargetType               string    `json:"targetType"`
 	LogTargetName               string    `json:"targetName"`
 	LogActionName               string    `json:"actionName"`
+	// AuditTarget is the object changed by the command, which gets the audit trail of the attack.
+	AuditTarget *ExecutionTarget `json:"auditTarget,omitempty"`
 }
 
 type KubectlActionState struct {
-	Opts             KubectlOpts `json:"opts"`
-	CmdStateID       string      `json:"cmdStateId"`
-	Pid              int         `json:"pid"`
-	CommandCompleted bool        `json:"commandCompleted"`
+	Opts             KubectlOpts  `json:"opts"`
+	CmdStateID       string       `json:"cmdStateId"`
+	Pid              int          `json:"pid"`
+	CommandCompleted bool         `json:"commandCompleted"`
+	Audit            *AttackAudit `json:"audit,omitempty"`
 }
 
 type KubectlOptsProvider func(ctx context.Context, request action_kit_api.PrepareActionRequestBody) (*KubectlOpts, error)
@@ -66,6 +70,14 @@ func (a KubectlAction) Prepare(ctx context.Context, state *KubectlActionState, r
 		}
 	}
 	state.Opts = *opts
+	if opts.AuditTarget != nil {
+		state.Audit = NewAttackAudit(request, *opts.AuditTarget, opts.LogActionName)
+	}
+	kind := opts.LogTargetType
+	if opts.AuditTarget != nil {
+		kind = opts.AuditTarget.Kind
+	}
+	RememberExecutionTarget(request, kind)
 	return nil, nil
 }
 
@@ -81,6 +93,7 @@ func (a KubectlAction) Start(_ context.Context, state *KubectlActionState) (*act
 	if err != nil {
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to %s.", state.Opts.LogActionName), err)
 	}
+	state.Audit.Started(client.K8S)
 
 	state.Pid = cmd.Process.Pid
 	go func() {
@@ -245,7 +258,9 @@ func (a KubectlAction) Stop(_ context.Context, state *KubectlActionState) (*acti
 		log.Debug().
 			Str(state.Opts.LogTargetType, state.Opts.LogTargetName).
 			Msgf("Rollback completed.")
+		state.Audit.RolledBack(client.K8S)
 	}
+	state.Audit.Stop(client.K8S)
 
 	messages := make([]action_kit_api.Message, 0)
 	messages = append(messages, action_kit_api.Message{
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	"time"
+)
+
+const (
+	AttackInProgressAnnotation = "steadybit.com/attack-in-progress"
+	AttackStartedReason        = "SteadybitAttackStarted"
+	AttackStoppedReason        = "SteadybitAttackStopped"
+	AttackRolledBackReason     = "SteadybitAttackRolledBack"
+)
+
+// AttackAudit writes the audit trail of an attack back to the cluster: an event on the attacked object for every
+// start, rollback and stop and an annotation on the object while the attack is in progress. This way, engineers
+// looking at the object with `kubectl describe` understand why it was changed. The audit trail is best effort, failures
+// are logged but don't fail the attack.
+type AttackAudit struct {
+	Target      ExecutionTarget `json:"target"`
+	Action      string          `json:"action"`
+	ExecutionId *int            `json:"executionId,omitempty"`
+	Stopped     bool            `json:"stopped,omitempty"`
+}
+
+// NewAttackAudit returns nil if the audit trail is disabled. All methods of AttackAudit can be called on nil.
+func NewAttackAudit(request action_kit_api.PrepareActionRequestBody, target ExecutionTarget, action string) *AttackAudit {
+	if extconfig.Config.DisableAuditTrail {
+		return nil
+	}
+	audit := &AttackAudit{
+		Target: target,
+		Action: action,
+	}
+	if request.ExecutionContext != nil {
+		audit.ExecutionId = request.ExecutionContext.ExecutionId
+	}
+	return audit
+}
+
+func (a *AttackAudit) Started(k8s *client.Client) {
+	if a == nil {
+		return
+	}
+	a.event(k8s, AttackStartedReason, "Start", fmt.Sprintf("%s started.", a.description()))
+	a.annotate(k8s, extutil.Ptr(fmt.Sprintf("%s since %s", a.description(), time.Now().UTC().Format(time.RFC3339))))
+}
+
+func (a *AttackAudit) RolledBack(k8s *client.Client) {
+	if a == nil {
+		return
+	}
+	a.event(k8s, AttackRolledBackReason, "Rollback", fmt.Sprintf("%s rolled back.", a.description()))
+}
+
+// Stop can be called multiple times, the attack is reported as stopped only once.
+func (a *AttackAudit) Stop(k8s *client.Client) {
+	if a == nil || a.Stopped {
+		return
+	}
+	a.Stopped = true
+	a.event(k8s, AttackStoppedReason, "Stop", fmt.Sprintf("%s stopped.", a.description()))
+	a.annotate(k8s, nil)
+}
+
+func (a *AttackAudit) description() string {
+	if a.ExecutionId != nil {
+		return fmt.Sprintf("Steadybit attack '%s' of experiment execution %d", a.Action, *a.ExecutionId)
+	}
+	return fmt.Sprintf("Steadybit attack '%s'", a.Action)
+}
+
+func (a *AttackAudit) event(k8s *client.Client, reason string, action string, note string) {
+	if !k8s.Permissions().CanCreateEvents() {
+		return
+	}
+	if err := k8s.CreateEvent(a.Target.Kind, a.Target.Namespace, a.Target.Name, corev1.EventTypeNormal, reason, action, note); err != nil {
+		log.Warn().Err(err).Msgf("Failed to create %s event for %s %s/%s", reason, a.Target.Kind, a.Target.Namespace, a.Target.Name)
+	}
+}
+
+func (a *AttackAudit) annotate(k8s *client.Client, value *string) {
+	if !k8s.Permissions().IsAnnotatePermitted(a.Target.Kind) {
+		return
+	}
+	if err := k8s.AnnotateObject(a.Target.Kind, a.Target.Namespace, a.Target.Name, AttackInProgressAnnotation, value); err != nil {
+		log.Warn().Err(err).Msgf("Failed to update annotation %s of %s %s/%s", AttackInProgressAnnotation, a.Target.Kind, a.Target.Namespace, a.Target.Name)
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+)
+
+var auditTarget = ExecutionTarget{Namespace: "shop", Kind: "Deployment", Name: "checkout"}
+
+func TestAttackAuditWritesEventsAndAnnotation(t *testing.T) {
+	// Given
+	k8sClient, clientset := createAttackAuditTestClient(t)
+	audit := NewAttackAudit(action_kit_api.PrepareActionRequestBody{
+		ExecutionContext: &action_kit_api.ExecutionContext{ExecutionId: extutil.Ptr(42)},
+	}, auditTarget, "scale deployment")
+	require.NotNil(t, audit)
+
+	// When
+	audit.Started(k8sClient)
+
+	// Then
+	events, err := clientset.EventsV1().Events("shop").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 1)
+	assert.Equal(t, AttackStartedReason, events.Items[0].Reason)
+	assert.Equal(t, "Steadybit attack 'scale deployment' of experiment execution 42 started.", events.Items[0].Note)
+	assert.Equal(t, "checkout-uid", string(events.Items[0].Regarding.UID))
+	assert.Contains(t, annotations(t, clientset)[AttackInProgressAnnotation], "Steadybit attack 'scale deployment' of experiment execution 42 since ")
+
+	// When
+	audit.Stop(k8sClient)
+	audit.Stop(k8sClient)
+
+	// Then
+	events, err = clientset.EventsV1().Events("shop").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 2)
+	assert.NotContains(t, annotations(t, clientset), AttackInProgressAnnotation)
+}
+
+func TestAttackAuditIsDisabled(t *testing.T) {
+	// Given
+	k8sClient, clientset := createAttackAuditTestClient(t)
+	extconfig.Config.DisableAuditTrail = true
+	defer func() { extconfig.Config.DisableAuditTrail = false }()
+
+	// When
+	audit := NewAttackAudit(action_kit_api.PrepareActionRequestBody{}, auditTarget, "scale deployment")
+	audit.Started(k8sClient)
+	audit.RolledBack(k8sClient)
+	audit.Stop(k8sClient)
+
+	// Then
+	assert.Nil(t, audit)
+	events, err := clientset.EventsV1().Events("shop").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, events.Items)
+	assert.NotContains(t, annotations(t, clientset), AttackInProgressAnnotation)
+}
+
+func annotations(t *testing.T, clientset *testclient.Clientset) map[string]string {
+	deployment, err := clientset.AppsV1().Deployments("shop").Get(context.Background(), "checkout", metav1.GetOptions{})
+	require.NoError(t, err)
+	return deployment.Annotations
+}
+
+func createAttackAuditTestClient(t *testing.T) (*client.Client, *testclient.Clientset) {
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.AppsV1().Deployments("shop").Create(context.Background(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop", UID: "checkout-uid"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	return client.CreateClient(clientset, stopCh, "", client.MockAllPermitted()), clientset
+}
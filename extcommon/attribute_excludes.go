@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+)
+
+// ApplyAttributeExcludes removes the attributes matching the excludes, which may be exact attribute names, globs or
+// regular expressions (see extconfig.MatchesAny). The given targets aren't modified.
+func ApplyAttributeExcludes(targets []discovery_kit_api.Target, excludes []string) []discovery_kit_api.Target {
+	if len(excludes) == 0 {
+		return targets
+	}
+	result := make([]discovery_kit_api.Target, len(targets))
+	for i, target := range targets {
+		result[i] = target
+		result[i].Attributes = applyExcludesToAttributes(target.Attributes, excludes)
+	}
+	return result
+}
+
+// ApplyAttributeExcludesToEnrichmentData is the ApplyAttributeExcludes counterpart for enrichment data.
+func ApplyAttributeExcludesToEnrichmentData(enrichmentData []discovery_kit_api.EnrichmentData, excludes []string) []discovery_kit_api.EnrichmentData {
+	if len(excludes) == 0 {
+		return enrichmentData
+	}
+	result := make([]discovery_kit_api.EnrichmentData, len(enrichmentData))
+	for i, data := range enrichmentData {
+		result[i] = data
+		result[i].Attributes = applyExcludesToAttributes(data.Attributes, excludes)
+	}
+	return result
+}
+
+func applyExcludesToAttributes(attributes map[string][]string, excludes []string) map[string][]string {
+	result := make(map[string][]string, len(attributes))
+	for key, value := range attributes {
+		if !extconfig.MatchesAny(excludes, key) {
+			result[key] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_ApplyAttributeExcludes(t *testing.T) {
+	// Given
+	targets := []discovery_kit_api.Target{{
+		Id: "target",
+		Attributes: map[string][]string{
+			"k8s.deployment": {"shop"},
+			"k8s.label.app":  {"shop"},
+			"k8s.deployment.label.sidecar.istio.io/inject": {"true"},
+			"k8s.container.spec.name":                      {"shop"},
+		},
+	}}
+
+	// When
+	result := ApplyAttributeExcludes(targets, []string{"k8s.label.*", `/^.+\.istio\.io\/.+$/`, "k8s.container.spec.name"})
+
+	// Then
+	assert.Equal(t, map[string][]string{"k8s.deployment": {"shop"}}, result[0].Attributes)
+	assert.Len(t, targets[0].Attributes, 4, "input must not be modified")
+}
+
+func Test_ApplyAttributeExcludesToEnrichmentData(t *testing.T) {
+	// Given
+	data := []discovery_kit_api.EnrichmentData{{
+		Id: "container",
+		Attributes: map[string][]string{
+			"k8s.pod.name":       {"shop-1"},
+			"k8s.pod.label.tier": {"1"},
+		},
+	}}
+
+	// When
+	result := ApplyAttributeExcludesToEnrichmentData(data, []string{"k8s.pod.label.tier?"})
+
+	// Then
+	assert.Equal(t, map[string][]string{"k8s.pod.label.tier": {"1"}, "k8s.pod.name": {"shop-1"}}, result[0].Attributes)
+	assert.Equal(t, map[string][]string{"k8s.pod.name": {"shop-1"}}, ApplyAttributeExcludesToEnrichmentData(data, []string{"k8s.pod.label.*"})[0].Attributes)
+}
@@ -31,6 +31,20 @@ func (a *attributeDescriber) DescribeAttributes() []discovery_kit_api.AttributeD
 				Other: "Namespace names",
 			},
 		},
+		{
+			Attribute: "k8s.namespace.pod-count",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Pod count",
+				Other: "Pod counts",
+			},
+		},
+		{
+			Attribute: "k8s.namespace.pod-security.enforce",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Pod security level",
+				Other: "Pod security levels",
+			},
+		},
 		{
 			Attribute: "k8s.cluster-name",
 			Label: discovery_kit_api.PluralLabel{
@@ -59,5 +73,145 @@ func (a *attributeDescriber) DescribeAttributes() []discovery_kit_api.AttributeD
 				Other: "DaemonSet names",
 			},
 		},
+		{
+			Attribute: "k8s.job",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Job name",
+				Other: "Job names",
+			},
+		},
+		{
+			Attribute: "k8s.cronjob",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "CronJob name",
+				Other: "CronJob names",
+			},
+		},
+		{
+			Attribute: "k8s.rollout",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Rollout name",
+				Other: "Rollout names",
+			},
+		},
+		{
+			Attribute: "k8s.deploymentconfig",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "DeploymentConfig name",
+				Other: "DeploymentConfig names",
+			},
+		},
+		{
+			Attribute: "k8s.replicationcontroller",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "ReplicationController name",
+				Other: "ReplicationController names",
+			},
+		},
+		{
+			Attribute: "k8s.route",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Route name",
+				Other: "Route names",
+			},
+		},
+		{
+			Attribute: "k8s.route.host",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Route host",
+				Other: "Route hosts",
+			},
+		},
+		{
+			Attribute: "k8s.pod.phase",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Pod phase",
+				Other: "Pod phases",
+			},
+		},
+		{
+			Attribute: "k8s.pod.qos-class",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Pod QoS class",
+				Other: "Pod QoS classes",
+			},
+		},
+		{
+			Attribute: "k8s.pod.ip",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Pod IP",
+				Other: "Pod IPs",
+			},
+		},
+		{
+			Attribute: "k8s.pod.priority-class",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Pod priority class",
+				Other: "Pod priority classes",
+			},
+		},
+		{
+			Attribute: "k8s.pod.service-account",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Pod service account",
+				Other: "Pod service accounts",
+			},
+		},
+		{
+			Attribute: "k8s.pod.restart-count",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Pod restart count",
+				Other: "Pod restart counts",
+			},
+		},
+		{
+			Attribute: "k8s.node.zone",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Node zone",
+				Other: "Node zones",
+			},
+		},
+		{
+			Attribute: "k8s.node.region",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Node region",
+				Other: "Node regions",
+			},
+		},
+		{
+			Attribute: "k8s.node.instance-type",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Node instance type",
+				Other: "Node instance types",
+			},
+		},
+		{
+			Attribute: "k8s.node.pool",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Node pool",
+				Other: "Node pools",
+			},
+		},
+		{
+			Attribute: "k8s.node.capacity-type",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Node capacity type",
+				Other: "Node capacity types",
+			},
+		},
+		{
+			Attribute: "k8s.node.taint",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Node taint",
+				Other: "Node taints",
+			},
+		},
+		{
+			Attribute: "k8s.node.kubelet-version",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Kubelet version",
+				Other: "Kubelet versions",
+			},
+		},
 	}
 }
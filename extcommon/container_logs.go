@@ -0,0 +1,480 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ContainerLogsLogType = "KUBERNETES_CONTAINER_LOGS"
+	// containerLogsLimitBytes limits the logs fetched per container and poll. Logs exceeding the limit are fetched by
+	// the next poll.
+	containerLogsLimitBytes = 1024 * 1024
+	// containerLogsFetchTimeout bounds the fetching of the logs per poll below the status call interval. Logs not fetched
+	// in time are fetched by the next poll.
+	containerLogsFetchTimeout = 1500 * time.Millisecond
+	// containerLogsFetchConcurrency limits the containers whose logs are fetched in parallel.
+	containerLogsFetchConcurrency = 10
+)
+
+var logLevelPattern = regexp.MustCompile(`(?i)\b(fatal|panic|error|err|warn|warning|info|debug|trace)\b`)
+
+// ContainerLogsAction follows the logs of the containers of a pod or of the pods of a workload. The pods are resolved
+// on every poll, so that the logs of pods created during the experiment (e.g. by a rollout restart) are included.
+type ContainerLogsAction struct {
+	Id                string
+	TargetType        string
+	WorkloadAttribute string
+	WorkloadLabel     string
+}
+
+type ContainerLogsState struct {
+	End         time.Time
+	Start       time.Time
+	Namespace   string
+	Workload    string
+	Containers  []string
+	Pattern     string
+	DetectLevel bool
+	// LastTimestamps are the timestamps of the last reported line, keyed by pod/container.
+	LastTimestamps map[string]time.Time
+	// LastTimestampCounts are the numbers of reported lines with the last timestamp, keyed by pod/container.
+	LastTimestampCounts map[string]int
+	// RestartCounts are the restart counts of the containers at the last poll, keyed by pod/container.
+	RestartCounts map[string]int32
+}
+
+type ContainerLogsConfig struct {
+	Duration       int
+	ContainerNames []string
+	Pattern        string
+	DetectLevel    bool
+}
+
+type logLine struct {
+	timestamp time.Time
+	text      string
+}
+
+// logRequest fetches the logs of a container since the last reported line, or of its previous instance after a restart.
+type logRequest struct {
+	pod       *corev1.Pod
+	container string
+	key       string
+	since     time.Time
+	// reportedAtSince is the number of lines with the since timestamp, which were already reported.
+	reportedAtSince int
+	previous        bool
+	restartCount    int32
+	lines           []logLine
+	truncated       bool
+	err             error
+}
+
+var _ action_kit_sdk.Action[ContainerLogsState] = (*ContainerLogsAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[ContainerLogsState] = (*ContainerLogsAction)(nil)
+var _ action_kit_sdk.ActionWithStop[ContainerLogsState] = (*ContainerLogsAction)(nil)
+
+func (a ContainerLogsAction) NewEmptyState() ContainerLogsState {
+	return ContainerLogsState{}
+}
+
+func (a ContainerLogsAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          a.Id,
+		Label:       "Kubernetes Container Logs",
+		Description: fmt.Sprintf("Collect the container logs of a %s", a.WorkloadLabel),
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr("data:image/svg+xml,%3Csvg%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%20width%3D%2224%22%20height%3D%2224%22%20fill%3D%22none%22%20viewBox%3D%220%200%2024%2024%22%3E%3Cpath%20fill%3D%22currentColor%22%20d%3D%22M6%202a2%202%200%200%200-2%202v16a2%202%200%200%200%202%202h12a2%202%200%200%200%202-2V8l-6-6H6zm7%201.5L18.5%209H13V3.5zM7%2012h10v2H7v-2zm0%204h10v2H7v-2z%22%2F%3E%3C%2Fsvg%3E"),
+		Category:    extutil.Ptr("Kubernetes"),
+		Kind:        action_kit_api.Other,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          a.TargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr(fmt.Sprintf("Find %s by cluster, namespace and name", a.WorkloadLabel)),
+					Query:       fmt.Sprintf("k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND %s=\"\"", a.WorkloadAttribute),
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the logs be collected."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "containerNames",
+				Label:       "Container names",
+				Description: extutil.Ptr("Collect only the logs of these containers. Leave empty for all containers."),
+				Type:        action_kit_api.StringArray,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(false),
+			},
+			{
+				Name:        "pattern",
+				Label:       "Pattern",
+				Description: extutil.Ptr("Collect only log lines matching this regular expression. Leave empty for all lines."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(3),
+				Required:    extutil.Ptr(false),
+			},
+			{
+				Name:         "detectLevel",
+				Label:        "Detect log level",
+				Description:  extutil.Ptr("Derive the level of a log line from level keywords like ERROR or WARN. Otherwise all lines are reported as info."),
+				Type:         action_kit_api.Boolean,
+				DefaultValue: extutil.Ptr("true"),
+				Order:        extutil.Ptr(4),
+				Required:     extutil.Ptr(false),
+				Advanced:     extutil.Ptr(true),
+			},
+		},
+		Widgets: extutil.Ptr([]action_kit_api.Widget{
+			action_kit_api.LogWidget{
+				Type:    action_kit_api.ComSteadybitWidgetLog,
+				Title:   "Container Logs",
+				LogType: ContainerLogsLogType,
+			},
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("2s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a ContainerLogsAction) Prepare(_ context.Context, state *ContainerLogsState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return a.prepareInternal(client.K8S, state, request)
+}
+
+func (a ContainerLogsAction) prepareInternal(k8s *client.Client, state *ContainerLogsState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config ContainerLogsConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	if _, err := regexp.Compile(config.Pattern); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Invalid pattern '%s'.", config.Pattern), err)
+	}
+
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Workload = request.Target.Attributes[a.WorkloadAttribute][0]
+	if _, found := a.pods(k8s, state.Namespace, state.Workload); !found {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to find %s %s/%s.", a.WorkloadLabel, state.Namespace, state.Workload), nil)
+	}
+
+	state.End = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Containers = config.ContainerNames
+	state.Pattern = config.Pattern
+	state.DetectLevel = config.DetectLevel
+	return nil, nil
+}
+
+func (a ContainerLogsAction) Start(_ context.Context, state *ContainerLogsState) (*action_kit_api.StartResult, error) {
+	state.Start = time.Now()
+	return nil, nil
+}
+
+func (a ContainerLogsAction) Status(ctx context.Context, state *ContainerLogsState) (*action_kit_api.StatusResult, error) {
+	return a.statusInternal(ctx, client.K8S, state), nil
+}
+
+func (a ContainerLogsAction) statusInternal(ctx context.Context, k8s *client.Client, state *ContainerLogsState) *action_kit_api.StatusResult {
+	completed := time.Now().After(state.End)
+	return &action_kit_api.StatusResult{
+		Completed: completed,
+		Messages:  extutil.Ptr(a.collectLogs(ctx, k8s, state)),
+	}
+}
+
+func (a ContainerLogsAction) Stop(ctx context.Context, state *ContainerLogsState) (*action_kit_api.StopResult, error) {
+	return a.stopInternal(ctx, client.K8S, state), nil
+}
+
+func (a ContainerLogsAction) stopInternal(ctx context.Context, k8s *client.Client, state *ContainerLogsState) *action_kit_api.StopResult {
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr(a.collectLogs(ctx, k8s, state)),
+	}
+}
+
+func (a ContainerLogsAction) pods(k8s *client.Client, namespace string, name string) ([]*corev1.Pod, bool) {
+	var selector *metav1.LabelSelector
+	switch a.WorkloadAttribute {
+	case "k8s.pod.name":
+		if pod := k8s.PodByNamespaceAndName(namespace, name); pod != nil {
+			return []*corev1.Pod{pod}, true
+		}
+		return nil, false
+	case "k8s.deployment":
+		if deployment := k8s.DeploymentByNamespaceAndName(namespace, name); deployment != nil {
+			selector = deployment.Spec.Selector
+		}
+	case "k8s.statefulset":
+		if statefulSet := k8s.StatefulSetByNamespaceAndName(namespace, name); statefulSet != nil {
+			selector = statefulSet.Spec.Selector
+		}
+	case "k8s.daemonset":
+		if daemonSet := k8s.DaemonSetByNamespaceAndName(namespace, name); daemonSet != nil {
+			selector = daemonSet.Spec.Selector
+		}
+	}
+	if selector == nil {
+		return nil, false
+	}
+	return k8s.PodsByLabelSelector(selector, namespace), true
+}
+
+func (a ContainerLogsAction) collectLogs(ctx context.Context, k8s *client.Client, state *ContainerLogsState) []action_kit_api.Message {
+	if state.LastTimestamps == nil {
+		state.LastTimestamps = make(map[string]time.Time)
+	}
+	if state.LastTimestampCounts == nil {
+		state.LastTimestampCounts = make(map[string]int)
+	}
+	if state.RestartCounts == nil {
+		state.RestartCounts = make(map[string]int32)
+	}
+	pattern := regexp.MustCompile(state.Pattern)
+
+	pods, _ := a.pods(k8s, state.Namespace, state.Workload)
+	var requests []*logRequest
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			if len(state.Containers) > 0 && !slices.Contains(state.Containers, container.Name) {
+				continue
+			}
+			key := pod.Name + "/" + container.Name
+			since, ok := state.LastTimestamps[key]
+			if !ok {
+				since = state.Start
+			}
+			reportedAtSince := state.LastTimestampCounts[key]
+
+			status := containerStatus(pod, container.Name)
+			if status != nil {
+				// the logs of a crashed container are only available as logs of the previous instance
+				if previousCount, ok := state.RestartCounts[key]; ok && status.RestartCount > previousCount {
+					requests = append(requests, &logRequest{pod: pod, container: container.Name, key: key, since: since, reportedAtSince: reportedAtSince, previous: true, restartCount: status.RestartCount})
+				} else {
+					state.RestartCounts[key] = status.RestartCount
+				}
+			}
+			if status == nil || status.State.Waiting == nil {
+				requests = append(requests, &logRequest{pod: pod, container: container.Name, key: key, since: since, reportedAtSince: reportedAtSince})
+			}
+		}
+	}
+
+	fetchLogs(ctx, k8s, requests)
+
+	// the logs of a container, whose logs weren't fetched completely, are fetched again by the next poll
+	failed := make(map[string]bool)
+	for _, request := range requests {
+		if request.err != nil {
+			failed[request.key] = true
+		}
+	}
+
+	var messages []action_kit_api.Message
+	for _, request := range requests {
+		if failed[request.key] {
+			continue
+		}
+		if request.previous {
+			state.RestartCounts[request.key] = request.restartCount
+		}
+		messages = append(messages, reportLogLines(state, request, pattern)...)
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Timestamp.Before(*messages[j].Timestamp)
+	})
+	return messages
+}
+
+// reportLogLines returns the messages of the fetched lines, which weren't reported yet, and advances the position of the
+// container in its logs. The since time of the logs api has second precision, the lines of the second before the last
+// reported line are returned again. Older lines are skipped, lines with the timestamp of the last reported line are
+// skipped as often as they were reported.
+func reportLogLines(state *ContainerLogsState, request *logRequest, pattern *regexp.Regexp) []action_kit_api.Message {
+	var messages []action_kit_api.Message
+	progressed := false
+	seenAtSince := 0
+	for _, line := range request.lines {
+		if line.timestamp.Before(request.since) {
+			continue
+		}
+		if line.timestamp.Equal(request.since) {
+			seenAtSince++
+			if seenAtSince <= request.reportedAtSince {
+				continue
+			}
+		}
+
+		progressed = true
+		if last := state.LastTimestamps[request.key]; line.timestamp.After(last) {
+			state.LastTimestamps[request.key] = line.timestamp
+			state.LastTimestampCounts[request.key] = 1
+		} else if line.timestamp.Equal(last) {
+			state.LastTimestampCounts[request.key]++
+		}
+		if !pattern.MatchString(line.text) {
+			continue
+		}
+		messages = append(messages, toLogMessage(request.pod, request.container, line, state.DetectLevel))
+	}
+
+	if next := request.since.Truncate(time.Second).Add(time.Second); request.truncated && !progressed && next.After(state.LastTimestamps[request.key]) {
+		// the limit was exceeded by the lines of the second of the since time, every poll would fetch them again
+		log.Warn().Msgf("Container %s/%s/%s logged more than %d bytes within a second, skipping the logs until %s.", request.pod.Namespace, request.pod.Name, request.container, containerLogsLimitBytes, next.Format(time.RFC3339))
+		state.LastTimestamps[request.key] = next
+		state.LastTimestampCounts[request.key] = 0
+	}
+	return messages
+}
+
+// fetchLogs fetches the logs of the requests in parallel. Requests not completed within containerLogsFetchTimeout fail.
+func fetchLogs(ctx context.Context, k8s *client.Client, requests []*logRequest) {
+	ctx, cancel := context.WithTimeout(ctx, containerLogsFetchTimeout)
+	defer cancel()
+
+	queue := make(chan *logRequest)
+	var wg sync.WaitGroup
+	for i := 0; i < min(containerLogsFetchConcurrency, len(requests)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for request := range queue {
+				fetchLogLines(ctx, k8s, request)
+			}
+		}()
+	}
+	for _, request := range requests {
+		queue <- request
+	}
+	close(queue)
+	wg.Wait()
+}
+
+func containerStatus(pod *corev1.Pod, container string) *corev1.ContainerStatus {
+	for i, status := range pod.Status.ContainerStatuses {
+		if status.Name == container {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+func fetchLogLines(ctx context.Context, k8s *client.Client, request *logRequest) {
+	pod := request.pod
+	logs, err := k8s.ContainerLogs(ctx, pod.Namespace, pod.Name, &corev1.PodLogOptions{
+		Container:  request.container,
+		Previous:   request.previous,
+		Timestamps: true,
+		SinceTime:  &metav1.Time{Time: request.since},
+		LimitBytes: extutil.Ptr(int64(containerLogsLimitBytes)),
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			log.Debug().Err(err).Msgf("Fetching logs of container %s/%s/%s timed out", pod.Namespace, pod.Name, request.container)
+			request.err = err
+			return
+		}
+		// e.g. the container was not started yet or the pod was deleted
+		log.Debug().Err(err).Msgf("Failed to fetch logs of container %s/%s/%s", pod.Namespace, pod.Name, request.container)
+		return
+	}
+	request.truncated = len(logs) >= containerLogsLimitBytes
+	request.lines = parseLogLines(logs, request.truncated, time.Now())
+}
+
+// parseLogLines splits logs fetched with timestamps into lines. Lines without a timestamp get the given time. If the
+// logs were truncated, the last incomplete line is skipped, it will be fetched again by the next poll.
+func parseLogLines(logs []byte, truncated bool, now time.Time) []logLine {
+	if truncated {
+		if i := bytes.LastIndexByte(logs, '\n'); i >= 0 {
+			logs = logs[:i]
+		}
+	}
+
+	var result []logLine
+	for _, line := range strings.Split(string(logs), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			continue
+		}
+		timestamp, text, found := strings.Cut(line, " ")
+		if parsed, err := time.Parse(time.RFC3339Nano, timestamp); found && err == nil {
+			result = append(result, logLine{timestamp: parsed, text: text})
+		} else {
+			result = append(result, logLine{timestamp: now, text: line})
+		}
+	}
+	return result
+}
+
+func toLogMessage(pod *corev1.Pod, container string, line logLine, detectLevel bool) action_kit_api.Message {
+	level := action_kit_api.Info
+	if detectLevel {
+		level = detectLogLevel(line.text)
+	}
+	return action_kit_api.Message{
+		Message:         line.text,
+		Type:            extutil.Ptr(ContainerLogsLogType),
+		Level:           extutil.Ptr(level),
+		Timestamp:       extutil.Ptr(line.timestamp),
+		TimestampSource: extutil.Ptr(action_kit_api.TimestampSourceExternal),
+		Fields: extutil.Ptr(action_kit_api.MessageFields{
+			"cluster-name": extconfig.Config.ClusterName,
+			"namespace":    pod.Namespace,
+			"pod":          pod.Name,
+			"container":    container,
+		}),
+	}
+}
+
+// detectLogLevel returns the level of the first level keyword in the line, which is usually the level field of the
+// log format.
+func detectLogLevel(line string) action_kit_api.MessageLevel {
+	match := logLevelPattern.FindString(line)
+	switch strings.ToLower(match) {
+	case "fatal", "panic", "error", "err":
+		return action_kit_api.Error
+	case "warn", "warning":
+		return action_kit_api.Warn
+	case "debug", "trace":
+		return action_kit_api.Debug
+	default:
+		return action_kit_api.Info
+	}
+}
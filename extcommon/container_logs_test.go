@@ -0,0 +1,406 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var testContainerLogsAction = ContainerLogsAction{
+	Id:                "test",
+	TargetType:        "deployment",
+	WorkloadAttribute: "k8s.deployment",
+	WorkloadLabel:     "Deployment",
+}
+
+func TestParseLogLines(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		logs      string
+		truncated bool
+		want      []logLine
+	}{
+		{
+			name: "lines with timestamps",
+			logs: "2024-03-01T11:59:58.123456789Z starting server\r\n2024-03-01T11:59:59Z listening on :8080\n",
+			want: []logLine{
+				{timestamp: time.Date(2024, 3, 1, 11, 59, 58, 123456789, time.UTC), text: "starting server"},
+				{timestamp: time.Date(2024, 3, 1, 11, 59, 59, 0, time.UTC), text: "listening on :8080"},
+			},
+		},
+		{
+			name: "line without timestamp",
+			logs: "fake logs",
+			want: []logLine{
+				{timestamp: now, text: "fake logs"},
+			},
+		},
+		{
+			name:      "truncated logs",
+			logs:      "2024-03-01T11:59:58Z first\n2024-03-01T11:59:59Z sec",
+			truncated: true,
+			want: []logLine{
+				{timestamp: time.Date(2024, 3, 1, 11, 59, 58, 0, time.UTC), text: "first"},
+			},
+		},
+		{
+			name: "empty logs",
+			logs: "",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseLogLines([]byte(tt.logs), tt.truncated, now))
+		})
+	}
+}
+
+func TestDetectLogLevel(t *testing.T) {
+	tests := []struct {
+		line string
+		want action_kit_api.MessageLevel
+	}{
+		{"ERROR connection refused", action_kit_api.Error},
+		{`{"level":"error","msg":"connection refused"}`, action_kit_api.Error},
+		{"level=warn msg=\"slow response\"", action_kit_api.Warn},
+		{"[WARNING] disk almost full", action_kit_api.Warn},
+		{"DEBUG cache miss", action_kit_api.Debug},
+		{"panic: runtime error: index out of range", action_kit_api.Error},
+		{"INFO 3 errors ignored", action_kit_api.Info},
+		{"listening on :8080", action_kit_api.Info},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectLogLevel(tt.line))
+		})
+	}
+}
+
+func TestPrepareContainerLogsExtractsState(t *testing.T) {
+	// Given
+	k8sClient := createContainerLogsTestClient(t, corev1.ContainerStatus{Name: "app"})
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":       1000 * 10,
+			"containerNames": []string{"app"},
+			"pattern":        "error|warn",
+			"detectLevel":    true,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+			},
+		}),
+	}
+	state := testContainerLogsAction.NewEmptyState()
+
+	// When
+	_, err := testContainerLogsAction.prepareInternal(k8sClient, &state, request)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, state.End.After(time.Now()))
+	assert.Equal(t, "shop", state.Namespace)
+	assert.Equal(t, "checkout", state.Workload)
+	assert.Equal(t, []string{"app"}, state.Containers)
+	assert.Equal(t, "error|warn", state.Pattern)
+	assert.True(t, state.DetectLevel)
+}
+
+func TestPrepareContainerLogsFails(t *testing.T) {
+	k8sClient := createContainerLogsTestClient(t, corev1.ContainerStatus{Name: "app"})
+
+	tests := []struct {
+		name       string
+		deployment string
+		pattern    string
+		wantError  string
+	}{
+		{
+			name:       "invalid pattern",
+			deployment: "checkout",
+			pattern:    "error(",
+			wantError:  "Invalid pattern 'error('.",
+		},
+		{
+			name:       "unknown deployment",
+			deployment: "cart",
+			wantError:  "Failed to find Deployment shop/cart.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := action_kit_api.PrepareActionRequestBody{
+				Config: map[string]interface{}{
+					"duration": 1000 * 10,
+					"pattern":  tt.pattern,
+				},
+				Target: extutil.Ptr(action_kit_api.Target{
+					Attributes: map[string][]string{
+						"k8s.namespace":  {"shop"},
+						"k8s.deployment": {tt.deployment},
+					},
+				}),
+			}
+			state := testContainerLogsAction.NewEmptyState()
+
+			_, err := testContainerLogsAction.prepareInternal(k8sClient, &state, request)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
+
+func TestContainerLogsStatusReportsLogsOfSelectedContainers(t *testing.T) {
+	// Given
+	k8sClient := createContainerLogsTestClient(t, corev1.ContainerStatus{Name: "app"})
+	state := ContainerLogsState{
+		End:        time.Now().Add(time.Minute),
+		Start:      time.Now().Add(-time.Minute),
+		Namespace:  "shop",
+		Workload:   "checkout",
+		Containers: []string{"app"},
+	}
+
+	// When
+	result := testContainerLogsAction.statusInternal(context.Background(), k8sClient, &state)
+
+	// Then
+	assert.False(t, result.Completed)
+	require.Len(t, *result.Messages, 1)
+	message := (*result.Messages)[0]
+	// the fake clientset always returns "fake logs"
+	assert.Equal(t, "fake logs", message.Message)
+	assert.Equal(t, ContainerLogsLogType, *message.Type)
+	assert.Equal(t, action_kit_api.Info, *message.Level)
+	assert.Equal(t, "checkout-1", (*message.Fields)["pod"])
+	assert.Equal(t, "app", (*message.Fields)["container"])
+	assert.Equal(t, "shop", (*message.Fields)["namespace"])
+	assert.Contains(t, state.LastTimestamps, "checkout-1/app")
+	assert.Equal(t, int32(0), state.RestartCounts["checkout-1/app"])
+}
+
+func TestContainerLogsStatusFiltersByPattern(t *testing.T) {
+	// Given
+	k8sClient := createContainerLogsTestClient(t, corev1.ContainerStatus{Name: "app"})
+	state := ContainerLogsState{
+		End:       time.Now().Add(time.Minute),
+		Start:     time.Now().Add(-time.Minute),
+		Namespace: "shop",
+		Workload:  "checkout",
+		Pattern:   "error",
+	}
+
+	// When
+	result := testContainerLogsAction.statusInternal(context.Background(), k8sClient, &state)
+
+	// Then
+	assert.Empty(t, *result.Messages)
+	assert.Len(t, state.LastTimestamps, 2)
+}
+
+func TestContainerLogsStatusReportsLogsOfRestartedContainer(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       corev1.ContainerStatus
+		wantMessages int
+	}{
+		{
+			name:         "running after restart",
+			status:       corev1.ContainerStatus{Name: "app", RestartCount: 2, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			wantMessages: 2,
+		},
+		{
+			name:         "waiting after crash",
+			status:       corev1.ContainerStatus{Name: "app", RestartCount: 2, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			wantMessages: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			k8sClient := createContainerLogsTestClient(t, tt.status)
+			state := ContainerLogsState{
+				End:           time.Now().Add(time.Minute),
+				Start:         time.Now().Add(-time.Minute),
+				Namespace:     "shop",
+				Workload:      "checkout",
+				Containers:    []string{"app"},
+				RestartCounts: map[string]int32{"checkout-1/app": 1},
+			}
+
+			// When
+			result := testContainerLogsAction.statusInternal(context.Background(), k8sClient, &state)
+
+			// Then
+			assert.Len(t, *result.Messages, tt.wantMessages)
+			assert.Equal(t, int32(2), state.RestartCounts["checkout-1/app"])
+		})
+	}
+}
+
+func TestContainerLogsStatusFetchesLogsNotFetchedInTimeByNextPoll(t *testing.T) {
+	// Given
+	k8sClient := createContainerLogsTestClient(t, corev1.ContainerStatus{Name: "app", RestartCount: 2, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}})
+	state := ContainerLogsState{
+		End:           time.Now().Add(time.Minute),
+		Start:         time.Now().Add(-time.Minute),
+		Namespace:     "shop",
+		Workload:      "checkout",
+		RestartCounts: map[string]int32{"checkout-1/app": 1},
+	}
+	expired, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// When
+	timedOut := testContainerLogsAction.statusInternal(expired, k8sClient, &state)
+	restartCountAfterTimeout := state.RestartCounts["checkout-1/app"]
+	nextPoll := testContainerLogsAction.statusInternal(context.Background(), k8sClient, &state)
+
+	// Then
+	assert.Empty(t, *timedOut.Messages)
+	assert.Equal(t, int32(1), restartCountAfterTimeout)
+	assert.Len(t, *nextPoll.Messages, 3)
+	assert.Equal(t, int32(2), state.RestartCounts["checkout-1/app"])
+}
+
+func TestReportLogLines(t *testing.T) {
+	second := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	lastReported := second.Add(500 * time.Millisecond)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "checkout-1", Namespace: "shop"}}
+	tests := []struct {
+		name                   string
+		lines                  []logLine
+		truncated              bool
+		wantMessages           []string
+		wantLastTimestamp      time.Time
+		wantLastTimestampCount int
+	}{
+		{
+			name: "lines of the second before the last reported line",
+			lines: []logLine{
+				{timestamp: second.Add(100 * time.Millisecond), text: "older"},
+				{timestamp: lastReported, text: "reported"},
+				{timestamp: second.Add(700 * time.Millisecond), text: "newer"},
+			},
+			wantMessages:           []string{"newer"},
+			wantLastTimestamp:      second.Add(700 * time.Millisecond),
+			wantLastTimestampCount: 1,
+		},
+		{
+			name: "lines with the timestamp of the last reported line",
+			lines: []logLine{
+				{timestamp: lastReported, text: "reported"},
+				{timestamp: lastReported, text: "same time 1"},
+				{timestamp: lastReported, text: "same time 2"},
+			},
+			wantMessages:           []string{"same time 1", "same time 2"},
+			wantLastTimestamp:      lastReported,
+			wantLastTimestampCount: 3,
+		},
+		{
+			name: "truncated without new lines",
+			lines: []logLine{
+				{timestamp: second.Add(100 * time.Millisecond), text: "older"},
+				{timestamp: lastReported, text: "reported"},
+			},
+			truncated:              true,
+			wantLastTimestamp:      second.Add(time.Second),
+			wantLastTimestampCount: 0,
+		},
+		{
+			name: "truncated with new lines",
+			lines: []logLine{
+				{timestamp: lastReported, text: "reported"},
+				{timestamp: second.Add(700 * time.Millisecond), text: "newer"},
+			},
+			truncated:              true,
+			wantMessages:           []string{"newer"},
+			wantLastTimestamp:      second.Add(700 * time.Millisecond),
+			wantLastTimestampCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			state := ContainerLogsState{
+				LastTimestamps:      map[string]time.Time{"checkout-1/app": lastReported},
+				LastTimestampCounts: map[string]int{"checkout-1/app": 1},
+			}
+			request := &logRequest{pod: pod, container: "app", key: "checkout-1/app", since: lastReported, reportedAtSince: 1, lines: tt.lines, truncated: tt.truncated}
+
+			// When
+			messages := reportLogLines(&state, request, regexp.MustCompile(""))
+
+			// Then
+			var texts []string
+			for _, message := range messages {
+				texts = append(texts, message.Message)
+			}
+			assert.Equal(t, tt.wantMessages, texts)
+			assert.Equal(t, tt.wantLastTimestamp, state.LastTimestamps["checkout-1/app"])
+			assert.Equal(t, tt.wantLastTimestampCount, state.LastTimestampCounts["checkout-1/app"])
+		})
+	}
+}
+
+func TestContainerLogsStatusCompletesAfterEnd(t *testing.T) {
+	// Given
+	k8sClient := createContainerLogsTestClient(t, corev1.ContainerStatus{Name: "app"})
+	state := ContainerLogsState{
+		End:       time.Now().Add(-time.Second),
+		Start:     time.Now().Add(-time.Minute),
+		Namespace: "shop",
+		Workload:  "checkout",
+	}
+
+	// When
+	result := testContainerLogsAction.statusInternal(context.Background(), k8sClient, &state)
+
+	// Then
+	assert.True(t, result.Completed)
+	assert.Len(t, *result.Messages, 2)
+}
+
+func createContainerLogsTestClient(t *testing.T, appStatus corev1.ContainerStatus) *client.Client {
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.AppsV1().Deployments("shop").Create(context.Background(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.CoreV1().Pods("shop").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout-1", Namespace: "shop", Labels: map[string]string{"app": "checkout"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{appStatus},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	return client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+}
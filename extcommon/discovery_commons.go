@@ -1,10 +1,13 @@
 package extcommon
 
 import (
+	"fmt"
 	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	"golang.org/x/exp/maps"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strings"
 )
@@ -56,6 +59,17 @@ func GetPodBasedAttributes(ownerType string, owner metav1.ObjectMeta, pods []*v1
 	return attributes
 }
 
+// GetAnnotationAttributes describes the annotations retained in the cache (see extconfig.Specification.DiscoveryAnnotations)
+// as k8s.annotation.<key> and k8s.<kind>.annotation.<key> attributes.
+func GetAnnotationAttributes(kind string, meta metav1.ObjectMeta) map[string][]string {
+	attributes := map[string][]string{}
+	for key, value := range meta.Annotations {
+		attributes[fmt.Sprintf("k8s.%s.annotation.%s", kind, key)] = []string{value}
+		attributes[fmt.Sprintf("k8s.annotation.%s", key)] = []string{value}
+	}
+	return attributes
+}
+
 func GetServiceNames(services []*v1.Service) map[string][]string {
 	attributes := map[string][]string{}
 	if len(services) > 0 {
@@ -68,6 +82,63 @@ func GetServiceNames(services []*v1.Service) map[string][]string {
 	return attributes
 }
 
+// GetRouteAttributes describes the OpenShift routes exposing the services of a workload.
+func GetRouteAttributes(routes []*client.Route) map[string][]string {
+	attributes := map[string][]string{}
+	if len(routes) > 0 {
+		routeNames := make([]string, 0, len(routes))
+		var hosts []string
+		for _, route := range routes {
+			routeNames = append(routeNames, route.Name)
+			if route.Spec.Host != "" {
+				hosts = append(hosts, route.Spec.Host)
+			}
+		}
+		attributes["k8s.route"] = routeNames
+		if len(hosts) > 0 {
+			attributes["k8s.route.host"] = hosts
+		}
+	}
+	return attributes
+}
+
+// GetPodDisruptionBudgetAttributes describes the pod disruption budgets covering a workload. The attribute
+// k8s.specification.has-pod-disruption-budget is only added for workloads with more than one replica, as a PDB for a
+// single replica workload would block every voluntary disruption.
+func GetPodDisruptionBudgetAttributes(pdbs []*policyv1.PodDisruptionBudget, replicas *int32) map[string][]string {
+	attributes := map[string][]string{}
+	if replicas != nil && *replicas > 1 {
+		attributes["k8s.specification.has-pod-disruption-budget"] = []string{fmt.Sprintf("%t", len(pdbs) > 0)}
+	}
+	if len(pdbs) == 0 {
+		return attributes
+	}
+
+	names := make([]string, 0, len(pdbs))
+	disruptionsAllowed := make([]string, 0, len(pdbs))
+	var minAvailable []string
+	var maxUnavailable []string
+	for _, pdb := range pdbs {
+		names = append(names, pdb.Name)
+		disruptionsAllowed = append(disruptionsAllowed, fmt.Sprintf("%d", pdb.Status.DisruptionsAllowed))
+		if pdb.Spec.MinAvailable != nil {
+			minAvailable = append(minAvailable, pdb.Spec.MinAvailable.String())
+		}
+		if pdb.Spec.MaxUnavailable != nil {
+			maxUnavailable = append(maxUnavailable, pdb.Spec.MaxUnavailable.String())
+		}
+	}
+	attributes["k8s.pdb.name"] = names
+	attributes["k8s.pdb.disruptions-allowed"] = disruptionsAllowed
+	if len(minAvailable) > 0 {
+		attributes["k8s.pdb.min-available"] = minAvailable
+	}
+	if len(maxUnavailable) > 0 {
+		attributes["k8s.pdb.max-unavailable"] = maxUnavailable
+	}
+	return attributes
+}
+
 func GetNodeHostnameAndFQDNs(nodes []*v1.Node, name string) (hostname string, fqdn []string) {
 	for _, node := range nodes {
 		if node.Name == name {
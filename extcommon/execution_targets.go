@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"strings"
+	"sync"
+	"time"
+)
+
+// executionTargetRetention is how long the targets of an experiment execution are remembered. It is an upper bound
+// for the duration of experiments.
+const executionTargetRetention = 24 * time.Hour
+
+// ExecutionTarget is a Kubernetes object attacked in an experiment execution.
+type ExecutionTarget struct {
+	Namespace string `json:"namespace,omitempty"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+}
+
+type executionTargetEntry struct {
+	targets []ExecutionTarget
+	updated time.Time
+}
+
+var executionTargets = struct {
+	sync.Mutex
+	m map[int]*executionTargetEntry
+}{m: make(map[int]*executionTargetEntry)}
+
+var executionTargetKinds = []struct {
+	attribute string
+	kind      string
+	// attackedOnly kinds are only recorded for attacks on an object of this kind, e.g. all pods carry the name of their node
+	attackedOnly bool
+}{
+	{"k8s.deployment", "Deployment", false},
+	{"k8s.statefulset", "StatefulSet", false},
+	{"k8s.daemonset", "DaemonSet", false},
+	{"k8s.job", "Job", false},
+	{"k8s.cronjob", "CronJob", false},
+	{"k8s.rollout", "Rollout", false},
+	{"k8s.deploymentconfig", "DeploymentConfig", false},
+	{"k8s.pod.name", "Pod", false},
+	{"k8s.node.name", "Node", true},
+}
+
+// RememberExecutionTarget records the target of an attack on an object of the given kind for the experiment execution,
+// so that other steps of the same execution (e.g. the Kubernetes event log) can be scoped to the attacked objects.
+func RememberExecutionTarget(request action_kit_api.PrepareActionRequestBody, kind string) {
+	if request.ExecutionContext == nil || request.ExecutionContext.ExecutionId == nil || request.Target == nil {
+		return
+	}
+
+	namespace := ""
+	if namespaces := request.Target.Attributes["k8s.namespace"]; len(namespaces) > 0 {
+		namespace = namespaces[0]
+	}
+	var targets []ExecutionTarget
+	for _, k := range executionTargetKinds {
+		if k.attackedOnly && !strings.EqualFold(k.kind, kind) {
+			continue
+		}
+		for _, name := range request.Target.Attributes[k.attribute] {
+			targets = append(targets, ExecutionTarget{Namespace: namespace, Kind: k.kind, Name: name})
+		}
+	}
+
+	executionTargets.Lock()
+	defer executionTargets.Unlock()
+	now := time.Now()
+	for id, entry := range executionTargets.m {
+		if now.Sub(entry.updated) > executionTargetRetention {
+			delete(executionTargets.m, id)
+		}
+	}
+	entry, ok := executionTargets.m[*request.ExecutionContext.ExecutionId]
+	if !ok {
+		entry = &executionTargetEntry{}
+		executionTargets.m[*request.ExecutionContext.ExecutionId] = entry
+	}
+	entry.targets = append(entry.targets, targets...)
+	entry.updated = now
+}
+
+// GetExecutionTargets returns the targets of all attacks prepared so far in the experiment execution.
+func GetExecutionTargets(executionId int) []ExecutionTarget {
+	executionTargets.Lock()
+	defer executionTargets.Unlock()
+	entry, ok := executionTargets.m[executionId]
+	if !ok {
+		return nil
+	}
+	return append([]ExecutionTarget(nil), entry.targets...)
+}
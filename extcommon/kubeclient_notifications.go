@@ -1,38 +1,28 @@
 package extcommon
 
 import (
+	"fmt"
 	"github.com/rs/zerolog/log"
 	"github.com/steadybit/extension-kubernetes/client"
 	"reflect"
-	"slices"
 )
 
 func TriggerOnKubernetesResourceChange(k8s *client.Client, t ...reflect.Type) chan struct{} {
-	chRefresh := make(chan struct{})
-	chNotification := make(chan interface{})
+	chRefresh := make(chan struct{}, 1)
 
-	k8s.Notify(chNotification)
-	go triggerNotificationsForType(chNotification, chRefresh, t...)
+	subscription := k8s.Subscribe(fmt.Sprintf("%v", t), client.WithTypes(t...))
+	go triggerNotifications(subscription, chRefresh)
 
 	return chRefresh
 }
 
-func triggerNotificationsForType(in <-chan interface{}, out chan<- struct{}, types ...reflect.Type) {
-	var s []string
-	for _, r := range types {
-		s = append(s, r.String())
-	}
-
-	for event := range in {
-		eventType := reflect.TypeOf(event)
-		if eventType.Kind() == reflect.Pointer {
-			eventType = eventType.Elem()
-		}
-
-		forward := slices.Index(types, eventType) >= 0
-		log.Trace().Type("type", event).Bool("forward", forward).Strs("types", s).Msg("resource event")
-		if forward {
-			out <- struct{}{}
+func triggerNotifications(subscription *client.Subscription, out chan<- struct{}) {
+	for event := range subscription.C() {
+		log.Trace().Type("type", event).Msg("resource event")
+		// a pending refresh already covers this change
+		select {
+		case out <- struct{}{}:
+		default:
 		}
 	}
 }
@@ -13,6 +13,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sJson "k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"runtime/debug"
@@ -73,6 +74,22 @@ func GetKubeScoreForDeployment(deployment *appsv1.Deployment, services []*corev1
 	return attributes
 }
 
+// GetKubeScoreForRollout scores the pod template of an Argo Rollout like the one of a deployment. Rollouts replace pods
+// by their canary or blue-green strategy, the deployment strategy isn't scored.
+func GetKubeScoreForRollout(meta metav1.ObjectMeta, replicas *int32, selector *metav1.LabelSelector, template corev1.PodTemplateSpec, services []*corev1.Service, hpa *autoscalingv2.HorizontalPodAutoscaler) map[string][]string {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: meta.Name, Namespace: meta.Namespace, Labels: meta.Labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: replicas,
+			Selector: selector,
+			Template: template,
+		},
+	}
+	attributes := GetKubeScoreForDeployment(deployment, services, hpa)
+	delete(attributes, "k8s.specification.has-rolling-update-strategy")
+	return attributes
+}
+
 func GetKubeScoreForDaemonSet(daemonSet *appsv1.DaemonSet, services []*corev1.Service) map[string][]string {
 	daemonSet.APIVersion = "apps/v1"
 	daemonSet.Kind = "DaemonSet"
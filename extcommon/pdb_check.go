@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"time"
+)
+
+// PodDisruptionBudgetCheckAction asserts that the pod disruption budgets of a workload keep allowing disruptions for
+// the whole duration. The budgets are taken from the k8s.pdb.name attribute of the target, so the action can be used
+// for every workload target type discovering this attribute.
+type PodDisruptionBudgetCheckAction struct {
+	Id                string
+	TargetType        string
+	WorkloadAttribute string
+	WorkloadLabel     string
+}
+
+type PodDisruptionBudgetCheckState struct {
+	End       time.Time
+	Namespace string
+	Workload  string
+	Names     []string
+}
+
+type PodDisruptionBudgetCheckConfig struct {
+	Duration int
+}
+
+var _ action_kit_sdk.Action[PodDisruptionBudgetCheckState] = (*PodDisruptionBudgetCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[PodDisruptionBudgetCheckState] = (*PodDisruptionBudgetCheckAction)(nil)
+
+func (a PodDisruptionBudgetCheckAction) NewEmptyState() PodDisruptionBudgetCheckState {
+	return PodDisruptionBudgetCheckState{}
+}
+
+func (a PodDisruptionBudgetCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          a.Id,
+		Label:       "Pod Disruption Budget",
+		Description: fmt.Sprintf("Verify that the pod disruption budgets of a %s allow disruptions for the whole duration", a.WorkloadLabel),
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr("data:image/svg+xml,%3Csvg%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%20width%3D%2224%22%20height%3D%2224%22%20fill%3D%22none%22%20viewBox%3D%220%200%2024%2024%22%3E%3Cpath%20fill%3D%22currentColor%22%20d%3D%22M12%202%204%205v6c0%205.55%203.84%2010.74%208%2012%204.16-1.26%208-6.45%208-12V5l-8-3zm-1%2014-4-4%201.41-1.41L11%2013.17l5.59-5.59L18%209l-7%207z%22%2F%3E%3C%2Fsvg%3E"),
+		Category:    extutil.Ptr("Kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          a.TargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr(fmt.Sprintf("Find %s by cluster, namespace and %s", a.WorkloadLabel, a.WorkloadLabel)),
+					Query:       fmt.Sprintf("k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND %s=\"\"", a.WorkloadAttribute),
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should disruptions be allowed."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("30s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (a PodDisruptionBudgetCheckAction) Prepare(_ context.Context, state *PodDisruptionBudgetCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config PodDisruptionBudgetCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+
+	namespace := request.Target.Attributes["k8s.namespace"][0]
+	workload := request.Target.Attributes[a.WorkloadAttribute][0]
+	names := request.Target.Attributes["k8s.pdb.name"]
+	if len(names) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("%s %s/%s is not covered by a pod disruption budget.", a.WorkloadLabel, namespace, workload), nil)
+	}
+
+	state.End = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = namespace
+	state.Workload = workload
+	state.Names = names
+	return nil, nil
+}
+
+func (a PodDisruptionBudgetCheckAction) Start(_ context.Context, _ *PodDisruptionBudgetCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (a PodDisruptionBudgetCheckAction) Status(_ context.Context, state *PodDisruptionBudgetCheckState) (*action_kit_api.StatusResult, error) {
+	return statusPodDisruptionBudgetCheckInternal(client.K8S, state), nil
+}
+
+func statusPodDisruptionBudgetCheckInternal(k8s *client.Client, state *PodDisruptionBudgetCheckState) *action_kit_api.StatusResult {
+	for _, name := range state.Names {
+		pdb := k8s.PodDisruptionBudgetByNamespaceAndName(state.Namespace, name)
+		if pdb == nil {
+			return &action_kit_api.StatusResult{
+				Completed: true,
+				Error: extutil.Ptr(action_kit_api.ActionKitError{
+					Title:  fmt.Sprintf("Pod disruption budget %s not found", name),
+					Status: extutil.Ptr(action_kit_api.Errored),
+				}),
+			}
+		}
+		if pdb.Status.DisruptionsAllowed <= 0 {
+			return &action_kit_api.StatusResult{
+				Completed: true,
+				Error: extutil.Ptr(action_kit_api.ActionKitError{
+					Title: fmt.Sprintf("Pod disruption budget %s of %s allows no disruptions. %d of %d desired healthy pods are healthy.",
+						name, state.Workload, pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy),
+					Status: extutil.Ptr(action_kit_api.Failed),
+				}),
+			}
+		}
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: time.Now().After(state.End),
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+var testPdbCheckAction = PodDisruptionBudgetCheckAction{
+	Id:                "test",
+	TargetType:        "deployment",
+	WorkloadAttribute: "k8s.deployment",
+	WorkloadLabel:     "Deployment",
+}
+
+func TestPreparePdbCheckExtractsState(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 1000 * 10,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+				"k8s.pdb.name":   {"checkout-pdb"},
+			},
+		}),
+	}
+	state := testPdbCheckAction.NewEmptyState()
+
+	// When
+	_, err := testPdbCheckAction.Prepare(context.Background(), &state, request)
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, state.End.After(time.Now()))
+	assert.Equal(t, "shop", state.Namespace)
+	assert.Equal(t, "checkout", state.Workload)
+	assert.Equal(t, []string{"checkout-pdb"}, state.Names)
+}
+
+func TestPreparePdbCheckFailsWithoutPdb(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 1000 * 10,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+			},
+		}),
+	}
+	state := testPdbCheckAction.NewEmptyState()
+
+	// When
+	_, err := testPdbCheckAction.Prepare(context.Background(), &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "Deployment shop/checkout is not covered by a pod disruption budget.")
+}
+
+func TestStatusPdbCheck(t *testing.T) {
+	tests := []struct {
+		name               string
+		disruptionsAllowed int32
+		end                time.Duration
+		pdbName            string
+		completed          bool
+		expectedError      string
+	}{
+		{
+			name:               "disruptions allowed until end",
+			disruptionsAllowed: 1,
+			end:                time.Minute,
+			pdbName:            "checkout-pdb",
+			completed:          false,
+		},
+		{
+			name:               "disruptions allowed after end",
+			disruptionsAllowed: 1,
+			end:                -time.Second,
+			pdbName:            "checkout-pdb",
+			completed:          true,
+		},
+		{
+			name:               "no disruptions allowed",
+			disruptionsAllowed: 0,
+			end:                time.Minute,
+			pdbName:            "checkout-pdb",
+			completed:          true,
+			expectedError:      "Pod disruption budget checkout-pdb of checkout allows no disruptions. 2 of 2 desired healthy pods are healthy.",
+		},
+		{
+			name:               "pdb not found",
+			disruptionsAllowed: 1,
+			end:                time.Minute,
+			pdbName:            "unknown",
+			completed:          true,
+			expectedError:      "Pod disruption budget unknown not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			clientset := testclient.NewSimpleClientset()
+			_, err := clientset.PolicyV1().PodDisruptionBudgets("shop").Create(context.Background(), &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "checkout-pdb",
+					Namespace: "shop",
+				},
+				Status: policyv1.PodDisruptionBudgetStatus{
+					DisruptionsAllowed: tt.disruptionsAllowed,
+					CurrentHealthy:     2,
+					DesiredHealthy:     2,
+				},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			k8sclient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+			require.Eventually(t, func() bool {
+				return k8sclient.PodDisruptionBudgetByNamespaceAndName("shop", "checkout-pdb") != nil
+			}, time.Second, 100*time.Millisecond)
+			state := PodDisruptionBudgetCheckState{
+				End:       time.Now().Add(tt.end),
+				Namespace: "shop",
+				Workload:  "checkout",
+				Names:     []string{tt.pdbName},
+			}
+
+			// When
+			result := statusPodDisruptionBudgetCheckInternal(k8sclient, &state)
+
+			// Then
+			assert.Equal(t, tt.completed, result.Completed)
+			if tt.expectedError == "" {
+				assert.Nil(t, result.Error)
+			} else {
+				require.NotNil(t, result.Error)
+				assert.Equal(t, tt.expectedError, result.Error.Title)
+			}
+		})
+	}
+}
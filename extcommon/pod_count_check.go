@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extutil"
+	"time"
+)
+
+const (
+	PodCountMin1                       = "podCountMin1"
+	PodCountEqualsDesiredCount         = "podCountEqualsDesiredCount"
+	PodCountLessThanDesiredCount       = "podCountLessThanDesiredCount"
+	PodCountDecreased                  = "podCountDecreased"
+	PodCountIncreased                  = "podCountIncreased"
+	PodCountAtLeast                    = "podCountAtLeast"
+	PodCountAtLeastPercentOfDesired    = "podCountAtLeastPercentOfDesired"
+	PodCountNeverBelowPercentOfDesired = "podCountNeverBelowPercentOfDesired"
+)
+
+// PodCountCheckInput holds everything needed to evaluate a pod count check for a single workload.
+type PodCountCheckInput struct {
+	Kind         string
+	Name         string
+	Mode         string
+	Threshold    int
+	InitialCount int
+	ReadyCount   int
+	DesiredCount *int
+	Timeout      time.Time
+}
+
+func PodCountCheckParameters() []action_kit_api.ActionParameter {
+	return []action_kit_api.ActionParameter{
+		{
+			Name:         "duration",
+			Label:        "Timeout",
+			Description:  extutil.Ptr("How long should the check wait for the specified pod count."),
+			Type:         action_kit_api.Duration,
+			DefaultValue: extutil.Ptr("10s"),
+			Order:        extutil.Ptr(1),
+			Required:     extutil.Ptr(true),
+		},
+		{
+			Name:         "podCountCheckMode",
+			Label:        "Pod count",
+			Description:  extutil.Ptr("How many pods are required to let the check pass."),
+			Type:         action_kit_api.String,
+			DefaultValue: extutil.Ptr(PodCountEqualsDesiredCount),
+			Order:        extutil.Ptr(2),
+			Required:     extutil.Ptr(true),
+			Options: extutil.Ptr([]action_kit_api.ParameterOption{
+				action_kit_api.ExplicitParameterOption{
+					Label: "ready count > 0",
+					Value: PodCountMin1,
+				},
+				action_kit_api.ExplicitParameterOption{
+					Label: "ready count = desired count",
+					Value: PodCountEqualsDesiredCount,
+				},
+				action_kit_api.ExplicitParameterOption{
+					Label: "ready count < desired count",
+					Value: PodCountLessThanDesiredCount,
+				},
+				action_kit_api.ExplicitParameterOption{
+					Label: "actual count increases",
+					Value: PodCountIncreased,
+				},
+				action_kit_api.ExplicitParameterOption{
+					Label: "actual count decreases",
+					Value: PodCountDecreased,
+				},
+				action_kit_api.ExplicitParameterOption{
+					Label: "ready count >= threshold",
+					Value: PodCountAtLeast,
+				},
+				action_kit_api.ExplicitParameterOption{
+					Label: "ready count >= threshold % of desired count",
+					Value: PodCountAtLeastPercentOfDesired,
+				},
+				action_kit_api.ExplicitParameterOption{
+					Label: "ready count never drops below threshold % of desired count",
+					Value: PodCountNeverBelowPercentOfDesired,
+				},
+			}),
+		},
+		{
+			Name:         "podCountThreshold",
+			Label:        "Threshold (pods)",
+			Description:  extutil.Ptr("Required number of ready pods. Only used by the option 'ready count >= threshold'."),
+			Type:         action_kit_api.Integer,
+			DefaultValue: extutil.Ptr("1"),
+			Order:        extutil.Ptr(3),
+			Required:     extutil.Ptr(false),
+		},
+		{
+			Name:         "podCountThresholdPercent",
+			Label:        "Threshold (%)",
+			Description:  extutil.Ptr("Required percentage of the desired count. Only used by the options based on a threshold % of the desired count."),
+			Type:         action_kit_api.Integer,
+			DefaultValue: extutil.Ptr("100"),
+			Order:        extutil.Ptr(4),
+			Required:     extutil.Ptr(false),
+		},
+	}
+}
+
+func IsPodCountCheckModeWithDesiredCount(mode string) bool {
+	return mode == PodCountEqualsDesiredCount ||
+		mode == PodCountLessThanDesiredCount ||
+		mode == PodCountAtLeastPercentOfDesired ||
+		mode == PodCountNeverBelowPercentOfDesired
+}
+
+// SelectPodCountThreshold returns the threshold used by the given mode, the percentage for the modes based on a
+// percentage of the desired count and the pod count otherwise.
+func SelectPodCountThreshold(mode string, count int, percent int) int {
+	if mode == PodCountAtLeastPercentOfDesired || mode == PodCountNeverBelowPercentOfDesired {
+		return percent
+	}
+	return count
+}
+
+// ValidatePodCountThreshold rejects thresholds which let a threshold based check never pass or never fail: negative
+// counts and percentages above 100.
+func ValidatePodCountThreshold(mode string, threshold int) error {
+	switch mode {
+	case PodCountAtLeast:
+		if threshold < 0 {
+			return extension_kit.ToError(fmt.Sprintf("The pod count threshold must not be negative, got %d.", threshold), nil)
+		}
+	case PodCountAtLeastPercentOfDesired, PodCountNeverBelowPercentOfDesired:
+		if threshold < 0 || threshold > 100 {
+			return extension_kit.ToError(fmt.Sprintf("The pod count threshold must be a percentage between 0 and 100, got %d.", threshold), nil)
+		}
+	}
+	return nil
+}
+
+// PodCountCheckStatus evaluates the check. All modes except PodCountNeverBelowPercentOfDesired complete as soon as the
+// expected pod count is reached. PodCountNeverBelowPercentOfDesired is asserted continuously and fails on the first
+// violation, it only succeeds once the timeout is reached.
+func PodCountCheckStatus(input PodCountCheckInput) *action_kit_api.StatusResult {
+	now := time.Now()
+
+	desiredCount := 0
+	if input.DesiredCount != nil {
+		desiredCount = *input.DesiredCount
+	} else if IsPodCountCheckModeWithDesiredCount(input.Mode) {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s %s has no desired count.", input.Kind, input.Name),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	readyCount := input.ReadyCount
+	var checkError *action_kit_api.ActionKitError
+	if input.Mode == PodCountMin1 && readyCount < 1 {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has no ready pods.", input.Name),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if input.Mode == PodCountEqualsDesiredCount && readyCount != desiredCount {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has only %d of desired %d pods ready.", input.Name, readyCount, desiredCount),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if input.Mode == PodCountLessThanDesiredCount && readyCount == desiredCount {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has all %d desired pods ready.", input.Name, desiredCount),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if input.Mode == PodCountIncreased && readyCount <= input.InitialCount {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s's pod count didn't increase. Initial count: %d, current count: %d.", input.Name, input.InitialCount, readyCount),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if input.Mode == PodCountDecreased && readyCount >= input.InitialCount {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s's pod count didn't decrease. Initial count: %d, current count: %d.", input.Name, input.InitialCount, readyCount),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if input.Mode == PodCountAtLeast && readyCount < input.Threshold {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has only %d of required %d pods ready.", input.Name, readyCount, input.Threshold),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if input.Mode == PodCountAtLeastPercentOfDesired && !isAtLeastPercent(readyCount, desiredCount, input.Threshold) {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has only %d of desired %d pods ready, required are at least %d%%.", input.Name, readyCount, desiredCount, input.Threshold),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if input.Mode == PodCountNeverBelowPercentOfDesired && !isAtLeastPercent(readyCount, desiredCount, input.Threshold) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("%s dropped to %d of desired %d pods ready, required are at least %d%%.", input.Name, readyCount, desiredCount, input.Threshold),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	if now.After(input.Timeout) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error:     checkError,
+		}
+	} else if input.Mode == PodCountNeverBelowPercentOfDesired {
+		return &action_kit_api.StatusResult{
+			Completed: false,
+		}
+	} else {
+		return &action_kit_api.StatusResult{
+			Completed: checkError == nil,
+		}
+	}
+}
+
+func isAtLeastPercent(readyCount int, desiredCount int, percent int) bool {
+	return readyCount*100 >= desiredCount*percent
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_ValidatePodCountThreshold(t *testing.T) {
+	tests := []struct {
+		mode      string
+		threshold int
+		wantErr   bool
+	}{
+		{mode: PodCountAtLeast, threshold: 0},
+		{mode: PodCountAtLeast, threshold: 250},
+		{mode: PodCountAtLeast, threshold: -1, wantErr: true},
+		{mode: PodCountAtLeastPercentOfDesired, threshold: 100},
+		{mode: PodCountAtLeastPercentOfDesired, threshold: 101, wantErr: true},
+		{mode: PodCountAtLeastPercentOfDesired, threshold: -5, wantErr: true},
+		{mode: PodCountNeverBelowPercentOfDesired, threshold: 50},
+		{mode: PodCountNeverBelowPercentOfDesired, threshold: 150, wantErr: true},
+		{mode: PodCountEqualsDesiredCount, threshold: -1},
+	}
+	for _, tt := range tests {
+		err := ValidatePodCountThreshold(tt.mode, tt.threshold)
+		if tt.wantErr {
+			assert.Error(t, err, "%s with %d", tt.mode, tt.threshold)
+		} else {
+			assert.NoError(t, err, "%s with %d", tt.mode, tt.threshold)
+		}
+	}
+}
+
+func Test_SelectPodCountThreshold(t *testing.T) {
+	// Given
+	count, percent := 1, 100
+
+	// When
+	thresholds := map[string]int{
+		PodCountAtLeast:                    SelectPodCountThreshold(PodCountAtLeast, count, percent),
+		PodCountAtLeastPercentOfDesired:    SelectPodCountThreshold(PodCountAtLeastPercentOfDesired, count, percent),
+		PodCountNeverBelowPercentOfDesired: SelectPodCountThreshold(PodCountNeverBelowPercentOfDesired, count, percent),
+	}
+
+	// Then
+	assert.Equal(t, map[string]int{
+		PodCountAtLeast:                    1,
+		PodCountAtLeastPercentOfDesired:    100,
+		PodCountNeverBelowPercentOfDesired: 100,
+	}, thresholds)
+}
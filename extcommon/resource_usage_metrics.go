@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"time"
+)
+
+const (
+	ResourceUsageCpuMetric    = "resource_usage_cpu_millicores"
+	ResourceUsageMemoryMetric = "resource_usage_memory_bytes"
+	ResourceUsageIcon         = "data:image/svg+xml,%3Csvg%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%20width%3D%2224%22%20height%3D%2224%22%20fill%3D%22none%22%20viewBox%3D%220%200%2024%2024%22%3E%3Cpath%20fill%3D%22currentColor%22%20d%3D%22M9%202v2H7a3%203%200%200%200-3%203v2H2v2h2v2H2v2h2v2a3%203%200%200%200%203%203h2v2h2v-2h2v2h2v-2h2a3%203%200%200%200%203-3v-2h2v-2h-2v-2h2V9h-2V7a3%203%200%200%200-3-3h-2V2h-2v2h-2V2H9zm-2%204h10a1%201%200%200%201%201%201v10a1%201%200%200%201-1%201H7a1%201%200%200%201-1-1V7a1%201%200%200%201%201-1zm2%203v6h6V9H9z%22%2F%3E%3C%2Fsvg%3E"
+)
+
+type ResourceUsageThresholds struct {
+	CpuMillicores   int64
+	MemoryMegabytes int64
+}
+
+// ResourceUsageParameters returns the optional thresholds of a resource usage metrics action. Without thresholds,
+// the action only reports the resource usage.
+func ResourceUsageParameters() []action_kit_api.ActionParameter {
+	return []action_kit_api.ActionParameter{
+		{
+			Name:         "duration",
+			Label:        "Duration",
+			Description:  extutil.Ptr("How long should the resource usage be collected."),
+			Type:         action_kit_api.Duration,
+			DefaultValue: extutil.Ptr("60s"),
+			Order:        extutil.Ptr(1),
+			Required:     extutil.Ptr(true),
+		},
+		{
+			Name:        "cpuMillicoresThreshold",
+			Label:       "CPU threshold (millicores)",
+			Description: extutil.Ptr("Fail if the CPU usage exceeds this value. Leave empty to only collect the CPU usage."),
+			Type:        action_kit_api.Integer,
+			Order:       extutil.Ptr(2),
+			Required:    extutil.Ptr(false),
+		},
+		{
+			Name:        "memoryMegabytesThreshold",
+			Label:       "Memory threshold (MiB)",
+			Description: extutil.Ptr("Fail if the memory usage exceeds this value. Leave empty to only collect the memory usage."),
+			Type:        action_kit_api.Integer,
+			Order:       extutil.Ptr(3),
+			Required:    extutil.Ptr(false),
+		},
+	}
+}
+
+// ResourceUsageWidgets charts the CPU and memory usage, one series per value of the given label (e.g. k8s.pod.name).
+func ResourceUsageWidgets(from string, tooltip ...LineChartWidgetTooltipContent) []action_kit_api.Widget {
+	widget := func(title string, metricName string, unit string) LineChartWidget {
+		return LineChartWidget{
+			Type:  LineChartWidgetType,
+			Title: title,
+			Identity: LineChartWidgetIdentityConfig{
+				MetricName: metricName,
+				From:       from,
+				Mode:       LineChartIdentityModeSelect,
+			},
+			Tooltip: &LineChartWidgetTooltipConfig{
+				MetricValueTitle:  extutil.Ptr(title),
+				MetricValueUnit:   extutil.Ptr(unit),
+				AdditionalContent: tooltip,
+			},
+		}
+	}
+	return []action_kit_api.Widget{
+		widget("CPU usage", ResourceUsageCpuMetric, "millicores"),
+		widget("Memory usage", ResourceUsageMemoryMetric, "bytes"),
+	}
+}
+
+// ResourceUsageMetrics converts the CPU and memory usage reported by the metrics.k8s.io API into action metrics.
+func ResourceUsageMetrics(labels map[string]string, usage corev1.ResourceList, timestamp time.Time) []action_kit_api.Metric {
+	metricLabels := func() map[string]string {
+		result := make(map[string]string, len(labels))
+		for k, v := range labels {
+			result[k] = v
+		}
+		return result
+	}
+	return []action_kit_api.Metric{
+		{
+			Name:      extutil.Ptr(ResourceUsageCpuMetric),
+			Metric:    metricLabels(),
+			Timestamp: timestamp,
+			Value:     float64(usage.Cpu().MilliValue()),
+		},
+		{
+			Name:      extutil.Ptr(ResourceUsageMemoryMetric),
+			Metric:    metricLabels(),
+			Timestamp: timestamp,
+			Value:     float64(usage.Memory().Value()),
+		},
+	}
+}
+
+// ResourceUsageThresholdError returns an error if the usage exceeds one of the configured thresholds.
+func ResourceUsageThresholdError(name string, usage corev1.ResourceList, thresholds ResourceUsageThresholds) *action_kit_api.ActionKitError {
+	cpu := usage.Cpu().MilliValue()
+	if thresholds.CpuMillicores > 0 && cpu > thresholds.CpuMillicores {
+		return extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("CPU usage of %s is %dm and exceeds the threshold of %dm.", name, cpu, thresholds.CpuMillicores),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+	memory := usage.Memory().Value()
+	if thresholds.MemoryMegabytes > 0 && memory > thresholds.MemoryMegabytes*1024*1024 {
+		return extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("Memory usage of %s is %dMi and exceeds the threshold of %dMi.", name, memory/(1024*1024), thresholds.MemoryMegabytes),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+	return nil
+}
+
+// SumContainerUsage sums up the usage of all containers of a pod.
+func SumContainerUsage(containers []metricsv1beta1.ContainerMetrics) corev1.ResourceList {
+	cpu := resource.NewMilliQuantity(0, resource.DecimalSI)
+	memory := resource.NewQuantity(0, resource.BinarySI)
+	for _, container := range containers {
+		cpu.Add(*container.Usage.Cpu())
+		memory.Add(*container.Usage.Memory())
+	}
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    *cpu,
+		corev1.ResourceMemory: *memory,
+	}
+}
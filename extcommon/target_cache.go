@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"slices"
+	"sync"
+)
+
+// Dependencies identify the versions of the Kubernetes objects a target is computed from.
+type Dependencies struct {
+	keys []string
+	// objects are retained with the cache entry, so that the addresses used as key of objects without resource version can't be reused.
+	objects []any
+}
+
+// AddDependencies adds the objects a target is computed from, identified by their uid and resource version.
+func AddDependencies[T metav1.Object](d *Dependencies, objects ...T) {
+	for _, object := range objects {
+		if resourceVersion := object.GetResourceVersion(); resourceVersion != "" {
+			d.keys = append(d.keys, string(object.GetUID())+"/"+resourceVersion)
+		} else {
+			// the informers replace an object on every change, objects without resource version (e.g. of fake clients) are identified by their address
+			d.keys = append(d.keys, fmt.Sprintf("%s/%p", object.GetUID(), any(object)))
+			d.objects = append(d.objects, object)
+		}
+	}
+}
+
+// AddNodeDependencies adds the nodes the given pods are scheduled on, whose hostnames and domain names are part of the
+// pod based attributes (see GetPodBasedAttributes).
+func AddNodeDependencies(d *Dependencies, pods []*corev1.Pod, nodes []*corev1.Node) {
+	for _, node := range nodes {
+		if slices.ContainsFunc(pods, func(pod *corev1.Pod) bool { return pod.Spec.NodeName == node.Name }) {
+			AddDependencies(d, node)
+		}
+	}
+}
+
+type cachedTarget struct {
+	dependencies Dependencies
+	target       discovery_kit_api.Target
+	generation   uint64
+}
+
+// TargetCache reuses the targets of a discovery, as long as none of the Kubernetes objects they are computed from changed.
+// A nil TargetCache computes every target.
+type TargetCache struct {
+	name       string
+	mu         sync.Mutex
+	entries    map[string]*cachedTarget
+	generation uint64
+	reused     int
+	computed   int
+}
+
+func NewTargetCache(name string) *TargetCache {
+	return &TargetCache{
+		name:    name,
+		entries: make(map[string]*cachedTarget),
+	}
+}
+
+// Target returns the cached target with the given id, if its dependencies are unchanged, or computes it otherwise.
+func (c *TargetCache) Target(id string, dependencies Dependencies, compute func() discovery_kit_api.Target) discovery_kit_api.Target {
+	if c == nil {
+		return compute()
+	}
+
+	// the listers return the objects in random order
+	slices.Sort(dependencies.keys)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[id]; ok && slices.Equal(entry.dependencies.keys, dependencies.keys) {
+		entry.generation = c.generation
+		c.reused++
+		return entry.target
+	}
+
+	target := compute()
+	c.entries[id] = &cachedTarget{
+		dependencies: dependencies,
+		target:       target,
+		generation:   c.generation,
+	}
+	c.computed++
+	return target
+}
+
+// Prune evicts the targets which weren't requested since the last call, and must be called at the end of each discovery.
+func (c *TargetCache) Prune() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, entry := range c.entries {
+		if entry.generation != c.generation {
+			delete(c.entries, id)
+		}
+	}
+	log.Debug().Msgf("Discovered %d %s targets, reused %d and computed %d.", c.reused+c.computed, c.name, c.reused, c.computed)
+	c.generation++
+	c.reused = 0
+	c.computed = 0
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestTargetCacheReusesTargetsWithUnchangedDependencies(t *testing.T) {
+	// Given
+	cache := NewTargetCache("test")
+	computations := 0
+	compute := func() discovery_kit_api.Target {
+		computations++
+		return discovery_kit_api.Target{Id: "checkout"}
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-uid", ResourceVersion: "1"}}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{UID: "service-uid", ResourceVersion: "7"}}
+
+	// When
+	cache.Target("checkout", dependenciesOf(pod, service), compute)
+	cache.Prune()
+	cache.Target("checkout", dependenciesOf(pod, service), compute)
+	cache.Prune()
+
+	// Then
+	assert.Equal(t, 1, computations)
+}
+
+func TestTargetCacheReusesTargetsWithDependenciesInDifferentOrder(t *testing.T) {
+	// Given
+	cache := NewTargetCache("test")
+	computations := 0
+	compute := func() discovery_kit_api.Target {
+		computations++
+		return discovery_kit_api.Target{Id: "checkout"}
+	}
+	pod1 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-1-uid", ResourceVersion: "1"}}
+	pod2 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-2-uid", ResourceVersion: "3"}}
+	service1 := &corev1.Service{ObjectMeta: metav1.ObjectMeta{UID: "service-1-uid", ResourceVersion: "7"}}
+	service2 := &corev1.Service{ObjectMeta: metav1.ObjectMeta{UID: "service-2-uid", ResourceVersion: "2"}}
+
+	// When
+	cache.Target("checkout", dependenciesOf(pod1, pod2, service1, service2), compute)
+	cache.Prune()
+	cache.Target("checkout", dependenciesOf(pod2, pod1, service2, service1), compute)
+	cache.Prune()
+
+	// Then
+	assert.Equal(t, 1, computations)
+}
+
+func TestTargetCacheRecomputesTargetsWithChangedDependencies(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []metav1.Object
+		after  []metav1.Object
+	}{
+		{
+			name:   "resource version changed",
+			before: []metav1.Object{&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-uid", ResourceVersion: "1"}}},
+			after:  []metav1.Object{&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-uid", ResourceVersion: "2"}}},
+		},
+		{
+			name:   "object replaced",
+			before: []metav1.Object{&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-uid", ResourceVersion: "1"}}},
+			after:  []metav1.Object{&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "other-pod-uid", ResourceVersion: "1"}}},
+		},
+		{
+			name:   "object added",
+			before: []metav1.Object{&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-uid", ResourceVersion: "1"}}},
+			after: []metav1.Object{
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-uid", ResourceVersion: "1"}},
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "other-pod-uid", ResourceVersion: "1"}},
+			},
+		},
+		{
+			name:   "object without resource version updated",
+			before: []metav1.Object{&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-uid"}}},
+			after:  []metav1.Object{&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-uid"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			cache := NewTargetCache("test")
+			computations := 0
+			compute := func() discovery_kit_api.Target {
+				computations++
+				return discovery_kit_api.Target{Id: "checkout"}
+			}
+
+			// When
+			cache.Target("checkout", dependenciesOf(tt.before...), compute)
+			cache.Prune()
+			cache.Target("checkout", dependenciesOf(tt.after...), compute)
+			cache.Prune()
+
+			// Then
+			assert.Equal(t, 2, computations)
+		})
+	}
+}
+
+func TestTargetCacheRecomputesTargetsWhenNodeOfPodChanged(t *testing.T) {
+	// Given
+	cache := NewTargetCache("test")
+	computations := 0
+	compute := func() discovery_kit_api.Target {
+		computations++
+		return discovery_kit_api.Target{Id: "checkout"}
+	}
+	pods := []*corev1.Pod{{ObjectMeta: metav1.ObjectMeta{UID: "pod-uid", ResourceVersion: "1"}, Spec: corev1.PodSpec{NodeName: "worker-1"}}}
+	otherNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-2", UID: "worker-2-uid", ResourceVersion: "1"}}
+	dependencies := func(nodes ...*corev1.Node) Dependencies {
+		var d Dependencies
+		AddDependencies(&d, pods...)
+		AddNodeDependencies(&d, pods, nodes)
+		return d
+	}
+
+	// When
+	cache.Target("checkout", dependencies(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", UID: "worker-1-uid", ResourceVersion: "1"}}, otherNode), compute)
+	cache.Prune()
+	cache.Target("checkout", dependencies(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", UID: "worker-1-uid", ResourceVersion: "1"}}, otherNode), compute)
+	cache.Prune()
+	otherNode = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-2", UID: "worker-2-uid", ResourceVersion: "2"}}
+	cache.Target("checkout", dependencies(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", UID: "worker-1-uid", ResourceVersion: "1"}}, otherNode), compute)
+	cache.Prune()
+	cache.Target("checkout", dependencies(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", UID: "worker-1-uid", ResourceVersion: "2"}}, otherNode), compute)
+	cache.Prune()
+
+	// Then
+	assert.Equal(t, 2, computations)
+}
+
+func TestTargetCachePrunesVanishedTargets(t *testing.T) {
+	// Given
+	cache := NewTargetCache("test")
+	compute := func() discovery_kit_api.Target { return discovery_kit_api.Target{} }
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod-uid", ResourceVersion: "1"}}
+	cache.Target("checkout", dependenciesOf(pod), compute)
+	cache.Target("gateway", dependenciesOf(pod), compute)
+	cache.Prune()
+
+	// When
+	cache.Target("checkout", dependenciesOf(pod), compute)
+	cache.Prune()
+
+	// Then
+	assert.Len(t, cache.entries, 1)
+	assert.Contains(t, cache.entries, "checkout")
+}
+
+func TestNilTargetCacheComputesTargets(t *testing.T) {
+	// Given
+	var cache *TargetCache
+	computations := 0
+	compute := func() discovery_kit_api.Target {
+		computations++
+		return discovery_kit_api.Target{Id: "checkout"}
+	}
+
+	// When
+	cache.Target("checkout", Dependencies{}, compute)
+	cache.Target("checkout", Dependencies{}, compute)
+	cache.Prune()
+
+	// Then
+	assert.Equal(t, 2, computations)
+}
+
+func dependenciesOf(objects ...metav1.Object) Dependencies {
+	var dependencies Dependencies
+	AddDependencies(&dependencies, objects...)
+	return dependencies
+}
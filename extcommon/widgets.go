@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcommon
+
+// The line chart widget isn't part of the action_kit_api version in use yet. The types below follow the line chart
+// widget of the action kit API, the platform renders them like any other widget.
+
+const (
+	LineChartWidgetType = "com.steadybit.widget.line_chart"
+	// LineChartIdentityModeSelect renders all series of the metric in one chart, the series are selectable.
+	LineChartIdentityModeSelect = "select"
+	// LineChartIdentityModeWidgetPerValue renders a chart for every value of the identity label.
+	LineChartIdentityModeWidgetPerValue = "widget-per-value"
+)
+
+// LineChartWidget plots a metric emitted by the status endpoint over time.
+type LineChartWidget struct {
+	Type     string                        `json:"type"`
+	Title    string                        `json:"title"`
+	Identity LineChartWidgetIdentityConfig `json:"identity"`
+	Tooltip  *LineChartWidgetTooltipConfig `json:"tooltip,omitempty"`
+}
+
+// LineChartWidgetIdentityConfig selects the metric and the label identifying a series, e.g. the pod name.
+type LineChartWidgetIdentityConfig struct {
+	MetricName string `json:"metricName"`
+	From       string `json:"from"`
+	Mode       string `json:"mode"`
+}
+
+type LineChartWidgetTooltipConfig struct {
+	MetricValueTitle  *string                         `json:"metricValueTitle,omitempty"`
+	MetricValueUnit   *string                         `json:"metricValueUnit,omitempty"`
+	AdditionalContent []LineChartWidgetTooltipContent `json:"additionalContent"`
+}
+
+// LineChartWidgetTooltipContent shows the value of a metric label in the tooltip.
+type LineChartWidgetTooltipContent struct {
+	From  string `json:"from"`
+	Title string `json:"title"`
+}
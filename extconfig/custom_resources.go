@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"strings"
+)
+
+// CustomResource declares a namespaced custom resource owning pods, e.g. an Argo Rollout or a Strimzi Kafka cluster.
+// The resource is watched by a dynamic informer, resolved as owner of pods and discovered as its own target type.
+type CustomResource struct {
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Resource string `json:"resource"`
+	Kind     string `json:"kind"`
+	// TargetType defaults to com.steadybit.extension_kubernetes.kubernetes-<kind>
+	TargetType string `json:"targetType"`
+	// ReplicasPath is the field path of the desired replicas, e.g. spec.replicas
+	ReplicasPath string `json:"replicasPath"`
+	// SelectorPath is the field path of the pod selector, e.g. spec.selector. The field may hold a label selector, a map
+	// of labels or a selector string. Without a selector, the pods are looked up by their owner references.
+	SelectorPath      string   `json:"selectorPath"`
+	AttributeExcludes []string `json:"attributeExcludes"`
+}
+
+// CustomResources is decoded from a JSON array.
+type CustomResources []CustomResource
+
+func (c *CustomResources) Decode(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(value), c)
+}
+
+// Contains checks whether a custom resource of the given kind is declared.
+func (c CustomResources) Contains(kind string) bool {
+	for _, customResource := range c {
+		if strings.EqualFold(customResource.Kind, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// AttributeName is the lower case kind, as used for attributes like k8s.rollout and k8s.workload-type.
+func (c CustomResource) AttributeName() string {
+	return strings.ToLower(c.Kind)
+}
+
+func (c CustomResource) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: c.Group, Version: c.Version, Resource: c.Resource}
+}
+
+func (c CustomResource) GetTargetType() string {
+	if c.TargetType != "" {
+		return c.TargetType
+	}
+	return fmt.Sprintf("com.steadybit.extension_kubernetes.kubernetes-%s", c.AttributeName())
+}
+
+func (c CustomResource) validate() error {
+	if c.Version == "" || c.Resource == "" || c.Kind == "" {
+		return fmt.Errorf("custom resource %s/%s is missing one of version, resource or kind", c.Group, c.Resource)
+	}
+	return nil
+}
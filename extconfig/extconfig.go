@@ -6,31 +6,54 @@ package extconfig
 import (
 	"github.com/kelseyhightower/envconfig"
 	"github.com/rs/zerolog/log"
+	"k8s.io/apimachinery/pkg/labels"
+	"time"
 )
 
 // Specification is the configuration specification for the extension. Configuration values can be applied
 // through environment variables. Learn more through the documentation of the envconfig package.
 // https://github.com/kelseyhightower/envconfig
 type Specification struct {
-	ClusterName                            string   `required:"true" split_words:"true"`
-	LabelFilter                            []string `required:"false" split_words:"true" default:"controller-revision-hash,pod-template-generation,pod-template-hash"`
-	ActiveAdviceList                       []string `required:"false" split_words:"true" default:"*"`
-	DisableDiscoveryExcludes               bool     `required:"false" split_words:"true" default:"false"`
-	LogKubernetesHttpRequests              bool     `required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledContainer             bool     `json:"discoveryDisabledContainer" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledDeployment            bool     `json:"discoveryDisabledDeployment" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledStatefulSet           bool     `json:"discoveryDisabledStatefulSet" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledDaemonSet             bool     `json:"discoveryDisabledDaemonSet" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledPod                   bool     `json:"discoveryDisabledPod" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledNode                  bool     `json:"discoveryDisabledNode" required:"false" split_words:"true" default:"false"`
-	DiscoveryDisabledCluster               bool     `json:"discoveryDisabledCluster" required:"false" split_words:"true" default:"false"`
-	DiscoveryAttributesExcludesContainer   []string `json:"discoveryAttributesExcludesContainer" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesDeployment  []string `json:"discoveryAttributesExcludesDeployment" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesStatefulSet []string `json:"discoveryAttributesExcludesStatefulSet" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesDaemonSet   []string `json:"discoveryAttributesExcludesDaemonSet" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesPod         []string `json:"discoveryAttributesExcludesPod" split_words:"true" required:"false"`
-	DiscoveryAttributesExcludesNode        []string `json:"discoveryAttributesExcludesNode" split_words:"true" required:"false"`
-	DiscoveryMaxPodCount                   int      `json:"discoveryMaxPodCount" split_words:"true" required:"false" default:"50"`
+	ClusterName                                 string               `required:"true" split_words:"true"`
+	LabelFilter                                 []string             `required:"false" split_words:"true" default:"controller-revision-hash,pod-template-generation,pod-template-hash"`
+	LabelAllowList                              []string             `json:"labelAllowList" required:"false" split_words:"true"`
+	LabelFilterOverrides                        LabelFilterOverrides `json:"labelFilterOverrides" required:"false" split_words:"true"`
+	ActiveAdviceList                            []string             `required:"false" split_words:"true" default:"*"`
+	DisableDiscoveryExcludes                    bool                 `required:"false" split_words:"true" default:"false"`
+	LogKubernetesHttpRequests                   bool                 `required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledContainer                  bool                 `json:"discoveryDisabledContainer" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledDeployment                 bool                 `json:"discoveryDisabledDeployment" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledStatefulSet                bool                 `json:"discoveryDisabledStatefulSet" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledDaemonSet                  bool                 `json:"discoveryDisabledDaemonSet" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledPod                        bool                 `json:"discoveryDisabledPod" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledNode                       bool                 `json:"discoveryDisabledNode" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledCluster                    bool                 `json:"discoveryDisabledCluster" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledNamespace                  bool                 `json:"discoveryDisabledNamespace" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledJob                        bool                 `json:"discoveryDisabledJob" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledCronJob                    bool                 `json:"discoveryDisabledCronJob" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledRollout                    bool                 `json:"discoveryDisabledRollout" required:"false" split_words:"true" default:"false"`
+	DiscoveryDisabledDeploymentConfig           bool                 `json:"discoveryDisabledDeploymentConfig" required:"false" split_words:"true" default:"false"`
+	DiscoveryAttributesExcludesContainer        []string             `json:"discoveryAttributesExcludesContainer" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesDeployment       []string             `json:"discoveryAttributesExcludesDeployment" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesStatefulSet      []string             `json:"discoveryAttributesExcludesStatefulSet" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesDaemonSet        []string             `json:"discoveryAttributesExcludesDaemonSet" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesPod              []string             `json:"discoveryAttributesExcludesPod" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesNode             []string             `json:"discoveryAttributesExcludesNode" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesNamespace        []string             `json:"discoveryAttributesExcludesNamespace" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesJob              []string             `json:"discoveryAttributesExcludesJob" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesCronJob          []string             `json:"discoveryAttributesExcludesCronJob" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesRollout          []string             `json:"discoveryAttributesExcludesRollout" split_words:"true" required:"false"`
+	DiscoveryAttributesExcludesDeploymentConfig []string             `json:"discoveryAttributesExcludesDeploymentConfig" split_words:"true" required:"false"`
+	DiscoveryExcludeNamespaces                  []string             `json:"discoveryExcludeNamespaces" split_words:"true" required:"false"`
+	DiscoveryIncludeNamespaces                  []string             `json:"discoveryIncludeNamespaces" split_words:"true" required:"false"`
+	DiscoveryExcludeNamespaceSelector           string               `json:"discoveryExcludeNamespaceSelector" split_words:"true" required:"false"`
+	DiscoveryIncludeNamespaceSelector           string               `json:"discoveryIncludeNamespaceSelector" split_words:"true" required:"false"`
+	DiscoveryExcludeSelector                    string               `json:"discoveryExcludeSelector" split_words:"true" required:"false"`
+	DiscoveryAnnotations                        []string             `json:"discoveryAnnotations" split_words:"true" required:"false"`
+	DiscoveryCustomResources                    CustomResources      `json:"discoveryCustomResources" split_words:"true" required:"false"`
+	DiscoveryMaxPodCount                        int                  `json:"discoveryMaxPodCount" split_words:"true" required:"false" default:"50"`
+	EventRetention                              time.Duration        `json:"eventRetention" split_words:"true" required:"false" default:"15m"`
+	DisableAuditTrail                           bool                 `json:"disableAuditTrail" split_words:"true" required:"false" default:"false"`
 }
 
 var (
@@ -48,4 +71,42 @@ func ValidateConfiguration() {
 	if Config.DisableDiscoveryExcludes {
 		log.Info().Msg("Discovery excludes are disabled. Will also discover workloads labeled with steadybit.com/discovery-disabled=true.")
 	}
+	for _, customResource := range Config.DiscoveryCustomResources {
+		if err := customResource.validate(); err != nil {
+			log.Fatal().Err(err).Msgf("Invalid custom resource configuration.")
+		}
+		if err := validatePatterns(customResource.AttributeExcludes); err != nil {
+			log.Fatal().Err(err).Msgf("Invalid attribute excludes of custom resource %s.", customResource.Kind)
+		}
+	}
+	for _, selector := range []string{Config.DiscoveryExcludeNamespaceSelector, Config.DiscoveryIncludeNamespaceSelector, Config.DiscoveryExcludeSelector} {
+		if _, err := labels.Parse(selector); err != nil {
+			log.Fatal().Err(err).Msgf("Invalid discovery label selector %s.", selector)
+		}
+	}
+	patterns := [][]string{
+		Config.DiscoveryExcludeNamespaces,
+		Config.DiscoveryIncludeNamespaces,
+		Config.LabelFilter,
+		Config.LabelAllowList,
+		Config.DiscoveryAttributesExcludesContainer,
+		Config.DiscoveryAttributesExcludesDeployment,
+		Config.DiscoveryAttributesExcludesStatefulSet,
+		Config.DiscoveryAttributesExcludesDaemonSet,
+		Config.DiscoveryAttributesExcludesPod,
+		Config.DiscoveryAttributesExcludesNode,
+		Config.DiscoveryAttributesExcludesNamespace,
+		Config.DiscoveryAttributesExcludesJob,
+		Config.DiscoveryAttributesExcludesCronJob,
+		Config.DiscoveryAttributesExcludesRollout,
+		Config.DiscoveryAttributesExcludesDeploymentConfig,
+	}
+	for _, override := range Config.LabelFilterOverrides {
+		patterns = append(patterns, override.Filter, override.Allow)
+	}
+	for _, p := range patterns {
+		if err := validatePatterns(p); err != nil {
+			log.Fatal().Err(err).Msgf("Invalid namespace patterns, label filter or attribute excludes.")
+		}
+	}
 }
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// LabelFilterOverride replaces the global label filter and/or allow list for a target type. Lists which aren't set
+// are inherited from the global configuration.
+type LabelFilterOverride struct {
+	Filter []string `json:"filter"`
+	Allow  []string `json:"allow"`
+}
+
+// LabelFilterOverrides by the lower case kind of the target type, e.g. deployment, pod or node. Decoded from a JSON object.
+type LabelFilterOverrides map[string]LabelFilterOverride
+
+func (o *LabelFilterOverrides) Decode(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(value), o)
+}
+
+// IncludesLabel tells whether a label is added to the targets of the given kind (e.g. deployment, pod or node).
+// A label is included, if it matches the allow list (when set) and doesn't match the label filter.
+func (s *Specification) IncludesLabel(kind string, key string) bool {
+	filter, allow := s.LabelFilter, s.LabelAllowList
+	if override, ok := s.LabelFilterOverrides[kind]; ok {
+		if override.Filter != nil {
+			filter = override.Filter
+		}
+		if override.Allow != nil {
+			allow = override.Allow
+		}
+	}
+	if len(allow) > 0 && !MatchesAny(allow, key) {
+		return false
+	}
+	return !MatchesAny(filter, key)
+}
+
+// compiledPatterns caches the regular expressions of glob and regex patterns.
+var compiledPatterns sync.Map
+
+// MatchesAny matches the key against patterns, which are either exact keys, globs (`*` matching any characters, `?`
+// a single one) or regular expressions enclosed in slashes, e.g. `/^.+\.istio\.io\/.+$/`. Invalid patterns never match.
+func MatchesAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if !isPattern(pattern) {
+			if pattern == key {
+				return true
+			}
+			continue
+		}
+		if re, err := compilePattern(pattern); err == nil && re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPattern(pattern string) bool {
+	return isRegexPattern(pattern) || strings.ContainsAny(pattern, "*?")
+}
+
+func isRegexPattern(pattern string) bool {
+	return len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/")
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := compiledPatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	var expression string
+	if isRegexPattern(pattern) {
+		expression = pattern[1 : len(pattern)-1]
+	} else {
+		expression = "^" + strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(pattern)) + "$"
+	}
+	re, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+	compiledPatterns.Store(pattern, re)
+	return re, nil
+}
+
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if isPattern(pattern) {
+			if _, err := compilePattern(pattern); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extconfig
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_MatchesAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		key      string
+		want     bool
+	}{
+		{name: "exact", patterns: []string{"pod-template-hash"}, key: "pod-template-hash", want: true},
+		{name: "exact mismatch", patterns: []string{"pod-template"}, key: "pod-template-hash", want: false},
+		{name: "no patterns", patterns: nil, key: "app", want: false},
+		{name: "trailing glob", patterns: []string{"k8s.label.*"}, key: "k8s.label.app", want: true},
+		{name: "leading glob", patterns: []string{"*.istio.io/rev"}, key: "service.istio.io/rev", want: true},
+		{name: "glob is anchored", patterns: []string{"*.istio.io"}, key: "service.istio.io/rev", want: false},
+		{name: "single character glob", patterns: []string{"tier-?"}, key: "tier-1", want: true},
+		{name: "glob quotes dots", patterns: []string{"a.*"}, key: "abc", want: false},
+		{name: "regex", patterns: []string{`/^.+\.istio\.io\/.+$/`}, key: "sidecar.istio.io/inject", want: true},
+		{name: "regex mismatch", patterns: []string{`/^.+\.istio\.io\/.+$/`}, key: "app.kubernetes.io/name", want: false},
+		{name: "invalid regex", patterns: []string{"/(/"}, key: "(", want: false},
+		{name: "any of", patterns: []string{"app", "team-*"}, key: "team-a", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchesAny(tt.patterns, tt.key))
+		})
+	}
+}
+
+func Test_IncludesLabel(t *testing.T) {
+	// Given
+	spec := Specification{
+		LabelFilter:    []string{"pod-template-hash", "*.istio.io/*"},
+		LabelAllowList: []string{"app", "team", "*.istio.io/*", "pod-template-hash"},
+		LabelFilterOverrides: LabelFilterOverrides{
+			"pod":  {Filter: []string{"pod-template-hash"}},
+			"node": {Allow: []string{}, Filter: []string{"/^kubernetes\\.io\\/.*/"}},
+		},
+	}
+
+	// Then
+	assert.True(t, spec.IncludesLabel("deployment", "app"))
+	assert.False(t, spec.IncludesLabel("deployment", "version"), "not on the allow list")
+	assert.False(t, spec.IncludesLabel("deployment", "sidecar.istio.io/inject"), "filtered")
+	assert.True(t, spec.IncludesLabel("pod", "sidecar.istio.io/inject"), "filter overridden for pods")
+	assert.False(t, spec.IncludesLabel("pod", "version"), "allow list inherited for pods")
+	assert.True(t, spec.IncludesLabel("node", "version"), "allow list cleared for nodes")
+	assert.False(t, spec.IncludesLabel("node", "kubernetes.io/hostname"))
+}
+
+func Test_LabelFilterOverrides_Decode(t *testing.T) {
+	// Given
+	var overrides LabelFilterOverrides
+
+	// When
+	err := overrides.Decode(`{"pod":{"filter":["a"]},"node":{"allow":["b*"]}}`)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, LabelFilterOverrides{
+		"pod":  {Filter: []string{"a"}},
+		"node": {Allow: []string{"b*"}},
+	}, overrides)
+}
+
+func Test_validatePatterns(t *testing.T) {
+	assert.NoError(t, validatePatterns([]string{"app", "k8s.*", "/^a.+$/"}))
+	assert.Error(t, validatePatterns([]string{"app", "/(/"}))
+}
@@ -7,14 +7,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
-	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extcommon"
 	"github.com/steadybit/extension-kubernetes/extconfig"
-	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	"reflect"
 	"strings"
@@ -31,7 +29,7 @@ var (
 
 func NewContainerDiscovery(ctx context.Context, k8s *client.Client) discovery_kit_sdk.EnrichmentDataDiscovery {
 	discovery := &containerDiscovery{k8s: k8s}
-	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s, reflect.TypeOf(corev1.Pod{}), reflect.TypeOf(corev1.Node{}))
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s, reflect.TypeOf(corev1.Pod{}), reflect.TypeOf(corev1.Namespace{}), reflect.TypeOf(corev1.Node{}))
 	return discovery_kit_sdk.NewCachedEnrichmentDataDiscovery(
 		discovery,
 		discovery_kit_sdk.WithRefreshEnrichmentDataNow(),
@@ -55,7 +53,7 @@ func (c *containerDiscovery) DescribeEnrichmentRules() []discovery_kit_api.Targe
 }
 
 func getContainerToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentRule {
-	return discovery_kit_api.TargetEnrichmentRule{
+	rule := discovery_kit_api.TargetEnrichmentRule{
 		Id:      "com.steadybit.extension_kubernetes.kubernetes-container-to-container",
 		Version: extbuild.GetSemverVersionStringOrUnknown(),
 		Src: discovery_kit_api.SourceOrDestination{
@@ -106,6 +104,14 @@ func getContainerToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentR
 				Matcher: discovery_kit_api.StartsWith,
 				Name:    "k8s.label.",
 			},
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.pod.annotation.",
+			},
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.annotation.",
+			},
 			{
 				Matcher: discovery_kit_api.Equals,
 				Name:    "k8s.replicaset",
@@ -130,23 +136,49 @@ func getContainerToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentR
 				Matcher: discovery_kit_api.Equals,
 				Name:    "k8s.statefulset",
 			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.job",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.cronjob",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.rollout",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.replicationcontroller",
+			},
+			{
+				Matcher: discovery_kit_api.Equals,
+				Name:    "k8s.deploymentconfig",
+			},
 		},
 	}
+	for _, customResource := range extconfig.Config.DiscoveryCustomResources {
+		if customResource.AttributeName() == client.RolloutCustomResource.AttributeName() || customResource.AttributeName() == client.DeploymentConfigCustomResource.AttributeName() {
+			continue
+		}
+		rule.Attributes = append(rule.Attributes, discovery_kit_api.Attribute{
+			Matcher: discovery_kit_api.Equals,
+			Name:    "k8s." + customResource.AttributeName(),
+		})
+	}
+	return rule
 }
 
 func (c *containerDiscovery) DiscoverEnrichmentData(_ context.Context) ([]discovery_kit_api.EnrichmentData, error) {
 	pods := c.k8s.Pods()
 
 	filteredPods := make([]*corev1.Pod, 0, len(pods))
-	if extconfig.Config.DisableDiscoveryExcludes {
-		filteredPods = pods
-	} else {
-		for _, p := range pods {
-			if client.IsExcludedFromDiscovery(p.ObjectMeta) {
-				continue
-			}
-			filteredPods = append(filteredPods, p)
+	for _, p := range pods {
+		if c.k8s.IsExcludedFromDiscovery(p.ObjectMeta) {
+			continue
 		}
+		filteredPods = append(filteredPods, p)
 	}
 
 	enrichmentDataList := make([]discovery_kit_api.EnrichmentData, 0, len(filteredPods))
@@ -174,11 +206,14 @@ func (c *containerDiscovery) DiscoverEnrichmentData(_ context.Context) ([]discov
 			}
 
 			for key, value := range podMetadata.Labels {
-				if !slices.Contains(extconfig.Config.LabelFilter, key) {
+				if extconfig.Config.IncludesLabel("container", key) {
 					attributes[fmt.Sprintf("k8s.pod.label.%v", key)] = []string{value}
 					attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
 				}
 			}
+			for key, value := range extcommon.GetAnnotationAttributes("pod", podMetadata) {
+				attributes[key] = value
+			}
 
 			if len(services) > 0 {
 				var serviceNames = make([]string, 0, len(services))
@@ -201,5 +236,5 @@ func (c *containerDiscovery) DiscoverEnrichmentData(_ context.Context) ([]discov
 			})
 		}
 	}
-	return discovery_kit_commons.ApplyAttributeExcludesToEnrichmentData(enrichmentDataList, extconfig.Config.DiscoveryAttributesExcludesContainer), nil
+	return extcommon.ApplyAttributeExcludesToEnrichmentData(enrichmentDataList, extconfig.Config.DiscoveryAttributesExcludesContainer), nil
 }
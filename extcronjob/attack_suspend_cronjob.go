@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcronjob
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+)
+
+func NewSuspendCronJobAction() action_kit_sdk.Action[extcommon.KubectlActionState] {
+	return &extcommon.KubectlAction{
+		Description:  getSuspendCronJobDescription(),
+		OptsProvider: suspendCronJob(),
+	}
+}
+
+func getSuspendCronJobDescription() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          SuspendCronJobActionId,
+		Label:       "Suspend CronJob",
+		Description: "Suspend a Kubernetes CronJob, so that no new jobs are scheduled. Already running jobs are not affected.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(cronJobIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: CronJobTargetType,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cronjob by cluster, namespace and cronjob"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.cronjob=\"\"",
+				},
+			}),
+		}),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Label:        "Duration",
+				Description:  extutil.Ptr("The duration of the action. The cronjob will be resumed after the action."),
+				Name:         "duration",
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("180s"),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status:  &action_kit_api.MutatingEndpointReferenceWithCallInterval{},
+		Stop:    &action_kit_api.MutatingEndpointReference{},
+	}
+}
+
+func suspendCronJob() extcommon.KubectlOptsProvider {
+	return func(ctx context.Context, request action_kit_api.PrepareActionRequestBody) (*extcommon.KubectlOpts, error) {
+		namespace := request.Target.Attributes["k8s.namespace"][0]
+		cronJob := request.Target.Attributes["k8s.cronjob"][0]
+
+		cronJobDefinition := client.K8S.CronJobByNamespaceAndName(namespace, cronJob)
+		if cronJobDefinition == nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to find cronjob %s/%s.", namespace, cronJob), nil)
+		}
+		if isSuspended(cronJobDefinition) {
+			return nil, extension_kit.ToError(fmt.Sprintf("CronJob %s/%s is already suspended.", namespace, cronJob), nil)
+		}
+
+		command := []string{"kubectl",
+			"patch",
+			fmt.Sprintf("cronjob/%s", cronJob),
+			fmt.Sprintf("--namespace=%s", namespace),
+			"--type=merge",
+			"--patch={\"spec\":{\"suspend\":true}}",
+		}
+
+		rollbackCommand := []string{"kubectl",
+			"patch",
+			fmt.Sprintf("cronjob/%s", cronJob),
+			fmt.Sprintf("--namespace=%s", namespace),
+			"--type=merge",
+			"--patch={\"spec\":{\"suspend\":false}}",
+		}
+
+		return &extcommon.KubectlOpts{
+			Command:         command,
+			RollbackCommand: &rollbackCommand,
+			LogTargetType:   "cronjob",
+			LogTargetName:   fmt.Sprintf("%s/%s", namespace, cronJob),
+			LogActionName:   "suspend cronjob",
+			AuditTarget:     &extcommon.ExecutionTarget{Kind: "CronJob", Namespace: namespace, Name: cronJob},
+		}, nil
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcronjob
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestSuspendCronJobPreparesCommands(t *testing.T) {
+	// Given
+	request := suspendCronJobRequest()
+	createCronJob(t, false)
+
+	action := NewSuspendCronJobAction()
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, request)
+	require.NoError(t, err)
+
+	// Then
+	require.Equal(t, []string{"kubectl", "patch", "cronjob/nightly-report", "--namespace=demo", "--type=merge", "--patch={\"spec\":{\"suspend\":true}}"}, state.Opts.Command)
+	require.Equal(t, []string{"kubectl", "patch", "cronjob/nightly-report", "--namespace=demo", "--type=merge", "--patch={\"spec\":{\"suspend\":false}}"}, *state.Opts.RollbackCommand)
+}
+
+func TestSuspendCronJobFailsIfAlreadySuspended(t *testing.T) {
+	// Given
+	request := suspendCronJobRequest()
+	createCronJob(t, true)
+
+	action := NewSuspendCronJobAction()
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "CronJob demo/nightly-report is already suspended.")
+}
+
+func suspendCronJobRequest() action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 100000,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace": {"demo"},
+				"k8s.cronjob":   {"nightly-report"},
+			},
+		}),
+	}
+}
+
+func createCronJob(t *testing.T, suspend bool) {
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	testClient, clientset := getTestClient(stopCh)
+	_, err := clientset.
+		BatchV1().
+		CronJobs("demo").
+		Create(context.Background(), &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly-report", Namespace: "demo"},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "0 2 * * *",
+				Suspend:  extutil.Ptr(suspend),
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return testClient.CronJobByNamespaceAndName("demo", "nightly-report") != nil
+	}, time.Second, 100*time.Millisecond)
+
+	client.K8S = testClient
+}
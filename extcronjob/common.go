@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcronjob
+
+const (
+	CronJobTargetType      = "com.steadybit.extension_kubernetes.kubernetes-cronjob"
+	SuspendCronJobActionId = "com.steadybit.extension_kubernetes.suspend_cronjob"
+	cronJobIcon            = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%3Cpath%20d%3D%22M12%202a10%2010%200%20100%2020%2010%2010%200%20000-20zm0%202a8%208%200%20110%2016%208%208%200%20010-16zm0%202a1%201%200%2000-1%201v5c0%20.27.1.52.3.7l3%203a1%201%200%20001.4-1.4L13%2011.58V7a1%201%200%2000-1-1z%22%20fill%3D%22currentColor%22%2F%3E%3C%2Fsvg%3E"
+)
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcronjob
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"reflect"
+	"time"
+)
+
+type cronJobDiscovery struct {
+	k8s *client.Client
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*cronJobDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*cronJobDiscovery)(nil)
+)
+
+func NewCronJobDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
+	discovery := &cronJobDiscovery{k8s: k8s}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
+		reflect.TypeOf(corev1.Pod{}),
+		reflect.TypeOf(corev1.Namespace{}),
+		reflect.TypeOf(batchv1.Job{}),
+		reflect.TypeOf(batchv1.CronJob{}),
+	)
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, 5*time.Second),
+	)
+}
+
+func (d *cronJobDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: CronJobTargetType,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("30s"),
+		},
+	}
+}
+
+func (d *cronJobDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       CronJobTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes CronJob", Other: "Kubernetes CronJobs"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(cronJobIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.cronjob"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+				{Attribute: "k8s.cronjob.schedule"},
+				{Attribute: "k8s.cronjob.suspend"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.cronjob",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *cronJobDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	cronJobs := d.k8s.CronJobs()
+
+	filteredCronJobs := make([]*batchv1.CronJob, 0, len(cronJobs))
+	for _, cronJob := range cronJobs {
+		if d.k8s.IsExcludedFromDiscovery(cronJob.ObjectMeta) {
+			continue
+		}
+		filteredCronJobs = append(filteredCronJobs, cronJob)
+	}
+
+	nodes := d.k8s.Nodes()
+	targets := make([]discovery_kit_api.Target, len(filteredCronJobs))
+	for i, cronJob := range filteredCronJobs {
+		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, cronJob.Namespace, cronJob.Name)
+		attributes := map[string][]string{
+			"k8s.namespace":                  {cronJob.Namespace},
+			"k8s.cronjob":                    {cronJob.Name},
+			"k8s.workload-type":              {"cronjob"},
+			"k8s.workload-owner":             {cronJob.Name},
+			"k8s.cluster-name":               {extconfig.Config.ClusterName},
+			"k8s.distribution":               {d.k8s.Distribution},
+			"k8s.cronjob.schedule":           {cronJob.Spec.Schedule},
+			"k8s.cronjob.suspend":            {fmt.Sprintf("%t", isSuspended(cronJob))},
+			"k8s.cronjob.concurrency-policy": {string(cronJob.Spec.ConcurrencyPolicy)},
+			"k8s.cronjob.active-jobs":        {fmt.Sprintf("%d", len(cronJob.Status.Active))},
+		}
+		if cronJob.Spec.TimeZone != nil {
+			attributes["k8s.cronjob.time-zone"] = []string{*cronJob.Spec.TimeZone}
+		}
+		if cronJob.Status.LastScheduleTime != nil {
+			attributes["k8s.cronjob.last-schedule-time"] = []string{cronJob.Status.LastScheduleTime.UTC().Format(time.RFC3339)}
+		}
+		if cronJob.Status.LastSuccessfulTime != nil {
+			attributes["k8s.cronjob.last-successful-time"] = []string{cronJob.Status.LastSuccessfulTime.UTC().Format(time.RFC3339)}
+		}
+		for key, value := range cronJob.ObjectMeta.Labels {
+			if extconfig.Config.IncludesLabel("cronjob", key) {
+				attributes[fmt.Sprintf("k8s.cronjob.label.%v", key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+
+		var activeJobs []string
+		var pods []*corev1.Pod
+		for _, ref := range cronJob.Status.Active {
+			job := d.k8s.JobByNamespaceAndName(cronJob.Namespace, ref.Name)
+			if job == nil {
+				continue
+			}
+			activeJobs = append(activeJobs, job.Name)
+			if job.Spec.Selector != nil {
+				pods = append(pods, d.k8s.PodsByLabelSelector(job.Spec.Selector, job.Namespace)...)
+			}
+		}
+		if len(activeJobs) > 0 {
+			attributes["k8s.job"] = activeJobs
+		}
+		for key, value := range extcommon.GetPodBasedAttributes("cronjob", cronJob.ObjectMeta, pods, nodes) {
+			attributes[key] = value
+		}
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: CronJobTargetType,
+			Label:      cronJob.Name,
+			Attributes: attributes,
+		}
+	}
+	return extcommon.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesCronJob), nil
+}
+
+func isSuspended(cronJob *batchv1.CronJob) bool {
+	return cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+}
+
+func (d *cronJobDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		getCronJobToContainerEnrichmentRule(),
+	}
+}
+
+func getCronJobToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentRule {
+	return discovery_kit_api.TargetEnrichmentRule{
+		Id:      "com.steadybit.extension_kubernetes.kubernetes-cronjob-to-container",
+		Version: extbuild.GetSemverVersionStringOrUnknown(),
+		Src: discovery_kit_api.SourceOrDestination{
+			Type: CronJobTargetType,
+			Selector: map[string]string{
+				"k8s.container.id.stripped": "${dest.container.id.stripped}",
+			},
+		},
+		Dest: discovery_kit_api.SourceOrDestination{
+			Type: "com.steadybit.extension_container.container",
+			Selector: map[string]string{
+				"container.id.stripped": "${src.k8s.container.id.stripped}",
+			},
+		},
+		Attributes: []discovery_kit_api.Attribute{
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.cronjob.label.",
+			},
+			{
+				Matcher: discovery_kit_api.Regex,
+				Name:    "^k8s\\.label\\.(?!topology).*",
+			},
+		},
+	}
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcronjob
+
+import (
+	"context"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func Test_cronJobDiscovery(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	extconfig.Config.DiscoveryMaxPodCount = 50
+
+	_, err := clientset.BatchV1().
+		CronJobs("default").
+		Create(context.Background(), &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nightly-report",
+				Namespace: "default",
+				Labels: map[string]string{
+					"team":         "reporting",
+					"secret-label": "secret",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule:          "0 2 * * *",
+				TimeZone:          extutil.Ptr("Europe/Berlin"),
+				ConcurrencyPolicy: batchv1.ForbidConcurrent,
+				Suspend:           extutil.Ptr(false),
+			},
+			Status: batchv1.CronJobStatus{
+				Active:             []v1.ObjectReference{{Kind: "Job", Namespace: "default", Name: "nightly-report-28000"}},
+				LastScheduleTime:   extutil.Ptr(metav1.NewTime(time.Date(2024, 5, 1, 2, 0, 0, 0, time.UTC))),
+				LastSuccessfulTime: extutil.Ptr(metav1.NewTime(time.Date(2024, 4, 30, 2, 5, 0, 0, time.UTC))),
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.BatchV1().
+		Jobs("default").
+		Create(context.Background(), &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "nightly-report-28000",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "nightly-report"}},
+			},
+			Spec: batchv1.JobSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"job-name": "nightly-report-28000"},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nightly-report-28000-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{"job-name": "nightly-report-28000"},
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{ContainerID: "containerd://abcdef", Name: "report"},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	d := &cronJobDiscovery{k8s: client}
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		targets, _ := d.DiscoverTargets(context.Background())
+		assert.Len(c, targets, 1)
+		assert.Len(c, client.Pods(), 1)
+		assert.NotNil(c, client.JobByNamespaceAndName("default", "nightly-report-28000"))
+	}, 1*time.Second, 100*time.Millisecond)
+
+	// Then
+	targets, _ := d.DiscoverTargets(context.Background())
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/default/nightly-report", target.Id)
+	assert.Equal(t, "nightly-report", target.Label)
+	assert.Equal(t, CronJobTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"host.hostname":                    {"unknown"},
+		"host.domainname":                  {"unknown"},
+		"k8s.cluster-name":                 {"development"},
+		"k8s.distribution":                 {"kubernetes"},
+		"k8s.namespace":                    {"default"},
+		"k8s.cronjob":                      {"nightly-report"},
+		"k8s.workload-type":                {"cronjob"},
+		"k8s.workload-owner":               {"nightly-report"},
+		"k8s.cronjob.schedule":             {"0 2 * * *"},
+		"k8s.cronjob.time-zone":            {"Europe/Berlin"},
+		"k8s.cronjob.suspend":              {"false"},
+		"k8s.cronjob.concurrency-policy":   {"Forbid"},
+		"k8s.cronjob.active-jobs":          {"1"},
+		"k8s.cronjob.last-schedule-time":   {"2024-05-01T02:00:00Z"},
+		"k8s.cronjob.last-successful-time": {"2024-04-30T02:05:00Z"},
+		"k8s.cronjob.label.team":           {"reporting"},
+		"k8s.label.team":                   {"reporting"},
+		"k8s.job":                          {"nightly-report-28000"},
+		"k8s.pod.name":                     {"nightly-report-28000-abcde"},
+		"k8s.container.id":                 {"containerd://abcdef"},
+		"k8s.container.id.stripped":        {"abcdef"},
+	}, target.Attributes)
+}
+
+func getTestClient(stopCh <-chan struct{}) (*client.Client, kubernetes.Interface) {
+	clientset := testclient.NewSimpleClientset()
+	client := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	return client, clientset
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcustomresource
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"reflect"
+	"time"
+)
+
+type customResourceDiscovery struct {
+	k8s        *client.Client
+	definition extconfig.CustomResource
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*customResourceDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*customResourceDiscovery)(nil)
+)
+
+func NewCustomResourceDiscovery(k8s *client.Client, definition extconfig.CustomResource) discovery_kit_sdk.TargetDiscovery {
+	discovery := &customResourceDiscovery{k8s: k8s, definition: definition}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s, reflect.TypeOf(corev1.Pod{}), reflect.TypeOf(corev1.Namespace{}), reflect.TypeOf(unstructured.Unstructured{}))
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, 5*time.Second),
+	)
+}
+
+func (d *customResourceDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: d.definition.GetTargetType(),
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("30s"),
+		},
+	}
+}
+
+func (d *customResourceDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       d.definition.GetTargetType(),
+		Label:    discovery_kit_api.PluralLabel{One: fmt.Sprintf("Kubernetes %s", d.definition.Kind), Other: fmt.Sprintf("Kubernetes %ss", d.definition.Kind)},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(customResourceIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: d.attribute()},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: d.attribute(),
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *customResourceDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	objects := d.k8s.CustomResources(d.definition.Kind)
+
+	filteredObjects := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, object := range objects {
+		if d.k8s.IsExcludedFromDiscovery(client.CustomResourceObjectMeta(object)) {
+			continue
+		}
+		filteredObjects = append(filteredObjects, object)
+	}
+
+	nodes := d.k8s.Nodes()
+	targets := make([]discovery_kit_api.Target, len(filteredObjects))
+	for i, object := range filteredObjects {
+		meta := client.CustomResourceObjectMeta(object)
+		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, meta.Namespace, meta.Name)
+		attributes := map[string][]string{
+			"k8s.namespace":      {meta.Namespace},
+			d.attribute():        {meta.Name},
+			"k8s.workload-type":  {d.definition.AttributeName()},
+			"k8s.workload-owner": {meta.Name},
+			"k8s.cluster-name":   {extconfig.Config.ClusterName},
+			"k8s.distribution":   {d.k8s.Distribution},
+		}
+		if replicas, ok := getReplicas(object, d.definition.ReplicasPath); ok {
+			attributes["k8s.specification.replicas"] = []string{fmt.Sprintf("%d", replicas)}
+		}
+		for _, ownerRef := range client.OwnerReferences(d.k8s, &meta).OwnerRefs {
+			attributes[fmt.Sprintf("k8s.%v", ownerRef.Kind)] = []string{ownerRef.Name}
+			attributes["k8s.workload-type"] = []string{ownerRef.Kind}
+			attributes["k8s.workload-owner"] = []string{ownerRef.Name}
+		}
+		for key, value := range meta.Labels {
+			if extconfig.Config.IncludesLabel(d.definition.AttributeName(), key) {
+				attributes[fmt.Sprintf("%s.label.%v", d.attribute(), key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+
+		var pods []*corev1.Pod
+		if selector := getSelector(object, d.definition.SelectorPath); selector != nil {
+			pods = d.k8s.PodsByLabelSelector(selector, meta.Namespace)
+		} else {
+			pods = d.k8s.PodsByOwnerUid(meta.UID)
+		}
+		for key, value := range extcommon.GetPodBasedAttributes(d.definition.AttributeName(), meta, pods, nodes) {
+			attributes[key] = value
+		}
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: d.definition.GetTargetType(),
+			Label:      meta.Name,
+			Attributes: attributes,
+		}
+	}
+	return extcommon.ApplyAttributeExcludes(targets, d.definition.AttributeExcludes), nil
+}
+
+func (d *customResourceDiscovery) attribute() string {
+	return "k8s." + d.definition.AttributeName()
+}
+
+func (d *customResourceDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		{
+			Id:      fmt.Sprintf("%s-to-container", d.definition.GetTargetType()),
+			Version: extbuild.GetSemverVersionStringOrUnknown(),
+			Src: discovery_kit_api.SourceOrDestination{
+				Type: d.definition.GetTargetType(),
+				Selector: map[string]string{
+					"k8s.container.id.stripped": "${dest.container.id.stripped}",
+				},
+			},
+			Dest: discovery_kit_api.SourceOrDestination{
+				Type: "com.steadybit.extension_container.container",
+				Selector: map[string]string{
+					"container.id.stripped": "${src.k8s.container.id.stripped}",
+				},
+			},
+			Attributes: []discovery_kit_api.Attribute{
+				{
+					Matcher: discovery_kit_api.StartsWith,
+					Name:    d.attribute() + ".label.",
+				},
+				{
+					Matcher: discovery_kit_api.Regex,
+					Name:    "^k8s\\.label\\.(?!topology).*",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcustomresource
+
+import (
+	"context"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+var rolloutDefinition = extconfig.CustomResource{
+	Group:        "argoproj.io",
+	Version:      "v1alpha1",
+	Resource:     "rollouts",
+	Kind:         "Rollout",
+	ReplicasPath: "spec.replicas",
+	SelectorPath: "spec.selector",
+}
+
+func Test_customResourceDiscoveryWithSelector(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	extconfig.Config.DiscoveryMaxPodCount = 50
+	client, clientset := getTestClient(stopCh, rolloutDefinition, rollout(map[string]interface{}{
+		"matchLabels": map[string]interface{}{"app": "checkout"},
+	}))
+	createPod(t, clientset, "checkout-5f8d9-x1y2z", map[string]string{"app": "checkout"}, nil)
+	createPod(t, clientset, "other", map[string]string{"app": "other"}, nil)
+
+	d := &customResourceDiscovery{k8s: client, definition: rolloutDefinition}
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, client.Pods(), 2)
+	}, 1*time.Second, 100*time.Millisecond)
+	targets, _ := d.DiscoverTargets(context.Background())
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/shop/checkout", target.Id)
+	assert.Equal(t, "checkout", target.Label)
+	assert.Equal(t, "com.steadybit.extension_kubernetes.kubernetes-rollout", target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"host.hostname":              {"unknown"},
+		"host.domainname":            {"unknown"},
+		"k8s.cluster-name":           {"development"},
+		"k8s.distribution":           {"kubernetes"},
+		"k8s.namespace":              {"shop"},
+		"k8s.rollout":                {"checkout"},
+		"k8s.workload-type":          {"rollout"},
+		"k8s.workload-owner":         {"checkout"},
+		"k8s.specification.replicas": {"3"},
+		"k8s.rollout.label.team":     {"payments"},
+		"k8s.label.team":             {"payments"},
+		"k8s.pod.name":               {"checkout-5f8d9-x1y2z"},
+		"k8s.container.id":           {"containerd://abcdef"},
+		"k8s.container.id.stripped":  {"abcdef"},
+	}, target.Attributes)
+}
+
+func Test_customResourceDiscoveryResolvesPodsByOwner(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DiscoveryMaxPodCount = 50
+	definition := rolloutDefinition
+	definition.SelectorPath = ""
+	k8sClient, clientset := getTestClient(stopCh, definition, rollout(nil))
+	_, err := clientset.AppsV1().
+		ReplicaSets("shop").
+		Create(context.Background(), &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "checkout-5f8d9",
+				Namespace:       "shop",
+				UID:             "replicaset-uid",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout", Name: "checkout", UID: "rollout-uid"}},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	createPod(t, clientset, "checkout-5f8d9-x1y2z", nil, []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "checkout-5f8d9", UID: "replicaset-uid"}})
+	createPod(t, clientset, "other", nil, nil)
+
+	d := &customResourceDiscovery{k8s: k8sClient, definition: definition}
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, k8sClient.Pods(), 2)
+		assert.NotNil(c, k8sClient.ReplicaSetByNamespaceAndName("shop", "checkout-5f8d9"))
+	}, 1*time.Second, 100*time.Millisecond)
+	targets, _ := d.DiscoverTargets(context.Background())
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"checkout-5f8d9-x1y2z"}, targets[0].Attributes["k8s.pod.name"])
+	pod := k8sClient.PodByNamespaceAndName("shop", "checkout-5f8d9-x1y2z")
+	assert.Equal(t, []client.OwnerReference{
+		{Name: "checkout-5f8d9", Kind: "replicaset"},
+		{Name: "checkout", Kind: "rollout"},
+	}, client.OwnerReferences(k8sClient, &pod.ObjectMeta).OwnerRefs)
+}
+
+func Test_getSelector(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  *metav1.LabelSelector
+	}{
+		{
+			name:  "label selector",
+			value: map[string]interface{}{"matchLabels": map[string]interface{}{"app": "checkout"}},
+			want:  &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+		},
+		{
+			name:  "labels",
+			value: map[string]interface{}{"app": "checkout"},
+			want:  &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+		},
+		{
+			name:  "selector string",
+			value: "app=checkout",
+			want:  &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}, MatchExpressions: []metav1.LabelSelectorRequirement{}},
+		},
+		{
+			name:  "empty selector",
+			value: map[string]interface{}{},
+			want:  nil,
+		},
+		{
+			name:  "no selector",
+			value: int64(42),
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			object := &unstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{"selector": tt.value},
+			}}
+			assert.Equal(t, tt.want, getSelector(object, ".spec.selector"))
+		})
+	}
+}
+
+func rollout(selector map[string]interface{}) *unstructured.Unstructured {
+	spec := map[string]interface{}{"replicas": int64(3)}
+	if selector != nil {
+		spec["selector"] = selector
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata": map[string]interface{}{
+			"name":      "checkout",
+			"namespace": "shop",
+			"uid":       "rollout-uid",
+			"labels":    map[string]interface{}{"team": "payments", "secret-label": "secret"},
+		},
+		"spec": spec,
+	}}
+}
+
+func createPod(t *testing.T, clientset kubernetes.Interface, name string, labels map[string]string, ownerReferences []metav1.OwnerReference) {
+	_, err := clientset.CoreV1().
+		Pods("shop").
+		Create(context.Background(), &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "shop",
+				Labels:          labels,
+				OwnerReferences: ownerReferences,
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{ContainerID: "containerd://abcdef", Name: "checkout"},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func getTestClient(stopCh <-chan struct{}, definition extconfig.CustomResource, objects ...runtime.Object) (*client.Client, kubernetes.Interface) {
+	extconfig.Config.DiscoveryCustomResources = extconfig.CustomResources{definition}
+	clientset := testclient.NewSimpleClientset()
+	client := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	gvr := schema.GroupVersionResource{Group: definition.Group, Version: definition.Version, Resource: definition.Resource}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{gvr: definition.Kind + "List"}, objects...)
+	client.WatchCustomResources(dynamicClient, stopCh, extconfig.Config.DiscoveryCustomResources)
+	return client, clientset
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extcustomresource
+
+import (
+	"github.com/rs/zerolog/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"strings"
+)
+
+func getField(object *unstructured.Unstructured, path string) (interface{}, bool) {
+	if path == "" {
+		return nil, false
+	}
+	value, found, err := unstructured.NestedFieldNoCopy(object.Object, strings.Split(strings.TrimPrefix(path, "."), ".")...)
+	if err != nil || !found {
+		return nil, false
+	}
+	return value, true
+}
+
+func getReplicas(object *unstructured.Unstructured, path string) (int64, bool) {
+	value, found := getField(object, path)
+	if !found {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	default:
+		log.Warn().Msgf("Field %s of %s/%s is no number.", path, object.GetNamespace(), object.GetName())
+		return 0, false
+	}
+}
+
+// getSelector reads a label selector, a map of labels or a selector string like app=checkout. Empty selectors are
+// ignored, as they would match all pods of the namespace.
+func getSelector(object *unstructured.Unstructured, path string) *metav1.LabelSelector {
+	value, found := getField(object, path)
+	if !found {
+		return nil
+	}
+
+	var selector *metav1.LabelSelector
+	switch v := value.(type) {
+	case string:
+		parsed, err := metav1.ParseToLabelSelector(v)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed to parse selector %s of %s/%s.", path, object.GetNamespace(), object.GetName())
+			return nil
+		}
+		selector = parsed
+	case map[string]interface{}:
+		_, hasMatchLabels := v["matchLabels"]
+		_, hasMatchExpressions := v["matchExpressions"]
+		if hasMatchLabels || hasMatchExpressions {
+			selector = &metav1.LabelSelector{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(v, selector); err != nil {
+				log.Warn().Err(err).Msgf("Failed to parse selector %s of %s/%s.", path, object.GetNamespace(), object.GetName())
+				return nil
+			}
+		} else {
+			selector = &metav1.LabelSelector{MatchLabels: map[string]string{}}
+			for key, label := range v {
+				if s, ok := label.(string); ok {
+					selector.MatchLabels[key] = s
+				}
+			}
+		}
+	default:
+		log.Warn().Msgf("Field %s of %s/%s is no selector.", path, object.GetNamespace(), object.GetName())
+		return nil
+	}
+
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		return nil
+	}
+	return selector
+}
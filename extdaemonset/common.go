@@ -4,5 +4,7 @@
 package extdaemonset
 
 const (
-	DaemonSetTargetType = "com.steadybit.extension_kubernetes.kubernetes-daemonset"
+	DaemonSetTargetType   = "com.steadybit.extension_kubernetes.kubernetes-daemonset"
+	PodCountCheckActionId = "com.steadybit.extension_kubernetes.daemonset_pod_count_check"
+	ContainerLogsActionId = "com.steadybit.extension_kubernetes.daemonset_container_logs"
 )
@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdaemonset
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+)
+
+func NewContainerLogsAction() action_kit_sdk.Action[extcommon.ContainerLogsState] {
+	return &extcommon.ContainerLogsAction{
+		Id:                ContainerLogsActionId,
+		TargetType:        DaemonSetTargetType,
+		WorkloadAttribute: "k8s.daemonset",
+		WorkloadLabel:     "DaemonSet",
+	}
+}
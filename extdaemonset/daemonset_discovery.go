@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
-	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
@@ -16,13 +15,13 @@ import (
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/utils/strings/slices"
 	"reflect"
 	"time"
 )
 
 type daemonSetDiscovery struct {
-	k8s *client.Client
+	k8s     *client.Client
+	targets *extcommon.TargetCache
 }
 
 var (
@@ -31,8 +30,8 @@ var (
 )
 
 func NewDaemonSetDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
-	discovery := &daemonSetDiscovery{k8s: k8s}
-	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s, reflect.TypeOf(corev1.Pod{}), reflect.TypeOf(appsv1.DaemonSet{}))
+	discovery := &daemonSetDiscovery{k8s: k8s, targets: extcommon.NewTargetCache("daemonset")}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s, reflect.TypeOf(corev1.Pod{}), reflect.TypeOf(corev1.Namespace{}), reflect.TypeOf(appsv1.DaemonSet{}))
 
 	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
 		discovery_kit_sdk.WithRefreshTargetsNow(),
@@ -76,52 +75,68 @@ func (d *daemonSetDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit
 	daemonsets := d.k8s.DaemonSets()
 
 	filteredDaemonSets := make([]*appsv1.DaemonSet, 0, len(daemonsets))
-	if extconfig.Config.DisableDiscoveryExcludes {
-		filteredDaemonSets = daemonsets
-	} else {
-		for _, ds := range daemonsets {
-			if client.IsExcludedFromDiscovery(ds.ObjectMeta) {
-				continue
-			}
-			filteredDaemonSets = append(filteredDaemonSets, ds)
+	for _, ds := range daemonsets {
+		if d.k8s.IsExcludedFromDiscovery(ds.ObjectMeta) {
+			continue
 		}
+		filteredDaemonSets = append(filteredDaemonSets, ds)
 	}
 
 	nodes := d.k8s.Nodes()
 	targets := make([]discovery_kit_api.Target, len(filteredDaemonSets))
 	for i, ds := range filteredDaemonSets {
+		pods := d.k8s.PodsByLabelSelector(ds.Spec.Selector, ds.Namespace)
+		services := d.k8s.ServicesMatchingToPodLabels(ds.Namespace, ds.Spec.Template.Labels)
+		routes := d.k8s.RoutesMatchingToServices(ds.Namespace, services)
+
+		var dependencies extcommon.Dependencies
+		extcommon.AddDependencies(&dependencies, ds)
+		extcommon.AddDependencies(&dependencies, pods...)
+		extcommon.AddNodeDependencies(&dependencies, pods, nodes)
+		extcommon.AddDependencies(&dependencies, services...)
+		extcommon.AddDependencies(&dependencies, routes...)
+
 		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, ds.Namespace, ds.Name)
-		attributes := map[string][]string{
-			"k8s.namespace":      {ds.Namespace},
-			"k8s.daemonset":      {ds.Name},
-			"k8s.workload-type":  {"daemonset"},
-			"k8s.workload-owner": {ds.Name},
-			"k8s.cluster-name":   {extconfig.Config.ClusterName},
-			"k8s.distribution":   {d.k8s.Distribution},
-		}
-		for key, value := range ds.ObjectMeta.Labels {
-			if !slices.Contains(extconfig.Config.LabelFilter, key) {
-				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+		targets[i] = d.targets.Target(targetName, dependencies, func() discovery_kit_api.Target {
+			attributes := map[string][]string{
+				"k8s.namespace":      {ds.Namespace},
+				"k8s.daemonset":      {ds.Name},
+				"k8s.workload-type":  {"daemonset"},
+				"k8s.workload-owner": {ds.Name},
+				"k8s.cluster-name":   {extconfig.Config.ClusterName},
+				"k8s.distribution":   {d.k8s.Distribution},
+			}
+			for key, value := range ds.ObjectMeta.Labels {
+				if extconfig.Config.IncludesLabel("daemonset", key) {
+					attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+				}
+			}
+			for key, value := range extcommon.GetAnnotationAttributes("daemonset", ds.ObjectMeta) {
+				attributes[key] = value
+			}
+			for key, value := range extcommon.GetPodBasedAttributes("daemonset", ds.ObjectMeta, pods, nodes) {
+				attributes[key] = value
+			}
+			for key, value := range extcommon.GetServiceNames(services) {
+				attributes[key] = value
+			}
+			for key, value := range extcommon.GetRouteAttributes(routes) {
+				attributes[key] = value
+			}
+			for key, value := range extcommon.GetKubeScoreForDaemonSet(ds, services) {
+				attributes[key] = value
 			}
-		}
-		for key, value := range extcommon.GetPodBasedAttributes("daemonset", ds.ObjectMeta, d.k8s.PodsByLabelSelector(ds.Spec.Selector, ds.Namespace), nodes) {
-			attributes[key] = value
-		}
-		for key, value := range extcommon.GetServiceNames(d.k8s.ServicesMatchingToPodLabels(ds.Namespace, ds.Spec.Template.Labels)) {
-			attributes[key] = value
-		}
-		for key, value := range extcommon.GetKubeScoreForDaemonSet(ds, d.k8s.ServicesMatchingToPodLabels(ds.Namespace, ds.Spec.Template.Labels)) {
-			attributes[key] = value
-		}
 
-		targets[i] = discovery_kit_api.Target{
-			Id:         targetName,
-			TargetType: DaemonSetTargetType,
-			Label:      ds.Name,
-			Attributes: attributes,
-		}
+			return discovery_kit_api.Target{
+				Id:         targetName,
+				TargetType: DaemonSetTargetType,
+				Label:      ds.Name,
+				Attributes: attributes,
+			}
+		})
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesDaemonSet), nil
+	d.targets.Prune()
+	return extcommon.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesDaemonSet), nil
 }
 
 func (d *daemonSetDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
@@ -151,6 +166,10 @@ func getDaemonSetToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentR
 				Matcher: discovery_kit_api.Regex,
 				Name:    "^k8s\\.label\\.(?!topology).*",
 			},
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.daemonset.annotation.",
+			},
 		},
 	}
 }
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdaemonset
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"time"
+)
+
+type PodCountCheckAction struct {
+}
+
+type PodCountCheckState struct {
+	Timeout           time.Time
+	PodCountCheckMode string
+	Namespace         string
+	DaemonSet         string
+	InitialCount      int
+	PodCountThreshold int
+}
+type PodCountCheckConfig struct {
+	Duration                 int
+	PodCountCheckMode        string
+	PodCountThreshold        int
+	PodCountThresholdPercent int
+}
+
+func NewPodCountCheckAction() action_kit_sdk.Action[PodCountCheckState] {
+	return PodCountCheckAction{}
+}
+
+var _ action_kit_sdk.Action[PodCountCheckState] = (*PodCountCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[PodCountCheckState] = (*PodCountCheckAction)(nil)
+
+func (f PodCountCheckAction) NewEmptyState() PodCountCheckState {
+	return PodCountCheckState{}
+}
+
+func (f PodCountCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          PodCountCheckActionId,
+		Label:       "DaemonSet Pod Count",
+		Description: "Verify pod counts of a DaemonSet",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr("data:image/svg+xml;base64,PHN2ZyB3aWR0aD0iMjQiIGhlaWdodD0iMjQiIHZpZXdCb3g9IjAgMCAyNCAyNCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KPHBhdGggZmlsbC1ydWxlPSJldmVub2RkIiBjbGlwLXJ1bGU9ImV2ZW5vZGQiIGQ9Ik0xMiA1LjY2MjY4QzEzLjU3IDUuNjYyNjggMTUgNi4yNjI2OCAxNi4wNyA3LjI1MjY4TDE5LjUgNS4zNTI2OEwxOS41IDUuMzUyNjZDMTkuNDMgNS4zMTI2NyAxOS4zNiA1LjI3MjY4IDE5LjI5IDUuMjQyNjhMMTMuMDggMi4yOTI2OEMxMi4yNSAxLjg5MjY4IDExLjI3IDEuOTAyNjggMTAuNDUgMi4zMjI2OEw0LjY2MDAyIDUuMjIyNjhDNC42MDkwMyA1LjI0NDU0IDQuNTYzMzUgNS4yNzE2OSA0LjUxNTI0IDUuMzAwMjlMNC41MTUyMiA1LjMwMDNDNC40OTcyOSA1LjMxMDk2IDQuNDc5MDMgNS4zMjE4MiA0LjQ2MDAyIDUuMzMyNjhMNy45MzAwMiA3LjI2MjY4QzkuMDAwMDIgNi4yNzI2OCAxMC40MyA1LjY3MjY4IDEyIDUuNjcyNjhWNS42NjI2OFpNNi42OSA4Ljg2MjY4QzYuMjUwNzIgOS42OTEzMiA2LjAwMDgyIDEwLjY0OTUgNiAxMS42NTc3TDYgMTEuNjUyN1YxMS42NjI3TDYgMTEuNjU3N0M2LjAwMjQyIDE0LjYzNTQgOC4xNjE1OSAxNy4wOTMgMTEgMTcuNTcyN1YyMS4yMTI3QzEwLjgxIDIxLjE2MjcgMTAuNjMgMjEuMDkyNyAxMC40NSAyMS4wMDI3TDQuNjYgMTguMTAyN0MzLjY0IDE3LjU5MjcgMyAxNi41NjI3IDMgMTUuNDIyN1Y3LjkwMjY4QzMgNy41NjI2OCAzLjA2IDcuMjIyNjggMy4xNyA2LjkwMjY4TDYuNjkgOC44NjI2OFpNMjAuODA1IDYuOTE1NDZMMjAuODEgNi45MTI2OEwyMC44IDYuOTAyNjhMMjAuODA1IDYuOTE1NDZaTTIwLjgwNSA2LjkxNTQ2TDE3LjMgOC44NjI2OEMxNy43NCA5LjcwMjY4IDE3Ljk5IDEwLjY1MjcgMTcuOTkgMTEuNjYyN0MxNy45OSAxNC42MzI3IDE1LjgzIDE3LjEwMjcgMTIuOTkgMTcuNTgyN1YyMS4wNzI3QzEyLjk5IDIxLjA3MjcgMTMuMDQgMjEuMDUyNyAxMy4wNyAyMS4wMzI3TDE5LjI4IDE4LjA4MjdDMjAuMzMgMTcuNTgyNyAyMC45OSAxNi41MzI3IDIwLjk5IDE1LjM3MjdWNy45NDI2OEMyMC45OSA3LjU4NzMzIDIwLjkzMTUgNy4yNDE3MSAyMC44MDUgNi45MTU0NlpNMTQgOS42ODI2OEMxNC4yNyA5LjQwMjY4IDE0LjcxIDkuMzkyNjggMTQuOTkgOS42NjI2OEwxNC45OCA5LjY1MjY4QzE1LjI2IDkuOTIyNjggMTUuMjcgMTAuMzYyNyAxNSAxMC42NDI3TDExLjY2IDE0LjE0MjdDMTEuNTMgMTQuMjcyNyAxMS4zNSAxNC4zNTI3IDExLjE2IDE0LjM1MjdDMTAuOTcgMTQuMzUyNyAxMC43OSAxNC4yODI3IDEwLjY2IDE0LjE0MjdMOSAxMi4zOTI3QzguNzQgMTIuMTEyNyA4Ljc0IDExLjY3MjcgOS4wMiAxMS40MDI3QzkuMyAxMS4xNDI3IDkuNzQgMTEuMTQyNyAxMC4wMSAxMS40MjI3TDExLjE3IDEyLjY1MjdMMTQgOS42ODI2OFoiIGZpbGw9IiMxRDI2MzIiLz4KPC9zdmc+Cg=="),
+		Category:    extutil.Ptr("Kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DaemonSetTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find daemonSet by cluster, namespace and daemonSet"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.daemonset=\"\"",
+				},
+			}),
+		}),
+		Parameters: extcommon.PodCountCheckParameters(),
+		Prepare:    action_kit_api.MutatingEndpointReference{},
+		Start:      action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f PodCountCheckAction) Prepare(_ context.Context, state *PodCountCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return preparePodCountCheckInternal(client.K8S, state, request)
+}
+
+func preparePodCountCheckInternal(k8s *client.Client, state *PodCountCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config PodCountCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	threshold := extcommon.SelectPodCountThreshold(config.PodCountCheckMode, config.PodCountThreshold, config.PodCountThresholdPercent)
+	if err := extcommon.ValidatePodCountThreshold(config.PodCountCheckMode, threshold); err != nil {
+		return nil, err
+	}
+
+	namespace := request.Target.Attributes["k8s.namespace"][0]
+	daemonSet := request.Target.Attributes["k8s.daemonset"][0]
+	d := k8s.DaemonSetByNamespaceAndName(namespace, daemonSet)
+	if d == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to find daemonSet %s/%s.", namespace, daemonSet), nil)
+	}
+
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.PodCountCheckMode = config.PodCountCheckMode
+	state.Namespace = namespace
+	state.DaemonSet = daemonSet
+	state.InitialCount = int(d.Status.NumberReady)
+	state.PodCountThreshold = threshold
+	return nil, nil
+}
+
+func (f PodCountCheckAction) Start(_ context.Context, _ *PodCountCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f PodCountCheckAction) Status(_ context.Context, state *PodCountCheckState) (*action_kit_api.StatusResult, error) {
+	return statusPodCountCheckInternal(client.K8S, state), nil
+}
+
+func statusPodCountCheckInternal(k8s *client.Client, state *PodCountCheckState) *action_kit_api.StatusResult {
+	daemonSet := k8s.DaemonSetByNamespaceAndName(state.Namespace, state.DaemonSet)
+	if daemonSet == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("DaemonSet %s not found", state.DaemonSet),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	desiredCount := extutil.Ptr(int(daemonSet.Status.DesiredNumberScheduled))
+
+	return extcommon.PodCountCheckStatus(extcommon.PodCountCheckInput{
+		Kind:         "DaemonSet",
+		Name:         state.DaemonSet,
+		Mode:         state.PodCountCheckMode,
+		Threshold:    state.PodCountThreshold,
+		InitialCount: state.InitialCount,
+		ReadyCount:   int(daemonSet.Status.NumberReady),
+		DesiredCount: desiredCount,
+		Timeout:      state.Timeout,
+	})
+}
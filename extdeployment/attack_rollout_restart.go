@@ -13,6 +13,8 @@ import (
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extconversion"
 	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"os/exec"
 	"strings"
 )
@@ -21,10 +23,11 @@ type DeploymentRolloutRestartAction struct {
 }
 
 type DeploymentRolloutRestartState struct {
-	Cluster    string `json:"cluster"`
-	Namespace  string `json:"namespace"`
-	Deployment string `json:"deployment"`
-	Wait       bool   `json:"wait"`
+	Cluster    string                 `json:"cluster"`
+	Namespace  string                 `json:"namespace"`
+	Deployment string                 `json:"deployment"`
+	Wait       bool                   `json:"wait"`
+	Audit      *extcommon.AttackAudit `json:"audit,omitempty"`
 }
 
 type DeploymentRolloutRestartConfig struct {
@@ -37,6 +40,7 @@ func NewDeploymentRolloutRestartAction() action_kit_sdk.Action[DeploymentRollout
 
 var _ action_kit_sdk.Action[DeploymentRolloutRestartState] = (*DeploymentRolloutRestartAction)(nil)
 var _ action_kit_sdk.ActionWithStatus[DeploymentRolloutRestartState] = (*DeploymentRolloutRestartAction)(nil)
+var _ action_kit_sdk.ActionWithStop[DeploymentRolloutRestartState] = (*DeploymentRolloutRestartAction)(nil)
 
 func (f DeploymentRolloutRestartAction) NewEmptyState() DeploymentRolloutRestartState {
 	return DeploymentRolloutRestartState{}
@@ -73,6 +77,7 @@ func (f DeploymentRolloutRestartAction) Describe() action_kit_api.ActionDescript
 		Prepare: action_kit_api.MutatingEndpointReference{},
 		Start:   action_kit_api.MutatingEndpointReference{},
 		Status:  extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{}),
+		Stop:    extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
 	}
 }
 
@@ -85,6 +90,8 @@ func (f DeploymentRolloutRestartAction) Prepare(_ context.Context, state *Deploy
 	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
 	state.Deployment = request.Target.Attributes["k8s.deployment"][0]
 	state.Wait = config.Wait
+	state.Audit = extcommon.NewAttackAudit(request, extcommon.ExecutionTarget{Kind: "Deployment", Namespace: state.Namespace, Name: state.Deployment}, "rollout restart deployment")
+	extcommon.RememberExecutionTarget(request, "Deployment")
 	return nil, nil
 }
 
@@ -101,12 +108,14 @@ func (f DeploymentRolloutRestartAction) Start(_ context.Context, state *Deployme
 	if cmdErr != nil {
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to execute rollout restart: %s", cmdOut), cmdErr)
 	}
+	state.Audit.Started(client.K8S)
 
 	return nil, nil
 }
 
 func (f DeploymentRolloutRestartAction) Status(_ context.Context, state *DeploymentRolloutRestartState) (*action_kit_api.StatusResult, error) {
 	if !state.Wait {
+		state.Audit.Stop(client.K8S)
 		return extutil.Ptr(action_kit_api.StatusResult{
 			Completed: true,
 		}), nil
@@ -126,7 +135,16 @@ func (f DeploymentRolloutRestartAction) Status(_ context.Context, state *Deploym
 
 	cmdOutStr := string(cmdOut)
 	completed := !strings.Contains(strings.ToLower(cmdOutStr), "waiting")
+	if completed {
+		state.Audit.Stop(client.K8S)
+	}
 	return extutil.Ptr(action_kit_api.StatusResult{
 		Completed: completed,
 	}), nil
 }
+
+func (f DeploymentRolloutRestartAction) Stop(_ context.Context, state *DeploymentRolloutRestartState) (*action_kit_api.StopResult, error) {
+	// the rollout can't be stopped, but the audit trail must not report the attack as in progress anymore
+	state.Audit.Stop(client.K8S)
+	return nil, nil
+}
@@ -112,6 +112,7 @@ func scaleDeployment() extcommon.KubectlOptsProvider {
 			LogTargetType:   "deployment",
 			LogTargetName:   fmt.Sprintf("%s/%s", namespace, deployment),
 			LogActionName:   "scale deployment",
+			AuditTarget:     &extcommon.ExecutionTarget{Kind: "Deployment", Namespace: namespace, Name: deployment},
 		}, nil
 	}
 }
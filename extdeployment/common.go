@@ -10,4 +10,9 @@ const (
 	RolloutRestartActionId  = "com.steadybit.extension_kubernetes.rollout-restart"
 	RolloutStatusActionId   = "com.steadybit.extension_kubernetes.rollout-status"
 	ScaleDeploymentActionId = "com.steadybit.extension_kubernetes.scale_deployment"
+	HpaScalingCheckActionId = "com.steadybit.extension_kubernetes.hpa_scaling_check"
+	PdbCheckActionId        = "com.steadybit.extension_kubernetes.deployment_pdb_check"
+	ContainerLogsActionId   = "com.steadybit.extension_kubernetes.deployment_container_logs"
+
+	podCountCheckIcon = "data:image/svg+xml;base64,PHN2ZyB3aWR0aD0iMjQiIGhlaWdodD0iMjQiIHZpZXdCb3g9IjAgMCAyNCAyNCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KPHBhdGggZmlsbC1ydWxlPSJldmVub2RkIiBjbGlwLXJ1bGU9ImV2ZW5vZGQiIGQ9Ik0xMiA1LjY2MjY4QzEzLjU3IDUuNjYyNjggMTUgNi4yNjI2OCAxNi4wNyA3LjI1MjY4TDE5LjUgNS4zNTI2OEwxOS41IDUuMzUyNjZDMTkuNDMgNS4zMTI2NyAxOS4zNiA1LjI3MjY4IDE5LjI5IDUuMjQyNjhMMTMuMDggMi4yOTI2OEMxMi4yNSAxLjg5MjY4IDExLjI3IDEuOTAyNjggMTAuNDUgMi4zMjI2OEw0LjY2MDAyIDUuMjIyNjhDNC42MDkwMyA1LjI0NDU0IDQuNTYzMzUgNS4yNzE2OSA0LjUxNTI0IDUuMzAwMjlMNC41MTUyMiA1LjMwMDNDNC40OTcyOSA1LjMxMDk2IDQuNDc5MDMgNS4zMjE4MiA0LjQ2MDAyIDUuMzMyNjhMNy45MzAwMiA3LjI2MjY4QzkuMDAwMDIgNi4yNzI2OCAxMC40MyA1LjY3MjY4IDEyIDUuNjcyNjhWNS42NjI2OFpNNi42OSA4Ljg2MjY4QzYuMjUwNzIgOS42OTEzMiA2LjAwMDgyIDEwLjY0OTUgNiAxMS42NTc3TDYgMTEuNjUyN1YxMS42NjI3TDYgMTEuNjU3N0M2LjAwMjQyIDE0LjYzNTQgOC4xNjE1OSAxNy4wOTMgMTEgMTcuNTcyN1YyMS4yMTI3QzEwLjgxIDIxLjE2MjcgMTAuNjMgMjEuMDkyNyAxMC40NSAyMS4wMDI3TDQuNjYgMTguMTAyN0MzLjY0IDE3LjU5MjcgMyAxNi41NjI3IDMgMTUuNDIyN1Y3LjkwMjY4QzMgNy41NjI2OCAzLjA2IDcuMjIyNjggMy4xNyA2LjkwMjY4TDYuNjkgOC44NjI2OFpNMjAuODA1IDYuOTE1NDZMMjAuODEgNi45MTI2OEwyMC44IDYuOTAyNjhMMjAuODA1IDYuOTE1NDZaTTIwLjgwNSA2LjkxNTQ2TDE3LjMgOC44NjI2OEMxNy43NCA5LjcwMjY4IDE3Ljk5IDEwLjY1MjcgMTcuOTkgMTEuNjYyN0MxNy45OSAxNC42MzI3IDE1LjgzIDE3LjEwMjcgMTIuOTkgMTcuNTgyN1YyMS4wNzI3QzEyLjk5IDIxLjA3MjcgMTMuMDQgMjEuMDUyNyAxMy4wNyAyMS4wMzI3TDE5LjI4IDE4LjA4MjdDMjAuMzMgMTcuNTgyNyAyMC45OSAxNi41MzI3IDIwLjk5IDE1LjM3MjdWNy45NDI2OEMyMC45OSA3LjU4NzMzIDIwLjkzMTUgNy4yNDE3MSAyMC44MDUgNi45MTU0NlpNMTQgOS42ODI2OEMxNC4yNyA5LjQwMjY4IDE0LjcxIDkuMzkyNjggMTQuOTkgOS42NjI2OEwxNC45OCA5LjY1MjY4QzE1LjI2IDkuOTIyNjggMTUuMjcgMTAuMzYyNyAxNSAxMC42NDI3TDExLjY2IDE0LjE0MjdDMTEuNTMgMTQuMjcyNyAxMS4zNSAxNC4zNTI3IDExLjE2IDE0LjM1MjdDMTAuOTcgMTQuMzUyNyAxMC43OSAxNC4yODI3IDEwLjY2IDE0LjE0MjdMOSAxMi4zOTI3QzguNzQgMTIuMTEyNyA4Ljc0IDExLjY3MjcgOS4wMiAxMS40MDI3QzkuMyAxMS4xNDI3IDkuNzQgMTEuMTQyNyAxMC4wMSAxMS40MjI3TDExLjE3IDEyLjY1MjdMMTQgOS42ODI2OFoiIGZpbGw9IiMxRDI2MzIiLz4KPC9zdmc+Cg=="
 )
@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+)
+
+func NewContainerLogsAction() action_kit_sdk.Action[extcommon.ContainerLogsState] {
+	return &extcommon.ContainerLogsAction{
+		Id:                ContainerLogsActionId,
+		TargetType:        DeploymentTargetType,
+		WorkloadAttribute: "k8s.deployment",
+		WorkloadLabel:     "Deployment",
+	}
+}
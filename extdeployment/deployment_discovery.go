@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
-	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
@@ -17,13 +16,14 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/utils/strings/slices"
+	policyv1 "k8s.io/api/policy/v1"
 	"reflect"
 	"time"
 )
 
 type deploymentDiscovery struct {
-	k8s *client.Client
+	k8s     *client.Client
+	targets *extcommon.TargetCache
 }
 
 var (
@@ -32,12 +32,14 @@ var (
 )
 
 func NewDeploymentDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
-	discovery := &deploymentDiscovery{k8s: k8s}
+	discovery := &deploymentDiscovery{k8s: k8s, targets: extcommon.NewTargetCache("deployment")}
 	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
 		reflect.TypeOf(corev1.Pod{}),
+		reflect.TypeOf(corev1.Namespace{}),
 		reflect.TypeOf(appsv1.Deployment{}),
 		reflect.TypeOf(autoscalingv2.HorizontalPodAutoscaler{}),
 		reflect.TypeOf(corev1.Service{}),
+		reflect.TypeOf(policyv1.PodDisruptionBudget{}),
 	)
 	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
 		discovery_kit_sdk.WithRefreshTargetsNow(),
@@ -81,64 +83,94 @@ func (d *deploymentDiscovery) DiscoverTargets(_ context.Context) ([]discovery_ki
 	deployments := d.k8s.Deployments()
 
 	filteredDeployments := make([]*appsv1.Deployment, 0, len(deployments))
-	if extconfig.Config.DisableDiscoveryExcludes {
-		filteredDeployments = deployments
-	} else {
-		for _, deployment := range deployments {
-			if client.IsExcludedFromDiscovery(deployment.ObjectMeta) {
-				continue
-			}
-			filteredDeployments = append(filteredDeployments, deployment)
+	for _, deployment := range deployments {
+		if d.k8s.IsExcludedFromDiscovery(deployment.ObjectMeta) {
+			continue
 		}
+		filteredDeployments = append(filteredDeployments, deployment)
 	}
 
 	targets := make([]discovery_kit_api.Target, len(filteredDeployments))
 
 	nodes := d.k8s.Nodes()
 	for i, deployment := range filteredDeployments {
-		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, deployment.Namespace, deployment.Name)
-		attributes := map[string][]string{
-			"k8s.namespace":                    {deployment.Namespace},
-			"k8s.deployment":                   {deployment.Name},
-			"k8s.workload-type":                {"deployment"},
-			"k8s.workload-owner":               {deployment.Name},
-			"k8s.cluster-name":                 {extconfig.Config.ClusterName},
-			"k8s.distribution":                 {d.k8s.Distribution},
-			"k8s.deployment.min-ready-seconds": {fmt.Sprintf("%d", deployment.Spec.MinReadySeconds)},
-		}
-		if deployment.Spec.Replicas != nil {
-			attributes["k8s.specification.replicas"] = []string{fmt.Sprintf("%d", *deployment.Spec.Replicas)}
-		}
-		for key, value := range deployment.ObjectMeta.Labels {
-			if !slices.Contains(extconfig.Config.LabelFilter, key) {
-				attributes[fmt.Sprintf("k8s.deployment.label.%v", key)] = []string{value}
-				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
-			}
-		}
-
-		for key, value := range extcommon.GetPodBasedAttributes("deployment", deployment.ObjectMeta, d.k8s.PodsByLabelSelector(deployment.Spec.Selector, deployment.Namespace), nodes) {
-			attributes[key] = value
-		}
-		for key, value := range extcommon.GetServiceNames(d.k8s.ServicesMatchingToPodLabels(deployment.Namespace, deployment.Spec.Template.Labels)) {
-			attributes[key] = value
+		pods := d.k8s.PodsByLabelSelector(deployment.Spec.Selector, deployment.Namespace)
+		services := d.k8s.ServicesMatchingToPodLabels(deployment.Namespace, deployment.Spec.Template.Labels)
+		routes := d.k8s.RoutesMatchingToServices(deployment.Namespace, services)
+		var pdbs []*policyv1.PodDisruptionBudget
+		if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+			pdbs = d.k8s.PodDisruptionBudgetsMatchingToPodLabels(deployment.Namespace, deployment.Spec.Template.Labels)
 		}
-
 		var hpa *autoscalingv2.HorizontalPodAutoscaler
 		if d.k8s.Permissions().CanReadHorizontalPodAutoscalers() {
 			hpa = d.k8s.HorizontalPodAutoscalerByNamespaceAndDeployment(deployment.Namespace, deployment.Name)
 		}
-		for key, value := range extcommon.GetKubeScoreForDeployment(deployment, d.k8s.ServicesMatchingToPodLabels(deployment.Namespace, deployment.Spec.Template.Labels), hpa) {
-			attributes[key] = value
-		}
 
-		targets[i] = discovery_kit_api.Target{
-			Id:         targetName,
-			TargetType: DeploymentTargetType,
-			Label:      deployment.Name,
-			Attributes: attributes,
+		var dependencies extcommon.Dependencies
+		extcommon.AddDependencies(&dependencies, deployment)
+		extcommon.AddDependencies(&dependencies, pods...)
+		extcommon.AddNodeDependencies(&dependencies, pods, nodes)
+		extcommon.AddDependencies(&dependencies, services...)
+		extcommon.AddDependencies(&dependencies, routes...)
+		extcommon.AddDependencies(&dependencies, pdbs...)
+		if hpa != nil {
+			extcommon.AddDependencies(&dependencies, hpa)
 		}
+
+		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, deployment.Namespace, deployment.Name)
+		targets[i] = d.targets.Target(targetName, dependencies, func() discovery_kit_api.Target {
+			attributes := map[string][]string{
+				"k8s.namespace":                    {deployment.Namespace},
+				"k8s.deployment":                   {deployment.Name},
+				"k8s.workload-type":                {"deployment"},
+				"k8s.workload-owner":               {deployment.Name},
+				"k8s.cluster-name":                 {extconfig.Config.ClusterName},
+				"k8s.distribution":                 {d.k8s.Distribution},
+				"k8s.deployment.min-ready-seconds": {fmt.Sprintf("%d", deployment.Spec.MinReadySeconds)},
+			}
+			if deployment.Spec.Replicas != nil {
+				attributes["k8s.specification.replicas"] = []string{fmt.Sprintf("%d", *deployment.Spec.Replicas)}
+			}
+			for key, value := range deployment.ObjectMeta.Labels {
+				if extconfig.Config.IncludesLabel("deployment", key) {
+					attributes[fmt.Sprintf("k8s.deployment.label.%v", key)] = []string{value}
+					attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+				}
+			}
+			for key, value := range extcommon.GetAnnotationAttributes("deployment", deployment.ObjectMeta) {
+				attributes[key] = value
+			}
+
+			for key, value := range extcommon.GetPodBasedAttributes("deployment", deployment.ObjectMeta, pods, nodes) {
+				attributes[key] = value
+			}
+			for key, value := range extcommon.GetServiceNames(services) {
+				attributes[key] = value
+			}
+			for key, value := range extcommon.GetRouteAttributes(routes) {
+				attributes[key] = value
+			}
+
+			if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+				for key, value := range extcommon.GetPodDisruptionBudgetAttributes(pdbs, deployment.Spec.Replicas) {
+					attributes[key] = value
+				}
+			}
+
+			for key, value := range extcommon.GetKubeScoreForDeployment(deployment, services, hpa) {
+				attributes[key] = value
+			}
+
+			return discovery_kit_api.Target{
+				Id:         targetName,
+				TargetType: DeploymentTargetType,
+				Label:      deployment.Name,
+				Attributes: attributes,
+			}
+		})
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesDeployment), nil
+	d.targets.Prune()
+	return extcommon.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesDeployment), nil
 }
 
 func (d *deploymentDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
@@ -168,6 +200,10 @@ func getDeploymentToContainerEnrichmentRule() discovery_kit_api.TargetEnrichment
 				Matcher: discovery_kit_api.StartsWith,
 				Name:    "k8s.deployment.label.",
 			},
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.deployment.annotation.",
+			},
 			{
 				Matcher: discovery_kit_api.Regex,
 				Name:    "^k8s\\.label\\.(?!topology).*",
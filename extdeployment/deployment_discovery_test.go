@@ -14,6 +14,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -32,6 +33,7 @@ func Test_deploymentDiscovery(t *testing.T) {
 		nodes                     []*v1.Node
 		deployment                *appsv1.Deployment
 		hpa                       *autoscalingv2.HorizontalPodAutoscaler
+		pdb                       *policyv1.PodDisruptionBudget
 		service                   *v1.Service
 		expectedAttributesExactly map[string][]string
 		expectedAttributes        map[string][]string
@@ -45,22 +47,23 @@ func Test_deploymentDiscovery(t *testing.T) {
 			nodes:      []*v1.Node{testNode("worker-1"), testNode("worker-2")},
 			deployment: testDeployment(nil),
 			expectedAttributesExactly: map[string][]string{
-				"host.hostname":                              {"worker-1", "worker-2"},
-				"host.domainname":                            {"worker-1.internal", "worker-2.internal"},
-				"k8s.namespace":                              {"default"},
-				"k8s.deployment":                             {"shop"},
-				"k8s.workload-type":                          {"deployment"},
-				"k8s.workload-owner":                         {"shop"},
-				"k8s.deployment.label.best-city":             {"Kevelaer"},
-				"k8s.label.best-city":                        {"Kevelaer"},
-				"k8s.deployment.min-ready-seconds":           {"10"},
-				"k8s.specification.replicas":                 {"3"},
-				"k8s.cluster-name":                           {"development"},
-				"k8s.pod.name":                               {"shop-pod-aaaaa", "shop-pod-bbbbb"},
-				"k8s.container.id":                           {"crio://abcdef-aaaaa", "crio://abcdef-bbbbb"},
-				"k8s.container.id.stripped":                  {"abcdef-aaaaa", "abcdef-bbbbb"},
-				"k8s.distribution":                           {"kubernetes"},
-				"k8s.specification.has-host-podantiaffinity": {"false"},
+				"host.hostname":                               {"worker-1", "worker-2"},
+				"host.domainname":                             {"worker-1.internal", "worker-2.internal"},
+				"k8s.namespace":                               {"default"},
+				"k8s.deployment":                              {"shop"},
+				"k8s.workload-type":                           {"deployment"},
+				"k8s.workload-owner":                          {"shop"},
+				"k8s.deployment.label.best-city":              {"Kevelaer"},
+				"k8s.label.best-city":                         {"Kevelaer"},
+				"k8s.deployment.min-ready-seconds":            {"10"},
+				"k8s.specification.replicas":                  {"3"},
+				"k8s.cluster-name":                            {"development"},
+				"k8s.pod.name":                                {"shop-pod-aaaaa", "shop-pod-bbbbb"},
+				"k8s.container.id":                            {"crio://abcdef-aaaaa", "crio://abcdef-bbbbb"},
+				"k8s.container.id.stripped":                   {"abcdef-aaaaa", "abcdef-bbbbb"},
+				"k8s.distribution":                            {"kubernetes"},
+				"k8s.specification.has-host-podantiaffinity":  {"false"},
+				"k8s.specification.has-pod-disruption-budget": {"false"},
 			},
 		},
 		{
@@ -320,6 +323,39 @@ func Test_deploymentDiscovery(t *testing.T) {
 				"k8s.specification.has-multiple-replica": {"true"},
 			},
 		},
+		{
+			name:       "should add pod disruption budget",
+			pods:       []*v1.Pod{testPod("aaaaa", nil)},
+			deployment: testDeployment(nil),
+			pdb:        testPDB(nil),
+			expectedAttributes: map[string][]string{
+				"k8s.pdb.name":                                {"shop-pdb"},
+				"k8s.pdb.min-available":                       {"2"},
+				"k8s.pdb.disruptions-allowed":                 {"1"},
+				"k8s.specification.has-pod-disruption-budget": {"true"},
+			},
+			expectedAttributesAbsence: []string{"k8s.pdb.max-unavailable"},
+		},
+		{
+			name:       "should ignore pod disruption budget for other pods",
+			pods:       []*v1.Pod{testPod("aaaaa", nil)},
+			deployment: testDeployment(nil),
+			pdb: testPDB(func(pdb *policyv1.PodDisruptionBudget) {
+				pdb.Spec.Selector.MatchLabels = map[string]string{"best-city": "Berlin"}
+			}),
+			expectedAttributes: map[string][]string{
+				"k8s.specification.has-pod-disruption-budget": {"false"},
+			},
+			expectedAttributesAbsence: []string{"k8s.pdb.name"},
+		},
+		{
+			name: "should not report missing pod disruption budget for single replica",
+			pods: []*v1.Pod{testPod("aaaaa", nil)},
+			deployment: testDeployment(func(deployment *appsv1.Deployment) {
+				deployment.Spec.Replicas = extutil.Ptr(int32(1))
+			}),
+			expectedAttributesAbsence: []string{"k8s.specification.has-pod-disruption-budget"},
+		},
 		{
 			name:                      "should not report multiple replicas if no service is defined",
 			pods:                      []*v1.Pod{testPod("aaaaa", nil)},
@@ -368,6 +404,14 @@ func Test_deploymentDiscovery(t *testing.T) {
 				require.NoError(t, err)
 			}
 
+			if tt.pdb != nil {
+				_, err = clientset.
+					PolicyV1().
+					PodDisruptionBudgets("default").
+					Create(context.Background(), tt.pdb, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
 			if tt.service != nil {
 				_, err := clientset.CoreV1().
 					Services("default").
@@ -457,6 +501,28 @@ func testHPA(modifier func(autoscaler *autoscalingv2.HorizontalPodAutoscaler)) *
 	return autoscaler
 }
 
+func testPDB(modifier func(pdb *policyv1.PodDisruptionBudget)) *policyv1.PodDisruptionBudget {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shop-pdb",
+			Namespace: "default",
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: extutil.Ptr(intstr.FromInt32(2)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"best-city": "Kevelaer"},
+			},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{
+			DisruptionsAllowed: 1,
+		},
+	}
+	if modifier != nil {
+		modifier(pdb)
+	}
+	return pdb
+}
+
 func testDeployment(modifier func(*appsv1.Deployment)) *appsv1.Deployment {
 	deployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
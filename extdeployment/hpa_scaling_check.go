@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"strings"
+	"time"
+)
+
+const (
+	hpaScaledUp              = "hpaScaledUp"
+	hpaReachedMaxReplicas    = "hpaReachedMaxReplicas"
+	hpaReturnedToBaseline    = "hpaReturnedToBaseline"
+	hpaReplicasCurrentMetric = "hpa_replicas_current_count"
+	hpaReplicasDesiredMetric = "hpa_replicas_desired_count"
+	hpaMetricValueMetric     = "hpa_metric_current_value"
+)
+
+type HpaScalingCheckAction struct {
+}
+
+type HpaScalingCheckState struct {
+	Timeout          time.Time
+	HpaCheckMode     string
+	Namespace        string
+	Deployment       string
+	BaselineReplicas int32
+	// ScaleUpObserved is set once the desired replicas exceeded the baseline, a return to the baseline is only accepted afterward.
+	ScaleUpObserved bool
+	// ReactionObserved is set once the expected reaction was observed, the check keeps emitting metrics until the timeout.
+	ReactionObserved bool
+}
+
+type HpaScalingCheckConfig struct {
+	Duration     int
+	HpaCheckMode string
+}
+
+func NewHpaScalingCheckAction() action_kit_sdk.Action[HpaScalingCheckState] {
+	return HpaScalingCheckAction{}
+}
+
+var _ action_kit_sdk.Action[HpaScalingCheckState] = (*HpaScalingCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[HpaScalingCheckState] = (*HpaScalingCheckAction)(nil)
+
+func (f HpaScalingCheckAction) NewEmptyState() HpaScalingCheckState {
+	return HpaScalingCheckState{}
+}
+
+func (f HpaScalingCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          HpaScalingCheckActionId,
+		Label:       "HPA Scaling",
+		Description: "Verify that the HorizontalPodAutoscaler of a deployment reacts within a timeout",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(podCountCheckIcon),
+		Category:    extutil.Ptr("Kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          DeploymentTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find deployment by cluster, namespace and deployment"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.deployment=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Timeout",
+				Description:  extutil.Ptr("How long should the check wait for the autoscaler to react. The HPA metrics are reported until the end of the duration."),
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "hpaCheckMode",
+				Label:        "Expected reaction",
+				Description:  extutil.Ptr("How should the autoscaler react? The baseline is the replica count at the start of the check, for the return to baseline it is the min replicas of the autoscaler."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr(hpaScaledUp),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "desired replicas > baseline",
+						Value: hpaScaledUp,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "desired replicas = max replicas",
+						Value: hpaReachedMaxReplicas,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "desired replicas > min replicas, then back to min replicas",
+						Value: hpaReturnedToBaseline,
+					},
+				}),
+			},
+		},
+		Widgets: extutil.Ptr([]action_kit_api.Widget{
+			hpaLineChartWidget("HPA current replicas", hpaReplicasCurrentMetric, "k8s.hpa", "Replicas"),
+			hpaLineChartWidget("HPA desired replicas", hpaReplicasDesiredMetric, "k8s.hpa", "Replicas"),
+			hpaLineChartWidget("HPA metrics", hpaMetricValueMetric, "metric", "Value"),
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("2s"),
+		}),
+	}
+}
+
+func hpaLineChartWidget(title string, metricName string, from string, valueTitle string) extcommon.LineChartWidget {
+	return extcommon.LineChartWidget{
+		Type:  extcommon.LineChartWidgetType,
+		Title: title,
+		Identity: extcommon.LineChartWidgetIdentityConfig{
+			MetricName: metricName,
+			From:       from,
+			Mode:       extcommon.LineChartIdentityModeWidgetPerValue,
+		},
+		Tooltip: &extcommon.LineChartWidgetTooltipConfig{
+			MetricValueTitle: extutil.Ptr(valueTitle),
+			AdditionalContent: []extcommon.LineChartWidgetTooltipContent{
+				{From: "k8s.deployment", Title: "Deployment"},
+				{From: "k8s.hpa", Title: "HPA"},
+			},
+		},
+	}
+}
+
+func (f HpaScalingCheckAction) Prepare(_ context.Context, state *HpaScalingCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return prepareHpaScalingCheckInternal(client.K8S, state, request)
+}
+
+func prepareHpaScalingCheckInternal(k8s *client.Client, state *HpaScalingCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config HpaScalingCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+
+	namespace := request.Target.Attributes["k8s.namespace"][0]
+	deployment := request.Target.Attributes["k8s.deployment"][0]
+	hpa := k8s.HorizontalPodAutoscalerByNamespaceAndDeployment(namespace, deployment)
+	if hpa == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to find a horizontal pod autoscaler for deployment %s/%s.", namespace, deployment), nil)
+	}
+
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.HpaCheckMode = config.HpaCheckMode
+	state.Namespace = namespace
+	state.Deployment = deployment
+	if config.HpaCheckMode == hpaReturnedToBaseline {
+		state.BaselineReplicas = 1
+		if hpa.Spec.MinReplicas != nil {
+			state.BaselineReplicas = *hpa.Spec.MinReplicas
+		}
+	} else {
+		// a scale up already in progress isn't a reaction
+		state.BaselineReplicas = max(hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas)
+	}
+	return nil, nil
+}
+
+func (f HpaScalingCheckAction) Start(_ context.Context, _ *HpaScalingCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f HpaScalingCheckAction) Status(_ context.Context, state *HpaScalingCheckState) (*action_kit_api.StatusResult, error) {
+	return statusHpaScalingCheckInternal(client.K8S, state), nil
+}
+
+func statusHpaScalingCheckInternal(k8s *client.Client, state *HpaScalingCheckState) *action_kit_api.StatusResult {
+	now := time.Now()
+	hpa := k8s.HorizontalPodAutoscalerByNamespaceAndDeployment(state.Namespace, state.Deployment)
+	if hpa == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Horizontal pod autoscaler for deployment %s not found", state.Deployment),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	current := hpa.Status.CurrentReplicas
+	desired := hpa.Status.DesiredReplicas
+	if desired > state.BaselineReplicas {
+		state.ScaleUpObserved = true
+	}
+
+	var checkError *action_kit_api.ActionKitError
+	if state.HpaCheckMode == hpaScaledUp && desired <= state.BaselineReplicas {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s's autoscaler didn't scale up. Baseline: %d, desired replicas: %d.", state.Deployment, state.BaselineReplicas, desired),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if state.HpaCheckMode == hpaReachedMaxReplicas && desired < hpa.Spec.MaxReplicas {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s's autoscaler didn't reach max replicas. Desired replicas: %d, max replicas: %d.", state.Deployment, desired, hpa.Spec.MaxReplicas),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if state.HpaCheckMode == hpaReturnedToBaseline && !state.ScaleUpObserved {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s's autoscaler didn't scale up, so it can't return to baseline. Baseline: %d, desired replicas: %d.", state.Deployment, state.BaselineReplicas, desired),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if state.HpaCheckMode == hpaReturnedToBaseline && (desired > state.BaselineReplicas || current > state.BaselineReplicas) {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s's autoscaler didn't return to baseline. Baseline: %d, current replicas: %d, desired replicas: %d.", state.Deployment, state.BaselineReplicas, current, desired),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+
+	if checkError == nil {
+		state.ReactionObserved = true
+	}
+
+	metrics := extutil.Ptr(toHpaMetrics(hpa, state.Deployment, now))
+	if !now.After(state.Timeout) {
+		return &action_kit_api.StatusResult{
+			Completed: false,
+			Metrics:   metrics,
+		}
+	}
+	if state.ReactionObserved {
+		checkError = nil
+	}
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Error:     checkError,
+		Metrics:   metrics,
+	}
+}
+
+func toHpaMetrics(hpa *autoscalingv2.HorizontalPodAutoscaler, deployment string, now time.Time) []action_kit_api.Metric {
+	labels := func() map[string]string {
+		return map[string]string{
+			"k8s.cluster-name": extconfig.Config.ClusterName,
+			"k8s.namespace":    hpa.Namespace,
+			"k8s.deployment":   deployment,
+			"k8s.hpa":          hpa.Name,
+		}
+	}
+
+	metrics := []action_kit_api.Metric{
+		{
+			Name:      extutil.Ptr(hpaReplicasCurrentMetric),
+			Metric:    labels(),
+			Timestamp: now,
+			Value:     float64(hpa.Status.CurrentReplicas),
+		},
+		{
+			Name:      extutil.Ptr(hpaReplicasDesiredMetric),
+			Metric:    labels(),
+			Timestamp: now,
+			Value:     float64(hpa.Status.DesiredReplicas),
+		},
+	}
+	for _, metricStatus := range hpa.Status.CurrentMetrics {
+		name, value, ok := hpaMetricValue(metricStatus)
+		if !ok {
+			continue
+		}
+		metricLabels := labels()
+		metricLabels["metric"] = name
+		metrics = append(metrics, action_kit_api.Metric{
+			Name:      extutil.Ptr(hpaMetricValueMetric),
+			Metric:    metricLabels,
+			Timestamp: now,
+			Value:     value,
+		})
+	}
+	return metrics
+}
+
+// hpaMetricValue returns a readable name (e.g. "resource/cpu") and the current value of a HPA metric. Utilization is
+// preferred over absolute values, as this is what HPAs are usually configured with.
+func hpaMetricValue(status autoscalingv2.MetricStatus) (string, float64, bool) {
+	var name string
+	var current *autoscalingv2.MetricValueStatus
+	switch status.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if status.Resource != nil {
+			name, current = fmt.Sprintf("resource/%s", status.Resource.Name), &status.Resource.Current
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if status.ContainerResource != nil {
+			name, current = fmt.Sprintf("container-resource/%s/%s", status.ContainerResource.Container, status.ContainerResource.Name), &status.ContainerResource.Current
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if status.Pods != nil {
+			name, current = fmt.Sprintf("pods/%s", status.Pods.Metric.Name), &status.Pods.Current
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if status.Object != nil {
+			name, current = fmt.Sprintf("object/%s/%s", strings.ToLower(status.Object.DescribedObject.Kind), status.Object.Metric.Name), &status.Object.Current
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if status.External != nil {
+			name, current = fmt.Sprintf("external/%s", status.External.Metric.Name), &status.External.Current
+		}
+	}
+	if current == nil {
+		return "", 0, false
+	}
+
+	if current.AverageUtilization != nil {
+		return name, float64(*current.AverageUtilization), true
+	} else if current.AverageValue != nil {
+		return name, current.AverageValue.AsApproximateFloat64(), true
+	} else if current.Value != nil {
+		return name, current.Value.AsApproximateFloat64(), true
+	}
+	return "", 0, false
+}
@@ -0,0 +1,352 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func TestPrepareHpaScalingCheckExtractsState(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":     1000 * 60,
+			"hpaCheckMode": hpaReachedMaxReplicas,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {"test"},
+				"k8s.namespace":    {"shop"},
+				"k8s.deployment":   {"checkout"},
+			},
+		}),
+	}
+	k8sclient, stopCh := createHpaTestClient(t, 2, 2)
+	defer close(stopCh)
+	state := NewHpaScalingCheckAction().NewEmptyState()
+
+	// When
+	result, err := prepareHpaScalingCheckInternal(k8sclient, &state, request)
+
+	// Then
+	require.Nil(t, err)
+	require.Nil(t, result)
+	require.True(t, state.Timeout.After(time.Now()))
+	require.Equal(t, hpaReachedMaxReplicas, state.HpaCheckMode)
+	require.Equal(t, "shop", state.Namespace)
+	require.Equal(t, "checkout", state.Deployment)
+	require.Equal(t, int32(2), state.BaselineReplicas)
+}
+
+func TestPrepareHpaScalingCheckFailsWithoutHpa(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":     1000 * 60,
+			"hpaCheckMode": hpaScaledUp,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {"test"},
+				"k8s.namespace":    {"shop"},
+				"k8s.deployment":   {"cart"},
+			},
+		}),
+	}
+	k8sclient, stopCh := createHpaTestClient(t, 2, 2)
+	defer close(stopCh)
+	state := NewHpaScalingCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareHpaScalingCheckInternal(k8sclient, &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "Failed to find a horizontal pod autoscaler for deployment shop/cart.")
+}
+
+func TestStatusHpaScalingCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		mode            string
+		baseline        int32
+		scaleUpObserved bool
+		current         int32
+		desired         int32
+		expectedError   string
+	}{
+		{
+			name:     "scaled up",
+			mode:     hpaScaledUp,
+			baseline: 2,
+			current:  2,
+			desired:  4,
+		},
+		{
+			name:          "not scaled up",
+			mode:          hpaScaledUp,
+			baseline:      2,
+			current:       2,
+			desired:       2,
+			expectedError: "checkout's autoscaler didn't scale up. Baseline: 2, desired replicas: 2.",
+		},
+		{
+			name:     "reached max replicas",
+			mode:     hpaReachedMaxReplicas,
+			baseline: 2,
+			current:  8,
+			desired:  10,
+		},
+		{
+			name:          "didn't reach max replicas",
+			mode:          hpaReachedMaxReplicas,
+			baseline:      2,
+			current:       4,
+			desired:       6,
+			expectedError: "checkout's autoscaler didn't reach max replicas. Desired replicas: 6, max replicas: 10.",
+		},
+		{
+			name:            "returned to baseline",
+			mode:            hpaReturnedToBaseline,
+			baseline:        2,
+			scaleUpObserved: true,
+			current:         2,
+			desired:         2,
+		},
+		{
+			name:            "didn't return to baseline",
+			mode:            hpaReturnedToBaseline,
+			baseline:        2,
+			scaleUpObserved: true,
+			current:         4,
+			desired:         2,
+			expectedError:   "checkout's autoscaler didn't return to baseline. Baseline: 2, current replicas: 4, desired replicas: 2.",
+		},
+		{
+			name:          "at baseline without scale up",
+			mode:          hpaReturnedToBaseline,
+			baseline:      2,
+			current:       2,
+			desired:       2,
+			expectedError: "checkout's autoscaler didn't scale up, so it can't return to baseline. Baseline: 2, desired replicas: 2.",
+		},
+	}
+	extconfig.Config.ClusterName = "development"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			k8sclient, stopCh := createHpaTestClient(t, tt.current, tt.desired)
+			defer close(stopCh)
+			state := HpaScalingCheckState{
+				Timeout:          time.Now().Add(-time.Second),
+				HpaCheckMode:     tt.mode,
+				Namespace:        "shop",
+				Deployment:       "checkout",
+				BaselineReplicas: tt.baseline,
+				ScaleUpObserved:  tt.scaleUpObserved,
+			}
+
+			// When
+			result := statusHpaScalingCheckInternal(k8sclient, &state)
+
+			// Then
+			assert.True(t, result.Completed)
+			if tt.expectedError == "" {
+				assert.Nil(t, result.Error)
+			} else {
+				require.NotNil(t, result.Error)
+				assert.Equal(t, tt.expectedError, result.Error.Title)
+			}
+
+			require.NotNil(t, result.Metrics)
+			metrics := *result.Metrics
+			require.Len(t, metrics, 3)
+			assert.Equal(t, hpaReplicasCurrentMetric, *metrics[0].Name)
+			assert.Equal(t, float64(tt.current), metrics[0].Value)
+			assert.Equal(t, hpaReplicasDesiredMetric, *metrics[1].Name)
+			assert.Equal(t, float64(tt.desired), metrics[1].Value)
+			assert.Equal(t, hpaMetricValueMetric, *metrics[2].Name)
+			assert.Equal(t, float64(85), metrics[2].Value)
+			assert.Equal(t, map[string]string{
+				"k8s.cluster-name": "development",
+				"k8s.namespace":    "shop",
+				"k8s.deployment":   "checkout",
+				"k8s.hpa":          "checkout-hpa",
+				"metric":           "resource/cpu",
+			}, metrics[2].Metric)
+		})
+	}
+}
+
+func TestStatusHpaScalingCheckEmitsMetricsUntilTimeout(t *testing.T) {
+	// Given
+	k8sclient, stopCh := createHpaTestClient(t, 2, 4)
+	defer close(stopCh)
+	state := HpaScalingCheckState{
+		Timeout:          time.Now().Add(time.Minute),
+		HpaCheckMode:     hpaScaledUp,
+		Namespace:        "shop",
+		Deployment:       "checkout",
+		BaselineReplicas: 2,
+	}
+
+	// When
+	result := statusHpaScalingCheckInternal(k8sclient, &state)
+
+	// Then
+	assert.False(t, result.Completed)
+	assert.Nil(t, result.Error)
+	assert.True(t, state.ReactionObserved)
+	require.NotNil(t, result.Metrics)
+	assert.Len(t, *result.Metrics, 3)
+
+	// When
+	state.Timeout = time.Now().Add(-time.Second)
+	state.BaselineReplicas = 4
+	result = statusHpaScalingCheckInternal(k8sclient, &state)
+
+	// Then
+	assert.True(t, result.Completed)
+	assert.Nil(t, result.Error, "the reaction was observed before")
+	require.NotNil(t, result.Metrics)
+}
+
+func TestPrepareHpaScalingCheckBaseline(t *testing.T) {
+	tests := []struct {
+		name             string
+		mode             string
+		current          int32
+		desired          int32
+		expectedBaseline int32
+	}{
+		{
+			name:             "current replicas",
+			mode:             hpaScaledUp,
+			current:          3,
+			desired:          3,
+			expectedBaseline: 3,
+		},
+		{
+			name:             "scale up in progress",
+			mode:             hpaScaledUp,
+			current:          3,
+			desired:          5,
+			expectedBaseline: 5,
+		},
+		{
+			name:             "min replicas for the return to baseline",
+			mode:             hpaReturnedToBaseline,
+			current:          3,
+			desired:          5,
+			expectedBaseline: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			k8sclient, stopCh := createHpaTestClient(t, tt.current, tt.desired)
+			defer close(stopCh)
+			state := NewHpaScalingCheckAction().NewEmptyState()
+
+			// When
+			_, err := prepareHpaScalingCheckInternal(k8sclient, &state, hpaScalingCheckRequest(tt.mode))
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedBaseline, state.BaselineReplicas)
+		})
+	}
+}
+
+func TestStatusHpaScalingCheckReturnToBaselineFailsIfHpaNeverScales(t *testing.T) {
+	// Given
+	k8sclient, stopCh := createHpaTestClient(t, 2, 2)
+	defer close(stopCh)
+	state := NewHpaScalingCheckAction().NewEmptyState()
+	_, err := prepareHpaScalingCheckInternal(k8sclient, &state, hpaScalingCheckRequest(hpaReturnedToBaseline))
+	require.NoError(t, err)
+
+	// When
+	running := statusHpaScalingCheckInternal(k8sclient, &state)
+	state.Timeout = time.Now().Add(-time.Second)
+	completed := statusHpaScalingCheckInternal(k8sclient, &state)
+
+	// Then
+	assert.False(t, running.Completed)
+	assert.False(t, state.ReactionObserved)
+	assert.True(t, completed.Completed)
+	require.NotNil(t, completed.Error)
+	assert.Equal(t, "checkout's autoscaler didn't scale up, so it can't return to baseline. Baseline: 2, desired replicas: 2.", completed.Error.Title)
+}
+
+func hpaScalingCheckRequest(mode string) action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":     1000 * 60,
+			"hpaCheckMode": mode,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {"test"},
+				"k8s.namespace":    {"shop"},
+				"k8s.deployment":   {"checkout"},
+			},
+		}),
+	}
+}
+
+func createHpaTestClient(t *testing.T, currentReplicas int32, desiredReplicas int32) (*client.Client, chan struct{}) {
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.
+		AutoscalingV2().
+		HorizontalPodAutoscalers("shop").
+		Create(context.Background(), &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-hpa",
+				Namespace: "shop",
+			},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+					Kind: "Deployment",
+					Name: "checkout",
+				},
+				MinReplicas: extutil.Ptr(int32(2)),
+				MaxReplicas: 10,
+			},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: currentReplicas,
+				DesiredReplicas: desiredReplicas,
+				CurrentMetrics: []autoscalingv2.MetricStatus{
+					{
+						Type: autoscalingv2.ResourceMetricSourceType,
+						Resource: &autoscalingv2.ResourceMetricStatus{
+							Name: corev1.ResourceCPU,
+							Current: autoscalingv2.MetricValueStatus{
+								AverageUtilization: extutil.Ptr(int32(85)),
+							},
+						},
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	k8sclient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	require.Eventually(t, func() bool {
+		return k8sclient.HorizontalPodAutoscalerByNamespaceAndDeployment("shop", "checkout") != nil
+	}, time.Second, 100*time.Millisecond)
+	return k8sclient, stopCh
+}
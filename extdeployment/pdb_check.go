@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdeployment
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+)
+
+func NewPdbCheckAction() action_kit_sdk.Action[extcommon.PodDisruptionBudgetCheckState] {
+	return &extcommon.PodDisruptionBudgetCheckAction{
+		Id:                PdbCheckActionId,
+		TargetType:        DeploymentTargetType,
+		WorkloadAttribute: "k8s.deployment",
+		WorkloadLabel:     "Deployment",
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
@@ -74,6 +75,31 @@ func TestPrepareCheckExtractsState(t *testing.T) {
 	require.Equal(t, 3, state.InitialCount)
 }
 
+func TestPrepareCheckRejectsInvalidThreshold(t *testing.T) {
+	// Given
+	state := PodCountCheckState{}
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":                 1000 * 10,
+			"podCountCheckMode":        extcommon.PodCountAtLeastPercentOfDesired,
+			"podCountThresholdPercent": 120,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+			},
+		}),
+	}
+
+	// When
+	result, err := preparePodCountCheckInternal(nil, &state, request)
+
+	// Then
+	require.Nil(t, result)
+	require.ErrorContains(t, err, "between 0 and 100")
+}
+
 func TestStatusCheckDeploymentNotFound(t *testing.T) {
 	// Given
 	state := PodCountCheckState{
@@ -111,6 +137,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 	type preparedState struct {
 		podCountCheckMode string
 		initialCount      int
+		podCountThreshold int
 	}
 	tests := []struct {
 		name               string
@@ -122,7 +149,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		{
 			name: "podCountMin1Success",
 			preparedState: preparedState{
-				podCountCheckMode: podCountMin1,
+				podCountCheckMode: extcommon.PodCountMin1,
 			},
 			readyCount:         1,
 			wantedErrorMessage: nil,
@@ -130,7 +157,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		{
 			name: "podCountMin1Failure",
 			preparedState: preparedState{
-				podCountCheckMode: podCountMin1,
+				podCountCheckMode: extcommon.PodCountMin1,
 			},
 			readyCount:         0,
 			wantedErrorMessage: extutil.Ptr("checkout has no ready pods."),
@@ -138,7 +165,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		{
 			name: "podCountEqualsDesiredCountSuccess",
 			preparedState: preparedState{
-				podCountCheckMode: podCountEqualsDesiredCount,
+				podCountCheckMode: extcommon.PodCountEqualsDesiredCount,
 			},
 			readyCount:         2,
 			desiredCount:       2,
@@ -147,7 +174,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		{
 			name: "podCountEqualsDesiredCountFailure",
 			preparedState: preparedState{
-				podCountCheckMode: podCountEqualsDesiredCount,
+				podCountCheckMode: extcommon.PodCountEqualsDesiredCount,
 			},
 			readyCount:         1,
 			desiredCount:       2,
@@ -156,7 +183,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		{
 			name: "podCountLessThanDesiredCountSuccess",
 			preparedState: preparedState{
-				podCountCheckMode: podCountLessThanDesiredCount,
+				podCountCheckMode: extcommon.PodCountLessThanDesiredCount,
 			},
 			readyCount:         1,
 			desiredCount:       2,
@@ -165,7 +192,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		{
 			name: "podCountLessThanDesiredCountFailure",
 			preparedState: preparedState{
-				podCountCheckMode: podCountLessThanDesiredCount,
+				podCountCheckMode: extcommon.PodCountLessThanDesiredCount,
 			},
 			readyCount:         2,
 			desiredCount:       2,
@@ -174,7 +201,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		{
 			name: "podCountIncreasedSuccess",
 			preparedState: preparedState{
-				podCountCheckMode: podCountIncreased,
+				podCountCheckMode: extcommon.PodCountIncreased,
 				initialCount:      1,
 			},
 			readyCount:         2,
@@ -183,7 +210,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		{
 			name: "podCountIncreasedFailure",
 			preparedState: preparedState{
-				podCountCheckMode: podCountIncreased,
+				podCountCheckMode: extcommon.PodCountIncreased,
 				initialCount:      2,
 			},
 			readyCount:         2,
@@ -192,7 +219,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		{
 			name: "podCountDecreasedSuccess",
 			preparedState: preparedState{
-				podCountCheckMode: podCountDecreased,
+				podCountCheckMode: extcommon.PodCountDecreased,
 				initialCount:      2,
 			},
 			readyCount:         1,
@@ -201,12 +228,72 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		{
 			name: "podCountDecreasedFailure",
 			preparedState: preparedState{
-				podCountCheckMode: podCountDecreased,
+				podCountCheckMode: extcommon.PodCountDecreased,
 				initialCount:      2,
 			},
 			readyCount:         2,
 			wantedErrorMessage: extutil.Ptr("checkout's pod count didn't decrease. Initial count: 2, current count: 2."),
 		},
+		{
+			name: "podCountAtLeastSuccess",
+			preparedState: preparedState{
+				podCountCheckMode: extcommon.PodCountAtLeast,
+				podCountThreshold: 3,
+			},
+			readyCount:         3,
+			desiredCount:       5,
+			wantedErrorMessage: nil,
+		},
+		{
+			name: "podCountAtLeastFailure",
+			preparedState: preparedState{
+				podCountCheckMode: extcommon.PodCountAtLeast,
+				podCountThreshold: 3,
+			},
+			readyCount:         2,
+			desiredCount:       5,
+			wantedErrorMessage: extutil.Ptr("checkout has only 2 of required 3 pods ready."),
+		},
+		{
+			name: "podCountAtLeastPercentOfDesiredSuccess",
+			preparedState: preparedState{
+				podCountCheckMode: extcommon.PodCountAtLeastPercentOfDesired,
+				podCountThreshold: 50,
+			},
+			readyCount:         2,
+			desiredCount:       4,
+			wantedErrorMessage: nil,
+		},
+		{
+			name: "podCountAtLeastPercentOfDesiredFailure",
+			preparedState: preparedState{
+				podCountCheckMode: extcommon.PodCountAtLeastPercentOfDesired,
+				podCountThreshold: 50,
+			},
+			readyCount:         1,
+			desiredCount:       4,
+			wantedErrorMessage: extutil.Ptr("checkout has only 1 of desired 4 pods ready, required are at least 50%."),
+		},
+		{
+			name: "podCountNeverBelowPercentOfDesiredSuccess",
+			preparedState: preparedState{
+				podCountCheckMode: extcommon.PodCountNeverBelowPercentOfDesired,
+				podCountThreshold: 75,
+			},
+			readyCount:         3,
+			desiredCount:       4,
+			wantedErrorMessage: nil,
+		},
+		{
+			name: "podCountNeverBelowPercentOfDesiredFailure",
+			preparedState: preparedState{
+				podCountCheckMode: extcommon.PodCountNeverBelowPercentOfDesired,
+				podCountThreshold: 75,
+			},
+			readyCount:         2,
+			desiredCount:       4,
+			wantedErrorMessage: extutil.Ptr("checkout dropped to 2 of desired 4 pods ready, required are at least 75%."),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -217,6 +304,7 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 				Namespace:         "shop",
 				Deployment:        "checkout",
 				InitialCount:      tt.preparedState.initialCount,
+				PodCountThreshold: tt.preparedState.podCountThreshold,
 			}
 
 			clientset := testclient.NewSimpleClientset()
@@ -251,3 +339,69 @@ func Test_statusPodCountCheckInternal(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusCheckNeverBelowPercentOfDesiredIsAssertedUntilTimeout(t *testing.T) {
+	// Given
+	state := PodCountCheckState{
+		Timeout:           time.Now().Add(time.Minute * 1),
+		PodCountCheckMode: extcommon.PodCountNeverBelowPercentOfDesired,
+		Namespace:         "shop",
+		Deployment:        "checkout",
+		PodCountThreshold: 50,
+	}
+
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.
+		AppsV1().
+		Deployments("shop").
+		Create(context.Background(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout",
+				Namespace: "shop",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: extutil.Ptr(int32(4)),
+			},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas: 4,
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+
+	// When
+	result := statusPodCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.False(t, result.Completed)
+	require.Nil(t, result.Error)
+
+	// When
+	_, err = clientset.
+		AppsV1().
+		Deployments("shop").
+		UpdateStatus(context.Background(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout",
+				Namespace: "shop",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: extutil.Ptr(int32(4)),
+			},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas: 1,
+			},
+		}, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return k8sclient.DeploymentByNamespaceAndName("shop", "checkout").Status.ReadyReplicas == 1
+	}, time.Second, 100*time.Millisecond)
+	result = statusPodCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	require.Equal(t, "checkout dropped to 1 of desired 4 pods ready, required are at least 50%.", result.Error.Title)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/steadybit/extension-kubernetes/extcluster"
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"time"
 )
 
@@ -23,12 +24,16 @@ type PodCountMetricsAction struct {
 }
 
 type PodCountMetricsState struct {
-	End         time.Time
-	LastMetrics map[string]int32
+	End           time.Time
+	Namespace     string
+	LabelSelector string
+	LastMetrics   map[string]int32
 }
 
 type PodCountMetricsConfig struct {
-	Duration int
+	Duration      int
+	Namespace     string
+	LabelSelector string
 }
 
 func NewPodCountMetricsAction() action_kit_sdk.Action[PodCountMetricsState] {
@@ -46,7 +51,7 @@ func (f PodCountMetricsAction) Describe() action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
 		Id:          PodCountMetricActionId,
 		Label:       "Pod Count Metrics",
-		Description: "Collects information about pod counts (desired vs. actual count).",
+		Description: "Collects information about pod counts (desired vs. actual count) of deployments, statefulsets and daemonsets.",
 		Version:     extbuild.GetSemverVersionStringOrUnknown(),
 		Icon:        extutil.Ptr("data:image/svg+xml;base64,PHN2ZyB3aWR0aD0iMjQiIGhlaWdodD0iMjQiIHZpZXdCb3g9IjAgMCAyNCAyNCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KPHBhdGggZmlsbC1ydWxlPSJldmVub2RkIiBjbGlwLXJ1bGU9ImV2ZW5vZGQiIGQ9Ik0xMC40NSAyLjMyTDQuNjYgNS4yMlY1LjIxQzQuNjMyMTYgNS4yMjU5MSA0LjYwNDMzIDUuMjQwMjMgNC41NzcxMiA1LjI1NDIzQzQuNTM1OTEgNS4yNzU0NCA0LjQ5NjE0IDUuMjk1OTEgNC40NiA1LjMyTDcuOTMgNy4yNUM5IDYuMjYgMTAuNDMgNS42NiAxMiA1LjY2QzEzLjU3IDUuNjYgMTUgNi4yNiAxNi4wNyA3LjI1TDE5LjUgNS4zNUMxOS40MyA1LjMxIDE5LjM2IDUuMjcgMTkuMjkgNS4yNEwxMy4wOCAyLjI5QzEyLjI1IDEuOSAxMS4yOCAxLjkxIDEwLjQ1IDIuMzJaTTYuNjg4MTggOC44NTM0NEw2LjcgOC44Nkw2LjY5IDguODVDNi42ODkzOSA4Ljg1MTE1IDYuNjg4NzkgOC44NTIyOSA2LjY4ODE4IDguODUzNDRaTTYuNjg4MTggOC44NTM0NEwzLjE3IDYuOUMzLjA2IDcuMjIgMyA3LjU2IDMgNy45VjE1LjQyQzMgMTYuNTYgMy42NCAxNy41OSA0LjY2IDE4LjFMMTAuNDUgMjFDMTAuNjMgMjEuMDkgMTAuODEgMjEuMTYgMTEgMjEuMjFWMTcuNTdDOC4xNiAxNy4wOSA2IDE0LjYzIDYgMTEuNjVDNiAxMC42NDE0IDYuMjQ5MzEgOS42ODI2NSA2LjY4ODE4IDguODUzNDRaTTEzLjAxIDIxLjA3VjE3LjU4TDEzIDE3LjU3QzE1Ljg0IDE3LjA5IDE4IDE0LjYyIDE4IDExLjY1QzE4IDEwLjY0IDE3Ljc1IDkuNjkgMTcuMzEgOC44NUwyMC44MiA2LjlDMjAuOTUgNy4yMyAyMS4wMSA3LjU4IDIxLjAxIDcuOTRWMTUuMzdDMjEuMDEgMTYuNTMgMjAuMzUgMTcuNTggMTkuMyAxOC4wOEwxMy4wOSAyMS4wM0MxMy4wNiAyMS4wNSAxMy4wMSAyMS4wNyAxMy4wMSAyMS4wN1pNMTQuMTIgMTIuMDRIMTUuNjRMMTUuNjUgMTIuMDNDMTUuOTYgMTIuMDMgMTYuMjEgMTIuMjUgMTYuMjEgMTIuNTNDMTYuMjEgMTIuODEgMTUuOTYgMTMuMDMgMTUuNjUgMTMuMDNIMTQuNDVMMTMuNDMgMTQuNkMxMy4zMyAxNC43NiAxMy4xNCAxNC44NSAxMi45NCAxNC44NUgxMi45QzEyLjY4IDE0Ljg0IDEyLjQ5IDE0LjcxIDEyLjQxIDE0LjUzTDExLjA2IDExLjMzTDEwLjQyIDEyLjczQzEwLjM0IDEyLjkyIDEwLjEzIDEzLjA0IDkuOTAwMDEgMTMuMDRIOC4zODAwMUM4LjA3MDAxIDEzLjA0IDcuODIwMDEgMTIuODEgNy44MjAwMSAxMi41NEM3LjgyMDAxIDEyLjI3IDguMDcwMDEgMTIuMDQgOC4zODAwMSAxMi4wNEg5LjUyMDAxTDEwLjU2IDkuNzdDMTAuNjUgOS41OCAxMC44NSA5LjQ2IDExLjA4IDkuNDZDMTEuMzEgOS40NiAxMS41MiA5LjU5IDExLjYgOS43OEwxMy4wNCAxMy4xOUwxMy42MyAxMi4yOUMxMy43MyAxMi4xNCAxMy45MiAxMi4wNCAxNC4xMiAxMi4wNFoiIGZpbGw9IiMxRDI2MzIiLz4KPC9zdmc+Cg=="),
 		Category:    extutil.Ptr("Kubernetes"),
@@ -73,6 +78,24 @@ func (f PodCountMetricsAction) Describe() action_kit_api.ActionDescription {
 				Order:        extutil.Ptr(1),
 				Required:     extutil.Ptr(true),
 			},
+			{
+				Name:        "namespace",
+				Label:       "Namespace",
+				Description: extutil.Ptr("Only collect pod counts of workloads in this namespace. Leave empty for all namespaces."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
+			{
+				Name:        "labelSelector",
+				Label:       "Label Selector",
+				Description: extutil.Ptr("Only collect pod counts of workloads matching this label selector (e.g. 'app=shop,tier!=cache'). Leave empty for all workloads."),
+				Type:        action_kit_api.String,
+				Order:       extutil.Ptr(3),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
 		},
 		Widgets: extutil.Ptr([]action_kit_api.Widget{
 			action_kit_api.PredefinedWidget{
@@ -93,7 +116,12 @@ func (f PodCountMetricsAction) Prepare(_ context.Context, state *PodCountMetrics
 	if err := extconversion.Convert(request.Config, &config); err != nil {
 		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
 	}
+	if _, err := labels.Parse(config.LabelSelector); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Invalid label selector '%s'.", config.LabelSelector), err)
+	}
 	state.End = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = config.Namespace
+	state.LabelSelector = config.LabelSelector
 	state.LastMetrics = make(map[string]int32)
 	return nil, nil
 }
@@ -109,11 +137,29 @@ func (f PodCountMetricsAction) Status(_ context.Context, state *PodCountMetricsS
 func statusPodCountMetricsInternal(k8s *client.Client, state *PodCountMetricsState) *action_kit_api.StatusResult {
 	now := time.Now()
 
+	selector, err := labels.Parse(state.LabelSelector)
+	if err != nil {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Invalid label selector '%s'.", state.LabelSelector),
+				Detail: extutil.Ptr(err.Error()),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
 	var metrics []action_kit_api.Metric
-	for _, d := range k8s.Deployments() {
-		if hasChanges(d, state) {
-			for _, m := range toMetrics(d, now) {
-				state.LastMetrics[getMetricKey(d, *m.Name)] = int32(m.Value)
+	for _, w := range workloadPodCounts(k8s) {
+		if state.Namespace != "" && w.namespace != state.Namespace {
+			continue
+		}
+		if !selector.Matches(labels.Set(w.labels)) {
+			continue
+		}
+		if hasChanges(w, state) {
+			for _, m := range toMetrics(w, now) {
+				state.LastMetrics[getMetricKey(w, *m.Name)] = int32(m.Value)
 				metrics = append(metrics, m)
 			}
 		}
@@ -125,70 +171,138 @@ func statusPodCountMetricsInternal(k8s *client.Client, state *PodCountMetricsSta
 	}
 }
 
-func hasChanges(deployment *appsv1.Deployment, state *PodCountMetricsState) bool {
-	currentDesiredReplicas := int32(0)
+// podCounts are the replica counts of a single deployment, statefulset or daemonset.
+type podCounts struct {
+	attribute string
+	namespace string
+	name      string
+	labels    map[string]string
+	desired   int32
+	current   int32
+	ready     int32
+	available int32
+}
+
+func workloadPodCounts(k8s *client.Client) []podCounts {
+	var result []podCounts
+	for _, d := range k8s.Deployments() {
+		result = append(result, deploymentPodCounts(d))
+	}
+	for _, s := range k8s.StatefulSets() {
+		result = append(result, statefulSetPodCounts(s))
+	}
+	for _, d := range k8s.DaemonSets() {
+		result = append(result, daemonSetPodCounts(d))
+	}
+	return result
+}
+
+func deploymentPodCounts(deployment *appsv1.Deployment) podCounts {
+	desired := int32(0)
 	if deployment.Spec.Replicas != nil {
-		currentDesiredReplicas = *deployment.Spec.Replicas
+		desired = *deployment.Spec.Replicas
+	}
+	return podCounts{
+		attribute: "k8s.deployment",
+		namespace: deployment.Namespace,
+		name:      deployment.Name,
+		labels:    deployment.Labels,
+		desired:   desired,
+		current:   deployment.Status.Replicas,
+		ready:     deployment.Status.ReadyReplicas,
+		available: deployment.Status.AvailableReplicas,
+	}
+}
+
+func statefulSetPodCounts(statefulSet *appsv1.StatefulSet) podCounts {
+	desired := int32(0)
+	if statefulSet.Spec.Replicas != nil {
+		desired = *statefulSet.Spec.Replicas
 	}
+	return podCounts{
+		attribute: "k8s.statefulset",
+		namespace: statefulSet.Namespace,
+		name:      statefulSet.Name,
+		labels:    statefulSet.Labels,
+		desired:   desired,
+		current:   statefulSet.Status.Replicas,
+		ready:     statefulSet.Status.ReadyReplicas,
+		available: statefulSet.Status.AvailableReplicas,
+	}
+}
+
+func daemonSetPodCounts(daemonSet *appsv1.DaemonSet) podCounts {
+	return podCounts{
+		attribute: "k8s.daemonset",
+		namespace: daemonSet.Namespace,
+		name:      daemonSet.Name,
+		labels:    daemonSet.Labels,
+		desired:   daemonSet.Status.DesiredNumberScheduled,
+		current:   daemonSet.Status.CurrentNumberScheduled,
+		ready:     daemonSet.Status.NumberReady,
+		available: daemonSet.Status.NumberAvailable,
+	}
+}
 
-	return hasChange(deployment, state, "replicas_current_count", deployment.Status.Replicas) ||
-		hasChange(deployment, state, "replicas_desired_count", currentDesiredReplicas) ||
-		hasChange(deployment, state, "replicas_ready_count", deployment.Status.ReadyReplicas) ||
-		hasChange(deployment, state, "replicas_available_count", deployment.Status.AvailableReplicas)
+func hasChanges(workload podCounts, state *PodCountMetricsState) bool {
+	return hasChange(workload, state, "replicas_current_count", workload.current) ||
+		hasChange(workload, state, "replicas_desired_count", workload.desired) ||
+		hasChange(workload, state, "replicas_ready_count", workload.ready) ||
+		hasChange(workload, state, "replicas_available_count", workload.available)
 }
 
-func hasChange(deployment *appsv1.Deployment, state *PodCountMetricsState, metric string, currentValue int32) bool {
-	key := getMetricKey(deployment, metric)
+func hasChange(workload podCounts, state *PodCountMetricsState, metric string, currentValue int32) bool {
+	key := getMetricKey(workload, metric)
 	oldValue, oldValuePresent := state.LastMetrics[key]
 	return !oldValuePresent || oldValue != currentValue
 }
 
-func getMetricKey(deployment *appsv1.Deployment, metric string) string {
-	return fmt.Sprintf("%s-%s/%s", metric, deployment.Namespace, deployment.Name)
+func getMetricKey(workload podCounts, metric string) string {
+	return fmt.Sprintf("%s-%s-%s/%s", metric, workload.attribute, workload.namespace, workload.name)
 }
 
-func toMetrics(deployment *appsv1.Deployment, now time.Time) []action_kit_api.Metric {
+func toMetrics(workload podCounts, now time.Time) []action_kit_api.Metric {
 	metrics := make([]action_kit_api.Metric, 4)
 
 	metrics[0] = action_kit_api.Metric{
 		Name: extutil.Ptr("replicas_desired_count"),
 		Metric: map[string]string{
 			"k8s.cluster-name": extconfig.Config.ClusterName,
-			"k8s.namespace":    deployment.Namespace,
-			"k8s.deployment":   deployment.Name,
+			"k8s.namespace":    workload.namespace,
+			workload.attribute: workload.name,
 		},
 		Timestamp: now,
-		Value:     float64(*deployment.Spec.Replicas),
+		Value:     float64(workload.desired),
 	}
 	metrics[1] = action_kit_api.Metric{
 		Name: extutil.Ptr("replicas_current_count"),
 		Metric: map[string]string{
 			"k8s.cluster-name": extconfig.Config.ClusterName,
-			"k8s.namespace":    deployment.Namespace,
-			"k8s.deployment":   deployment.Name,
+			"k8s.namespace":    workload.namespace,
+			workload.attribute: workload.name,
 		},
 		Timestamp: now,
-		Value:     float64(deployment.Status.Replicas),
+		Value:     float64(workload.current),
 	}
 	metrics[2] = action_kit_api.Metric{
 		Name: extutil.Ptr("replicas_ready_count"),
 		Metric: map[string]string{
 			"k8s.cluster-name": extconfig.Config.ClusterName,
-			"k8s.namespace":    deployment.Namespace,
-			"k8s.deployment":   deployment.Name,
+			"k8s.namespace":    workload.namespace,
+			workload.attribute: workload.name,
 		},
 		Timestamp: now,
-		Value:     float64(deployment.Status.ReadyReplicas),
+		Value:     float64(workload.ready),
 	}
 	metrics[3] = action_kit_api.Metric{
 		Name: extutil.Ptr("replicas_available_count"),
 		Metric: map[string]string{
 			"k8s.cluster-name": extconfig.Config.ClusterName,
-			"k8s.namespace":    deployment.Namespace,
-			"k8s.deployment":   deployment.Name,
+			"k8s.namespace":    workload.namespace,
+			workload.attribute: workload.name,
 		},
 		Timestamp: now,
-		Value:     float64(deployment.Status.AvailableReplicas),
+		Value:     float64(workload.available),
 	}
 
 	return metrics
@@ -6,6 +6,7 @@ package extdeployment
 import (
 	"context"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	"github.com/stretchr/testify/require"
@@ -112,7 +113,7 @@ func TestCreateMetrics(t *testing.T) {
 	}
 
 	// When
-	metrics := toMetrics(&deployment, now)
+	metrics := toMetrics(deploymentPodCounts(&deployment), now)
 
 	// Then
 	for _, metric := range metrics {
@@ -135,3 +136,184 @@ func TestCreateMetrics(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateMetricsWithoutDesiredReplicas(t *testing.T) {
+	// Given
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shop",
+			Namespace: "default",
+		},
+	}
+
+	// When
+	metrics := toMetrics(deploymentPodCounts(&deployment), time.Now())
+
+	// Then
+	require.Equal(t, "replicas_desired_count", *metrics[0].Name)
+	require.Equal(t, float64(0), metrics[0].Value)
+}
+
+func TestStatusReturnsMetricsForStatefulSetsAndDaemonSets(t *testing.T) {
+	tests := []struct {
+		name              string
+		namespace         string
+		labelSelector     string
+		expectedWorkloads []string
+	}{
+		{
+			name:              "all workloads",
+			expectedWorkloads: []string{"k8s.deployment=shop", "k8s.statefulset=db", "k8s.daemonset=agent"},
+		},
+		{
+			name:              "filtered by namespace",
+			namespace:         "infra",
+			expectedWorkloads: []string{"k8s.daemonset=agent"},
+		},
+		{
+			name:              "filtered by label selector",
+			labelSelector:     "tier in (backend,data)",
+			expectedWorkloads: []string{"k8s.deployment=shop", "k8s.statefulset=db"},
+		},
+		{
+			name:              "filtered by namespace and label selector",
+			namespace:         "default",
+			labelSelector:     "tier=data",
+			expectedWorkloads: []string{"k8s.statefulset=db"},
+		},
+	}
+	extconfig.Config.ClusterName = "development"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			state := PodCountMetricsState{
+				End:           time.Now().Add(time.Minute * -1),
+				Namespace:     tt.namespace,
+				LabelSelector: tt.labelSelector,
+				LastMetrics:   make(map[string]int32),
+			}
+			k8sclient, stopCh := createPodCountMetricsTestClient(t)
+			defer close(stopCh)
+
+			// When
+			result := statusPodCountMetricsInternal(k8sclient, &state)
+
+			// Then
+			require.Nil(t, result.Error)
+			require.Len(t, *result.Metrics, 4*len(tt.expectedWorkloads))
+			var workloads []string
+			for _, metric := range *result.Metrics {
+				for _, attribute := range []string{"k8s.deployment", "k8s.statefulset", "k8s.daemonset"} {
+					if name, ok := metric.Metric[attribute]; ok && *metric.Name == "replicas_ready_count" {
+						workloads = append(workloads, attribute+"="+name)
+					}
+				}
+			}
+			require.ElementsMatch(t, tt.expectedWorkloads, workloads)
+		})
+	}
+}
+
+func TestStatusReturnsStatefulSetAndDaemonSetCounts(t *testing.T) {
+	// Given
+	state := PodCountMetricsState{
+		End:         time.Now().Add(time.Minute * -1),
+		LastMetrics: make(map[string]int32),
+	}
+	k8sclient, stopCh := createPodCountMetricsTestClient(t)
+	defer close(stopCh)
+
+	// When
+	result := statusPodCountMetricsInternal(k8sclient, &state)
+
+	// Then
+	values := make(map[string]float64)
+	for _, metric := range *result.Metrics {
+		if name, ok := metric.Metric["k8s.statefulset"]; ok {
+			values[name+"/"+*metric.Name] = metric.Value
+		}
+		if name, ok := metric.Metric["k8s.daemonset"]; ok {
+			values[name+"/"+*metric.Name] = metric.Value
+		}
+	}
+	require.Equal(t, map[string]float64{
+		"db/replicas_desired_count":      3,
+		"db/replicas_current_count":      3,
+		"db/replicas_ready_count":        2,
+		"db/replicas_available_count":    1,
+		"agent/replicas_desired_count":   4,
+		"agent/replicas_current_count":   4,
+		"agent/replicas_ready_count":     3,
+		"agent/replicas_available_count": 3,
+	}, values)
+}
+
+func TestPrepareMetricsFailsForInvalidLabelSelector(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":      1000 * 60,
+			"labelSelector": "tier in (",
+		},
+	}
+	action := NewPodCountMetricsAction()
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.TODO(), &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "Invalid label selector 'tier in ('.")
+}
+
+func createPodCountMetricsTestClient(t *testing.T) (*client.Client, chan struct{}) {
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.AppsV1().Deployments("default").Create(context.Background(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shop",
+			Namespace: "default",
+			Labels:    map[string]string{"tier": "backend"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: extutil.Ptr(int32(2)),
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.AppsV1().StatefulSets("default").Create(context.Background(), &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "default",
+			Labels:    map[string]string{"tier": "data"},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: extutil.Ptr(int32(3)),
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:          3,
+			ReadyReplicas:     2,
+			AvailableReplicas: 1,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.AppsV1().DaemonSets("infra").Create(context.Background(), &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent",
+			Namespace: "infra",
+			Labels:    map[string]string{"tier": "infra"},
+		},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 4,
+			CurrentNumberScheduled: 4,
+			NumberReady:            3,
+			NumberAvailable:        3,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	k8sclient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	require.Eventually(t, func() bool {
+		return len(k8sclient.Deployments()) == 1 && len(k8sclient.StatefulSets()) == 1 && len(k8sclient.DaemonSets()) == 1
+	}, time.Second, 100*time.Millisecond)
+	return k8sclient, stopCh
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdeploymentconfig
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+)
+
+type RolloutLatestDeploymentConfigAction struct {
+}
+
+type RolloutLatestDeploymentConfigState struct {
+	Cluster          string                 `json:"cluster"`
+	Namespace        string                 `json:"namespace"`
+	DeploymentConfig string                 `json:"deploymentConfig"`
+	Wait             bool                   `json:"wait"`
+	LatestVersion    int64                  `json:"latestVersion"`
+	Audit            *extcommon.AttackAudit `json:"audit,omitempty"`
+}
+
+type RolloutLatestDeploymentConfigConfig struct {
+	Wait bool
+}
+
+func NewRolloutLatestDeploymentConfigAction() action_kit_sdk.Action[RolloutLatestDeploymentConfigState] {
+	return RolloutLatestDeploymentConfigAction{}
+}
+
+var _ action_kit_sdk.Action[RolloutLatestDeploymentConfigState] = (*RolloutLatestDeploymentConfigAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[RolloutLatestDeploymentConfigState] = (*RolloutLatestDeploymentConfigAction)(nil)
+var _ action_kit_sdk.ActionWithStop[RolloutLatestDeploymentConfigState] = (*RolloutLatestDeploymentConfigAction)(nil)
+
+func (f RolloutLatestDeploymentConfigAction) NewEmptyState() RolloutLatestDeploymentConfigState {
+	return RolloutLatestDeploymentConfigState{}
+}
+
+func (f RolloutLatestDeploymentConfigAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          RolloutLatestDeploymentConfigActionId,
+		Label:       "Rollout Latest DeploymentConfig",
+		Description: "Start a new rollout of an OpenShift DeploymentConfig, like `oc rollout latest` does.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentConfigIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: DeploymentConfigTargetType,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find deployment config by cluster, namespace and deployment config"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.deploymentconfig=\"\"",
+				},
+			}),
+		}),
+		TimeControl: action_kit_api.TimeControlInternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Label:        "wait for rollout completion",
+				Name:         "wait",
+				Type:         action_kit_api.Boolean,
+				Advanced:     extutil.Ptr(true),
+				DefaultValue: extutil.Ptr("false"),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status:  extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{}),
+		Stop:    extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (f RolloutLatestDeploymentConfigAction) Prepare(_ context.Context, state *RolloutLatestDeploymentConfigState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return prepareRolloutLatestInternal(client.K8S, state, request)
+}
+
+func prepareRolloutLatestInternal(k8s *client.Client, state *RolloutLatestDeploymentConfigState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config RolloutLatestDeploymentConfigConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Cluster = request.Target.Attributes["k8s.cluster-name"][0]
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.DeploymentConfig = request.Target.Attributes["k8s.deploymentconfig"][0]
+	state.Wait = config.Wait
+	deploymentConfig := k8s.DeploymentConfigByNamespaceAndName(state.Namespace, state.DeploymentConfig)
+	if deploymentConfig == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to find deployment config %s/%s.", state.Namespace, state.DeploymentConfig), nil)
+	}
+	if deploymentConfig.Spec.Paused {
+		return nil, extension_kit.ToError(fmt.Sprintf("Deployment config %s/%s is paused.", state.Namespace, state.DeploymentConfig), nil)
+	}
+	state.LatestVersion = deploymentConfig.Status.LatestVersion
+	state.Audit = extcommon.NewAttackAudit(request, extcommon.ExecutionTarget{Kind: "DeploymentConfig", Namespace: state.Namespace, Name: state.DeploymentConfig}, "rollout latest deployment config")
+	extcommon.RememberExecutionTarget(request, "DeploymentConfig")
+	return nil, nil
+}
+
+func (f RolloutLatestDeploymentConfigAction) Start(_ context.Context, state *RolloutLatestDeploymentConfigState) (*action_kit_api.StartResult, error) {
+	log.Info().Msgf("Starting rollout latest deployment config attack for %+v", state)
+
+	if err := client.K8S.InstantiateDeploymentConfig(state.Namespace, state.DeploymentConfig); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to rollout deployment config %s/%s.", state.Namespace, state.DeploymentConfig), err)
+	}
+	state.Audit.Started(client.K8S)
+
+	return nil, nil
+}
+
+func (f RolloutLatestDeploymentConfigAction) Status(_ context.Context, state *RolloutLatestDeploymentConfigState) (*action_kit_api.StatusResult, error) {
+	result := statusRolloutLatestInternal(client.K8S, state)
+	if result.Completed {
+		state.Audit.Stop(client.K8S)
+	}
+	return result, nil
+}
+
+func statusRolloutLatestInternal(k8s *client.Client, state *RolloutLatestDeploymentConfigState) *action_kit_api.StatusResult {
+	if !state.Wait {
+		return &action_kit_api.StatusResult{Completed: true}
+	}
+
+	deploymentConfig := k8s.DeploymentConfigByNamespaceAndName(state.Namespace, state.DeploymentConfig)
+	if deploymentConfig == nil {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Deployment config %s/%s not found", state.Namespace, state.DeploymentConfig),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	// the new version is rolled out once all replicas are updated and available
+	status := deploymentConfig.Status
+	rolledOut := status.LatestVersion > state.LatestVersion &&
+		status.UpdatedReplicas == deploymentConfig.Spec.Replicas &&
+		status.AvailableReplicas == deploymentConfig.Spec.Replicas &&
+		status.Replicas == deploymentConfig.Spec.Replicas
+	return &action_kit_api.StatusResult{
+		Completed: rolledOut,
+	}
+}
+
+func (f RolloutLatestDeploymentConfigAction) Stop(_ context.Context, state *RolloutLatestDeploymentConfigState) (*action_kit_api.StopResult, error) {
+	// the rollout can't be stopped, but the audit trail must not report the attack as in progress anymore
+	state.Audit.Stop(client.K8S)
+	return nil, nil
+}
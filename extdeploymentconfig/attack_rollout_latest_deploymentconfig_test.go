@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdeploymentconfig
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+)
+
+func TestRolloutLatestPrepareExtractsState(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient := getTestClient(stopCh, testclient.NewSimpleClientset(), deploymentConfig(nil))
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"wait": true,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name":     {"test"},
+				"k8s.namespace":        {"shop"},
+				"k8s.deploymentconfig": {"checkout"},
+			},
+		}),
+	}
+	state := NewRolloutLatestDeploymentConfigAction().NewEmptyState()
+
+	// When
+	_, err := prepareRolloutLatestInternal(k8sClient, &state, request)
+	require.NoError(t, err)
+
+	// Then
+	require.Equal(t, "test", state.Cluster)
+	require.Equal(t, "shop", state.Namespace)
+	require.Equal(t, "checkout", state.DeploymentConfig)
+	require.Equal(t, int64(4), state.LatestVersion)
+	require.True(t, state.Wait)
+}
+
+func TestRolloutLatestStatusWaitsForNewVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    map[string]interface{}
+		completed bool
+	}{
+		{
+			name:      "not instantiated yet",
+			status:    map[string]interface{}{"latestVersion": int64(4), "replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3)},
+			completed: false,
+		},
+		{
+			name:      "rolling out",
+			status:    map[string]interface{}{"latestVersion": int64(5), "replicas": int64(4), "updatedReplicas": int64(1), "availableReplicas": int64(3)},
+			completed: false,
+		},
+		{
+			name:      "rolled out",
+			status:    map[string]interface{}{"latestVersion": int64(5), "replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3)},
+			completed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			k8sClient := getTestClient(stopCh, testclient.NewSimpleClientset(), deploymentConfig(tt.status))
+			state := RolloutLatestDeploymentConfigState{Namespace: "shop", DeploymentConfig: "checkout", Wait: true, LatestVersion: 4}
+
+			// When
+			result := statusRolloutLatestInternal(k8sClient, &state)
+
+			// Then
+			assert.Equal(t, tt.completed, result.Completed)
+			assert.Nil(t, result.Error)
+		})
+	}
+}
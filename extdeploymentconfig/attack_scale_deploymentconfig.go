@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdeploymentconfig
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+)
+
+func NewScaleDeploymentConfigAction() action_kit_sdk.Action[extcommon.KubectlActionState] {
+	return &extcommon.KubectlAction{
+		Description:  getScaleDeploymentConfigDescription(),
+		OptsProvider: scaleDeploymentConfig(),
+	}
+}
+
+type ScaleDeploymentConfigConfig struct {
+	ReplicaCount int
+}
+
+func getScaleDeploymentConfigDescription() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          ScaleDeploymentConfigActionId,
+		Label:       "Scale DeploymentConfig",
+		Description: "Up-/ or downscale an OpenShift DeploymentConfig",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(deploymentConfigIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: DeploymentConfigTargetType,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find deployment config by cluster, namespace and deployment config"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.deploymentconfig=\"\"",
+				},
+			}),
+		}),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Label:        "Duration",
+				Description:  extutil.Ptr("The duration of the action. The deployment config will be scaled back to the original value after the action."),
+				Name:         "duration",
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("180s"),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "replicaCount",
+				Label:        "Replica Count",
+				Description:  extutil.Ptr("The new replica count."),
+				Type:         action_kit_api.Integer,
+				DefaultValue: extutil.Ptr("1"),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status:  &action_kit_api.MutatingEndpointReferenceWithCallInterval{},
+		Stop:    &action_kit_api.MutatingEndpointReference{},
+	}
+}
+
+func scaleDeploymentConfig() extcommon.KubectlOptsProvider {
+	return func(ctx context.Context, request action_kit_api.PrepareActionRequestBody) (*extcommon.KubectlOpts, error) {
+		namespace := request.Target.Attributes["k8s.namespace"][0]
+		deploymentConfig := request.Target.Attributes["k8s.deploymentconfig"][0]
+
+		var config ScaleDeploymentConfigConfig
+		if err := extconversion.Convert(request.Config, &config); err != nil {
+			return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+		}
+
+		deploymentConfigDefinition := client.K8S.DeploymentConfigByNamespaceAndName(namespace, deploymentConfig)
+		if deploymentConfigDefinition == nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to find deployment config %s/%s.", namespace, deploymentConfig), nil)
+		}
+
+		oldReplicaCount := deploymentConfigDefinition.Spec.Replicas
+		// the fully qualified resource name, "dc" is only known to kubectl if the OpenShift API is discovered
+		resource := fmt.Sprintf("deploymentconfig.apps.openshift.io/%s", deploymentConfig)
+
+		command := []string{"kubectl",
+			"scale",
+			fmt.Sprintf("--replicas=%d", config.ReplicaCount),
+			fmt.Sprintf("--current-replicas=%d", oldReplicaCount),
+			fmt.Sprintf("--namespace=%s", namespace),
+			resource,
+		}
+
+		rollbackCommand := []string{"kubectl",
+			"scale",
+			fmt.Sprintf("--replicas=%d", oldReplicaCount),
+			fmt.Sprintf("--namespace=%s", namespace),
+			resource,
+		}
+
+		return &extcommon.KubectlOpts{
+			Command:         command,
+			RollbackCommand: &rollbackCommand,
+			LogTargetType:   "deployment config",
+			LogTargetName:   fmt.Sprintf("%s/%s", namespace, deploymentConfig),
+			LogActionName:   "scale deployment config",
+			AuditTarget:     &extcommon.ExecutionTarget{Kind: "DeploymentConfig", Namespace: namespace, Name: deploymentConfig},
+		}, nil
+	}
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdeploymentconfig
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/stretchr/testify/require"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+)
+
+func TestScaleDeploymentConfigPreparesCommands(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":     100000,
+			"replicaCount": 1,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":        {"shop"},
+				"k8s.deploymentconfig": {"checkout"},
+			},
+		}),
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client.K8S = getTestClient(stopCh, testclient.NewSimpleClientset(), deploymentConfig(nil))
+
+	action := NewScaleDeploymentConfigAction()
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, request)
+	require.NoError(t, err)
+
+	// Then
+	require.Equal(t, []string{"kubectl", "scale", "--replicas=1", "--current-replicas=3", "--namespace=shop", "deploymentconfig.apps.openshift.io/checkout"}, state.Opts.Command)
+	require.Equal(t, []string{"kubectl", "scale", "--replicas=3", "--namespace=shop", "deploymentconfig.apps.openshift.io/checkout"}, *state.Opts.RollbackCommand)
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdeploymentconfig
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"reflect"
+	"time"
+)
+
+type deploymentConfigDiscovery struct {
+	k8s *client.Client
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*deploymentConfigDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*deploymentConfigDiscovery)(nil)
+)
+
+func NewDeploymentConfigDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
+	discovery := &deploymentConfigDiscovery{k8s: k8s}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
+		reflect.TypeOf(corev1.Pod{}),
+		reflect.TypeOf(corev1.Namespace{}),
+		reflect.TypeOf(unstructured.Unstructured{}),
+		reflect.TypeOf(corev1.Service{}),
+		reflect.TypeOf(policyv1.PodDisruptionBudget{}),
+	)
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, 5*time.Second),
+	)
+}
+
+func (d *deploymentConfigDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: DeploymentConfigTargetType,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("30s"),
+		},
+	}
+}
+
+func (d *deploymentConfigDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       DeploymentConfigTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "OpenShift DeploymentConfig", Other: "OpenShift DeploymentConfigs"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(deploymentConfigIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.deploymentconfig"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.deploymentconfig",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *deploymentConfigDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	deploymentConfigs := d.k8s.DeploymentConfigs()
+
+	filteredDeploymentConfigs := make([]*client.DeploymentConfig, 0, len(deploymentConfigs))
+	for _, deploymentConfig := range deploymentConfigs {
+		if d.k8s.IsExcludedFromDiscovery(deploymentConfig.ObjectMeta) {
+			continue
+		}
+		filteredDeploymentConfigs = append(filteredDeploymentConfigs, deploymentConfig)
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredDeploymentConfigs))
+
+	nodes := d.k8s.Nodes()
+	for i, deploymentConfig := range filteredDeploymentConfigs {
+		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, deploymentConfig.Namespace, deploymentConfig.Name)
+		attributes := map[string][]string{
+			"k8s.namespace":                       {deploymentConfig.Namespace},
+			"k8s.deploymentconfig":                {deploymentConfig.Name},
+			"k8s.workload-type":                   {"deploymentconfig"},
+			"k8s.workload-owner":                  {deploymentConfig.Name},
+			"k8s.cluster-name":                    {extconfig.Config.ClusterName},
+			"k8s.distribution":                    {d.k8s.Distribution},
+			"k8s.specification.replicas":          {fmt.Sprintf("%d", deploymentConfig.Spec.Replicas)},
+			"k8s.deploymentconfig.latest-version": {fmt.Sprintf("%d", deploymentConfig.Status.LatestVersion)},
+		}
+		if deploymentConfig.Spec.Strategy.Type != "" {
+			attributes["k8s.deploymentconfig.strategy"] = []string{deploymentConfig.Spec.Strategy.Type}
+		}
+		for key, value := range deploymentConfig.ObjectMeta.Labels {
+			if extconfig.Config.IncludesLabel("deploymentconfig", key) {
+				attributes[fmt.Sprintf("k8s.deploymentconfig.label.%v", key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+
+		// an empty selector of a deployment config doesn't select any pods
+		if len(deploymentConfig.Spec.Selector) > 0 {
+			pods := d.k8s.PodsByLabelSelector(&metav1.LabelSelector{MatchLabels: deploymentConfig.Spec.Selector}, deploymentConfig.Namespace)
+			for key, value := range extcommon.GetPodBasedAttributes("deploymentconfig", deploymentConfig.ObjectMeta, pods, nodes) {
+				attributes[key] = value
+			}
+		}
+
+		var templateLabels map[string]string
+		if deploymentConfig.Spec.Template != nil {
+			templateLabels = deploymentConfig.Spec.Template.Labels
+		}
+		services := d.k8s.ServicesMatchingToPodLabels(deploymentConfig.Namespace, templateLabels)
+		for key, value := range extcommon.GetServiceNames(services) {
+			attributes[key] = value
+		}
+		for key, value := range extcommon.GetRouteAttributes(d.k8s.RoutesMatchingToServices(deploymentConfig.Namespace, services)) {
+			attributes[key] = value
+		}
+
+		if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+			for key, value := range extcommon.GetPodDisruptionBudgetAttributes(d.k8s.PodDisruptionBudgetsMatchingToPodLabels(deploymentConfig.Namespace, templateLabels), &deploymentConfig.Spec.Replicas) {
+				attributes[key] = value
+			}
+		}
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: DeploymentConfigTargetType,
+			Label:      deploymentConfig.Name,
+			Attributes: attributes,
+		}
+	}
+	return extcommon.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesDeploymentConfig), nil
+}
+
+func (d *deploymentConfigDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		getDeploymentConfigToContainerEnrichmentRule(),
+	}
+}
+
+func getDeploymentConfigToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentRule {
+	return discovery_kit_api.TargetEnrichmentRule{
+		Id:      "com.steadybit.extension_kubernetes.kubernetes-deploymentconfig-to-container",
+		Version: extbuild.GetSemverVersionStringOrUnknown(),
+		Src: discovery_kit_api.SourceOrDestination{
+			Type: DeploymentConfigTargetType,
+			Selector: map[string]string{
+				"k8s.container.id.stripped": "${dest.container.id.stripped}",
+			},
+		},
+		Dest: discovery_kit_api.SourceOrDestination{
+			Type: "com.steadybit.extension_container.container",
+			Selector: map[string]string{
+				"container.id.stripped": "${src.k8s.container.id.stripped}",
+			},
+		},
+		Attributes: []discovery_kit_api.Attribute{
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.deploymentconfig.label.",
+			},
+			{
+				Matcher: discovery_kit_api.Regex,
+				Name:    "^k8s\\.label\\.(?!topology).*",
+			},
+		},
+	}
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extdeploymentconfig
+
+import (
+	"context"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func Test_deploymentConfigDiscovery(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	extconfig.Config.DiscoveryMaxPodCount = 50
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.CoreV1().Services("shop").Create(context.Background(), &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+		Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "checkout"}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	k8sClient := getTestClient(stopCh, clientset, deploymentConfig(nil), route())
+	createPod(t, clientset, "checkout-1-x1y2z", map[string]string{"app": "checkout"})
+	createPod(t, clientset, "other", map[string]string{"app": "other"})
+
+	d := &deploymentConfigDiscovery{k8s: k8sClient}
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, k8sClient.Pods(), 2)
+		assert.Len(c, k8sClient.ServicesMatchingToPodLabels("shop", map[string]string{"app": "checkout"}), 1)
+	}, 1*time.Second, 100*time.Millisecond)
+	targets, _ := d.DiscoverTargets(context.Background())
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/shop/checkout", target.Id)
+	assert.Equal(t, "checkout", target.Label)
+	assert.Equal(t, DeploymentConfigTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"host.hostname":                               {"unknown"},
+		"host.domainname":                             {"unknown"},
+		"k8s.cluster-name":                            {"development"},
+		"k8s.distribution":                            {"kubernetes"},
+		"k8s.namespace":                               {"shop"},
+		"k8s.deploymentconfig":                        {"checkout"},
+		"k8s.workload-type":                           {"deploymentconfig"},
+		"k8s.workload-owner":                          {"checkout"},
+		"k8s.deploymentconfig.strategy":               {"Rolling"},
+		"k8s.deploymentconfig.latest-version":         {"4"},
+		"k8s.specification.replicas":                  {"3"},
+		"k8s.deploymentconfig.label.team":             {"payments"},
+		"k8s.label.team":                              {"payments"},
+		"k8s.pod.name":                                {"checkout-1-x1y2z"},
+		"k8s.container.id":                            {"containerd://abcdef"},
+		"k8s.container.id.stripped":                   {"abcdef"},
+		"k8s.service.name":                            {"checkout"},
+		"k8s.route":                                   {"checkout"},
+		"k8s.route.host":                              {"checkout.apps.example.com"},
+		"k8s.specification.has-pod-disruption-budget": {"false"},
+	}, target.Attributes)
+}
+
+func deploymentConfig(status map[string]interface{}) *unstructured.Unstructured {
+	if status == nil {
+		status = map[string]interface{}{"latestVersion": int64(4), "replicas": int64(3), "updatedReplicas": int64(3), "availableReplicas": int64(3)}
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps.openshift.io/v1",
+		"kind":       "DeploymentConfig",
+		"metadata": map[string]interface{}{
+			"name":      "checkout",
+			"namespace": "shop",
+			"labels":    map[string]interface{}{"team": "payments", "secret-label": "secret"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"selector": map[string]interface{}{"app": "checkout"},
+			"strategy": map[string]interface{}{"type": "Rolling"},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "checkout"}},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "checkout", "image": "checkout:latest"},
+					},
+				},
+			},
+		},
+		"status": status,
+	}}
+}
+
+func route() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "route.openshift.io/v1",
+		"kind":       "Route",
+		"metadata": map[string]interface{}{
+			"name":      "checkout",
+			"namespace": "shop",
+		},
+		"spec": map[string]interface{}{
+			"host": "checkout.apps.example.com",
+			"to":   map[string]interface{}{"kind": "Service", "name": "checkout"},
+		},
+	}}
+}
+
+func createPod(t *testing.T, clientset kubernetes.Interface, name string, labels map[string]string) {
+	_, err := clientset.CoreV1().
+		Pods("shop").
+		Create(context.Background(), &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "shop",
+				Labels:    labels,
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{ContainerID: "containerd://abcdef", Name: "checkout"},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func getTestClient(stopCh <-chan struct{}, clientset kubernetes.Interface, objects ...runtime.Object) *client.Client {
+	extconfig.Config.DiscoveryCustomResources = nil
+	k8sClient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		client.DeploymentConfigCustomResource.GroupVersionResource(): "DeploymentConfigList",
+		client.RouteCustomResource.GroupVersionResource():            "RouteList",
+	}, objects...)
+	k8sClient.WatchCustomResources(dynamicClient, stopCh, []extconfig.CustomResource{client.DeploymentConfigCustomResource, client.RouteCustomResource})
+	return k8sClient
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extcluster"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -26,12 +28,21 @@ type K8sEventsAction struct {
 }
 
 type K8sEventsState struct {
-	LastEventTime *int64 `json:"lastEventTime"`
-	TimeoutEnd    *int64 `json:"timeoutEnd"`
+	LastEventTime *int64      `json:"lastEventTime"`
+	TimeoutEnd    *int64      `json:"timeoutEnd"`
+	Filter        EventFilter `json:"filter"`
+	// ReportedEvents are the observation counts of the events reported by the last poll, keyed by namespace/name.
+	ReportedEvents map[string]int32 `json:"reportedEvents,omitempty"`
 }
 
 type K8sEventsConfig struct {
-	Duration int
+	Duration            int
+	Namespaces          []string
+	EventType           string
+	IncludedReasons     []string
+	ExcludedReasons     []string
+	InvolvedObjectKinds []string
+	Scope               string
 }
 
 func NewK8sEventsAction() action_kit_sdk.Action[K8sEventsState] {
@@ -77,6 +88,84 @@ func (f K8sEventsAction) Describe() action_kit_api.ActionDescription {
 				Order:        extutil.Ptr(1),
 				Required:     extutil.Ptr(true),
 			},
+			{
+				Name:         "scope",
+				Label:        "Scope",
+				Description:  extutil.Ptr("Collect the events of the whole cluster or only of the objects attacked by Kubernetes attacks of the same experiment (including their pods)."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr(eventScopeCluster),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "Whole cluster",
+						Value: eventScopeCluster,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Targets of the experiment",
+						Value: eventScopeExperimentTargets,
+					},
+				}),
+			},
+			{
+				Name:         "eventType",
+				Label:        "Event type",
+				Description:  extutil.Ptr("Collect only events of this type."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr(eventTypeAll),
+				Order:        extutil.Ptr(3),
+				Required:     extutil.Ptr(true),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "All",
+						Value: eventTypeAll,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Normal",
+						Value: corev1.EventTypeNormal,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Warning",
+						Value: corev1.EventTypeWarning,
+					},
+				}),
+			},
+			{
+				Name:        "namespaces",
+				Label:       "Namespaces",
+				Description: extutil.Ptr("Collect only events of these namespaces. Leave empty for all namespaces."),
+				Type:        action_kit_api.StringArray,
+				Order:       extutil.Ptr(4),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
+			{
+				Name:        "involvedObjectKinds",
+				Label:       "Involved object kinds",
+				Description: extutil.Ptr("Collect only events of these kinds of objects (e.g. Pod, Deployment, Node). Leave empty for all kinds."),
+				Type:        action_kit_api.StringArray,
+				Order:       extutil.Ptr(5),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
+			{
+				Name:        "includedReasons",
+				Label:       "Included reasons",
+				Description: extutil.Ptr("Collect only events with these reasons (e.g. BackOff, Killing, FailedScheduling). Leave empty for all reasons."),
+				Type:        action_kit_api.StringArray,
+				Order:       extutil.Ptr(6),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
+			{
+				Name:        "excludedReasons",
+				Label:       "Excluded reasons",
+				Description: extutil.Ptr("Don't collect events with these reasons (e.g. Pulled, Scheduled)."),
+				Type:        action_kit_api.StringArray,
+				Order:       extutil.Ptr(7),
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+			},
 		},
 		Widgets: extutil.Ptr([]action_kit_api.Widget{
 			action_kit_api.LogWidget{
@@ -104,6 +193,19 @@ func (f K8sEventsAction) Prepare(_ context.Context, state *K8sEventsState, reque
 	}
 	state.LastEventTime = extutil.Ptr(time.Now().Unix())
 	state.TimeoutEnd = timeoutEnd
+	state.Filter = EventFilter{
+		Namespaces:          config.Namespaces,
+		EventType:           config.EventType,
+		IncludedReasons:     config.IncludedReasons,
+		ExcludedReasons:     config.ExcludedReasons,
+		InvolvedObjectKinds: config.InvolvedObjectKinds,
+	}
+	if config.Scope == eventScopeExperimentTargets {
+		if request.ExecutionContext == nil || request.ExecutionContext.ExecutionId == nil {
+			return nil, extension_kit.ToError("Events can only be scoped to the targets of the experiment within an experiment execution.", nil)
+		}
+		state.Filter.ExecutionId = request.ExecutionContext.ExecutionId
+	}
 	return nil, nil
 }
 
@@ -132,15 +234,29 @@ func statusInternal(k8s *client.Client, state *K8sEventsState) *action_kit_api.S
 
 func getMessages(k8s *client.Client, state *K8sEventsState) *action_kit_api.Messages {
 	newLastEventTime := time.Now().Unix()
-	events := k8s.Events(time.Unix(*state.LastEventTime, 0))
+	events := state.Filter.filter(k8s, *k8s.Events(time.Unix(*state.LastEventTime, 0)))
 	state.LastEventTime = extutil.Ptr(newLastEventTime)
 
+	// Events observed within the second of the last poll are listed again and updates of an event series are listed
+	// with an increased count. Only events which weren't reported with the same count are reported.
+	reported := make(map[string]int32, len(events))
+	var newEvents []eventsv1.Event
+	for _, event := range events {
+		key := event.Namespace + "/" + event.Name
+		count := client.EventCount(&event)
+		if previousCount, ok := state.ReportedEvents[key]; !ok || previousCount != count {
+			newEvents = append(newEvents, event)
+		}
+		reported[key] = count
+	}
+	state.ReportedEvents = reported
+
 	// log events
-	for _, event := range *events {
-		log.Debug().Msgf("Event: %s", event.Message)
+	for _, event := range newEvents {
+		log.Debug().Msgf("Event: %s", event.Note)
 	}
 
-	messages := eventsToMessages(events)
+	messages := eventsToMessages(&newEvents)
 	return messages
 }
 
@@ -155,7 +271,7 @@ func stopInternal(k8s *client.Client, state *K8sEventsState) *action_kit_api.Sto
 	})
 }
 
-func eventsToMessages(events *[]corev1.Event) *action_kit_api.Messages {
+func eventsToMessages(events *[]eventsv1.Event) *action_kit_api.Messages {
 	var messages []action_kit_api.Message
 	clusterName, cnAvailable := os.LookupEnv("STEADYBIT_EXTENSION_CLUSTER_NAME")
 	if !cnAvailable {
@@ -163,16 +279,17 @@ func eventsToMessages(events *[]corev1.Event) *action_kit_api.Messages {
 	}
 	for _, event := range *events {
 		messages = append(messages, action_kit_api.Message{
-			Message:         event.Message,
+			Message:         event.Note,
 			Type:            extutil.Ptr(LogType),
 			Level:           convertToLevel(event.Type),
-			Timestamp:       extutil.Ptr(event.LastTimestamp.Time),
+			Timestamp:       extutil.Ptr(client.EventTimestamp(&event)),
 			TimestampSource: extutil.Ptr(action_kit_api.TimestampSourceExternal),
 			Fields: extutil.Ptr(action_kit_api.MessageFields{
 				"reason":       event.Reason,
 				"cluster-name": clusterName,
 				"namespace":    event.Namespace,
-				"object":       strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name,
+				"object":       strings.ToLower(event.Regarding.Kind) + "/" + event.Regarding.Name,
+				"count":        strconv.Itoa(int(client.EventCount(&event))),
 			}),
 		})
 	}
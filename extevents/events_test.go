@@ -9,10 +9,21 @@ import (
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	"strings"
 	"testing"
 	"time"
 )
@@ -49,11 +60,12 @@ func TestStatusEventsFound(t *testing.T) {
 	result := statusInternal(k8sClient, state)
 
 	// Then
+	require.Len(t, *result.Messages, 1)
 	for _, message := range *(result.Messages) {
 		require.Equal(t, "test", message.Message)
 		require.Equal(t, "KUBERNETES_EVENTS", *message.Type)
 		require.Equal(t, action_kit_api.MessageLevel("info"), *message.Level)
-		require.Equal(t, action_kit_api.MessageFields{"cluster-name": "unknown", "namespace": "shop", "object": "/", "reason": ""}, *message.Fields)
+		require.Equal(t, action_kit_api.MessageFields{"cluster-name": "unknown", "namespace": "shop", "object": "/", "reason": "", "count": "1"}, *message.Fields)
 	}
 }
 
@@ -68,11 +80,12 @@ func TestStopEventsFound(t *testing.T) {
 	result := stopInternal(k8sClient, state)
 
 	// Then
+	require.Len(t, *result.Messages, 1)
 	for _, message := range *(result.Messages) {
 		require.Equal(t, "test", message.Message)
 		require.Equal(t, "KUBERNETES_EVENTS", *message.Type)
 		require.Equal(t, action_kit_api.MessageLevel("info"), *message.Level)
-		require.Equal(t, action_kit_api.MessageFields{"cluster-name": "unknown", "namespace": "shop", "object": "/", "reason": ""}, *message.Fields)
+		require.Equal(t, action_kit_api.MessageFields{"cluster-name": "unknown", "namespace": "shop", "object": "/", "reason": "", "count": "1"}, *message.Fields)
 	}
 }
 
@@ -84,15 +97,467 @@ func prepareTest(t *testing.T, stopCh chan struct{}) (*K8sEventsState, *client.C
 
 	clientset := testclient.NewSimpleClientset()
 	_, err := clientset.
-		CoreV1().
+		EventsV1().
 		Events("shop").
-		Create(context.Background(), &corev1.Event{
-			LastTimestamp: metav1.Time{Time: time.Now()},
-			Message:       "test",
-			Type:          "Normal",
+		Create(context.Background(), &eventsv1.Event{
+			DeprecatedLastTimestamp: metav1.Time{Time: time.Now()},
+			Note:                    "test",
+			Type:                    "Normal",
 		}, metav1.CreateOptions{})
 
 	require.NoError(t, err)
 	client := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
 	return &state, client
 }
+
+func TestPrepareExtractsFilter(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":            1000 * 10,
+			"scope":               "experimentTargets",
+			"eventType":           "Warning",
+			"namespaces":          []interface{}{"shop"},
+			"includedReasons":     []interface{}{"BackOff"},
+			"excludedReasons":     []interface{}{"Pulled"},
+			"involvedObjectKinds": []interface{}{"Pod"},
+		},
+		ExecutionContext: extutil.Ptr(action_kit_api.ExecutionContext{
+			ExecutionId: extutil.Ptr(42),
+		}),
+	}
+	action := NewK8sEventsAction()
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.TODO(), &state, request)
+
+	// Then
+	require.Nil(t, err)
+	require.Equal(t, EventFilter{
+		Namespaces:          []string{"shop"},
+		EventType:           "Warning",
+		IncludedReasons:     []string{"BackOff"},
+		ExcludedReasons:     []string{"Pulled"},
+		InvolvedObjectKinds: []string{"Pod"},
+		ExecutionId:         extutil.Ptr(42),
+	}, state.Filter)
+}
+
+func TestPrepareFailsToScopeWithoutExecution(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 1000 * 10,
+			"scope":    "experimentTargets",
+		},
+	}
+	action := NewK8sEventsAction()
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.TODO(), &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "Events can only be scoped to the targets of the experiment within an experiment execution.")
+}
+
+func TestEventFilterMatches(t *testing.T) {
+	event := eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop"},
+		Type:       "Warning",
+		Reason:     "BackOff",
+		Regarding:  corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "checkout-1"},
+	}
+	tests := []struct {
+		name    string
+		filter  EventFilter
+		matches bool
+	}{
+		{name: "no filter", filter: EventFilter{}, matches: true},
+		{name: "all event types", filter: EventFilter{EventType: "all"}, matches: true},
+		{name: "matching namespace", filter: EventFilter{Namespaces: []string{"default", "shop"}}, matches: true},
+		{name: "other namespace", filter: EventFilter{Namespaces: []string{"default"}}, matches: false},
+		{name: "matching event type", filter: EventFilter{EventType: "Warning"}, matches: true},
+		{name: "other event type", filter: EventFilter{EventType: "Normal"}, matches: false},
+		{name: "included reason", filter: EventFilter{IncludedReasons: []string{"backoff"}}, matches: true},
+		{name: "not included reason", filter: EventFilter{IncludedReasons: []string{"Killing"}}, matches: false},
+		{name: "excluded reason", filter: EventFilter{ExcludedReasons: []string{"BackOff"}}, matches: false},
+		{name: "not excluded reason", filter: EventFilter{ExcludedReasons: []string{"Pulled"}}, matches: true},
+		{name: "matching kind", filter: EventFilter{InvolvedObjectKinds: []string{"pod"}}, matches: true},
+		{name: "other kind", filter: EventFilter{InvolvedObjectKinds: []string{"Node"}}, matches: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.matches, tt.filter.matches(event))
+		})
+	}
+}
+
+func TestStatusEventsScopedToExecutionTargets(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.AppsV1().Deployments("shop").Create(context.Background(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.AppsV1().ReplicaSets("shop").Create(context.Background(), &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-5d8f",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "checkout"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.CoreV1().Pods("shop").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-5d8f-x2x4k",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "checkout-5d8f"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	for _, involvedObject := range []corev1.ObjectReference{
+		{Kind: "Pod", Namespace: "shop", Name: "checkout-5d8f-x2x4k"},
+		{Kind: "Deployment", Namespace: "shop", Name: "checkout"},
+		{Kind: "Pod", Namespace: "shop", Name: "cart-7c9d-abcde"},
+		{Kind: "Deployment", Namespace: "other", Name: "checkout"},
+	} {
+		_, err = clientset.EventsV1().Events(involvedObject.Namespace).Create(context.Background(), &eventsv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: involvedObject.Name, Namespace: involvedObject.Namespace},
+			EventTime:  metav1.NewMicroTime(time.Now()),
+			Note:       involvedObject.Kind + "/" + involvedObject.Name,
+			Type:       "Normal",
+			Regarding:  involvedObject,
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	k8sClient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+
+	extcommon.RememberExecutionTarget(action_kit_api.PrepareActionRequestBody{
+		ExecutionContext: extutil.Ptr(action_kit_api.ExecutionContext{ExecutionId: extutil.Ptr(4711)}),
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace":  {"shop"},
+				"k8s.deployment": {"checkout"},
+			},
+		}),
+	}, "Deployment")
+	state := K8sEventsState{
+		TimeoutEnd:    extutil.Ptr(time.Now().Add(time.Minute * 1).Unix()),
+		LastEventTime: extutil.Ptr(time.Now().Add(-time.Minute * 1).Unix()),
+		Filter:        EventFilter{ExecutionId: extutil.Ptr(4711)},
+	}
+
+	// When
+	result := statusInternal(k8sClient, &state)
+
+	// Then
+	var messages []string
+	for _, message := range *result.Messages {
+		messages = append(messages, message.Message)
+	}
+	require.ElementsMatch(t, []string{"Pod/checkout-5d8f-x2x4k", "Deployment/checkout"}, messages)
+}
+
+func TestStatusEventsScopedToNodeOnlyForNodeTargets(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	clientset := testclient.NewSimpleClientset()
+	createEvents(t, clientset,
+		corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "checkout-5d8f-x2x4k"},
+		corev1.ObjectReference{Kind: "Node", Namespace: "default", Name: "worker-1"},
+	)
+	k8sClient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+
+	// When
+	podAttackMessages := scopedEventMessages(k8sClient, 4715, "Pod", map[string][]string{
+		"k8s.namespace": {"shop"},
+		"k8s.pod.name":  {"checkout-5d8f-x2x4k"},
+		"k8s.node.name": {"worker-1"},
+	})
+	nodeAttackMessages := scopedEventMessages(k8sClient, 4716, "Node", map[string][]string{
+		"k8s.node.name": {"worker-1"},
+	})
+
+	// Then
+	require.ElementsMatch(t, []string{"Pod/checkout-5d8f-x2x4k"}, podAttackMessages)
+	require.ElementsMatch(t, []string{"Node/worker-1"}, nodeAttackMessages)
+}
+
+func TestStatusEventsScopedToCronJobTarget(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.BatchV1().CronJobs("shop").Create(context.Background(), &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "cleanup", Namespace: "shop"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.BatchV1().Jobs("shop").Create(context.Background(), &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "cleanup-28391",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "cleanup"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.CoreV1().Pods("shop").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "cleanup-28391-x2x4k",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Job", Name: "cleanup-28391"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	createEvents(t, clientset,
+		corev1.ObjectReference{Kind: "CronJob", Namespace: "shop", Name: "cleanup"},
+		corev1.ObjectReference{Kind: "Job", Namespace: "shop", Name: "cleanup-28391"},
+		corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "cleanup-28391-x2x4k"},
+		corev1.ObjectReference{Kind: "Job", Namespace: "shop", Name: "other-28391"},
+	)
+	k8sClient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+
+	// When
+	messages := scopedEventMessages(k8sClient, 4712, "CronJob", map[string][]string{
+		"k8s.namespace": {"shop"},
+		"k8s.cronjob":   {"cleanup"},
+	})
+
+	// Then
+	require.ElementsMatch(t, []string{"CronJob/cleanup", "Job/cleanup-28391", "Pod/cleanup-28391-x2x4k"}, messages)
+}
+
+func TestStatusEventsScopedToRolloutTarget(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.AppsV1().ReplicaSets("shop").Create(context.Background(), &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-6b7c",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Rollout", Name: "checkout"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.CoreV1().Pods("shop").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-6b7c-x2x4k",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "checkout-6b7c"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	createEvents(t, clientset,
+		corev1.ObjectReference{Kind: "Rollout", Namespace: "shop", Name: "checkout"},
+		corev1.ObjectReference{Kind: "ReplicaSet", Namespace: "shop", Name: "checkout-6b7c"},
+		corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "checkout-6b7c-x2x4k"},
+		corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "cart-7c9d-abcde"},
+	)
+	k8sClient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	watchCustomResources(k8sClient, stopCh, client.RolloutCustomResource, "RolloutList", &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata":   map[string]interface{}{"name": "checkout", "namespace": "shop"},
+	}})
+
+	// When
+	messages := scopedEventMessages(k8sClient, 4713, "Rollout", map[string][]string{
+		"k8s.namespace": {"shop"},
+		"k8s.rollout":   {"checkout"},
+	})
+
+	// Then
+	require.ElementsMatch(t, []string{"Rollout/checkout", "ReplicaSet/checkout-6b7c", "Pod/checkout-6b7c-x2x4k"}, messages)
+}
+
+func TestStatusEventsScopedToDeploymentConfigTarget(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.CoreV1().ReplicationControllers("shop").Create(context.Background(), &corev1.ReplicationController{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-3",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "DeploymentConfig", Name: "checkout"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.CoreV1().Pods("shop").Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-3-x2x4k",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicationController", Name: "checkout-3"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	createEvents(t, clientset,
+		corev1.ObjectReference{Kind: "DeploymentConfig", Namespace: "shop", Name: "checkout"},
+		corev1.ObjectReference{Kind: "ReplicationController", Namespace: "shop", Name: "checkout-3"},
+		corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "checkout-3-x2x4k"},
+		corev1.ObjectReference{Kind: "ReplicationController", Namespace: "shop", Name: "cart-1"},
+	)
+	k8sClient := client.CreateClient(clientset, stopCh, "/oapi", client.MockAllPermitted())
+	watchCustomResources(k8sClient, stopCh, client.DeploymentConfigCustomResource, "DeploymentConfigList", &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps.openshift.io/v1",
+		"kind":       "DeploymentConfig",
+		"metadata":   map[string]interface{}{"name": "checkout", "namespace": "shop"},
+	}})
+
+	// When
+	messages := scopedEventMessages(k8sClient, 4714, "DeploymentConfig", map[string][]string{
+		"k8s.namespace":        {"shop"},
+		"k8s.deploymentconfig": {"checkout"},
+	})
+
+	// Then
+	require.ElementsMatch(t, []string{"DeploymentConfig/checkout", "ReplicationController/checkout-3", "Pod/checkout-3-x2x4k"}, messages)
+}
+
+// watchCustomResources lets the client watch the custom resources of a single definition.
+func watchCustomResources(k8sClient *client.Client, stopCh <-chan struct{}, definition extconfig.CustomResource, listKind string, objects ...runtime.Object) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{definition.GroupVersionResource(): listKind}, objects...)
+	k8sClient.WatchCustomResources(dynamicClient, stopCh, []extconfig.CustomResource{definition})
+}
+
+// createEvents creates an event for each involved object, the note of the event is <kind>/<name>.
+func createEvents(t *testing.T, clientset kubernetes.Interface, involvedObjects ...corev1.ObjectReference) {
+	for _, involvedObject := range involvedObjects {
+		_, err := clientset.EventsV1().Events(involvedObject.Namespace).Create(context.Background(), &eventsv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: strings.ToLower(involvedObject.Kind) + "-" + involvedObject.Name, Namespace: involvedObject.Namespace},
+			EventTime:  metav1.NewMicroTime(time.Now()),
+			Note:       involvedObject.Kind + "/" + involvedObject.Name,
+			Type:       "Normal",
+			Regarding:  involvedObject,
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+}
+
+// scopedEventMessages remembers an attacked target of the given kind with the given attributes for the execution and
+// returns the notes of the events reported for the execution.
+func scopedEventMessages(k8sClient *client.Client, executionId int, kind string, attributes map[string][]string) []string {
+	extcommon.RememberExecutionTarget(action_kit_api.PrepareActionRequestBody{
+		ExecutionContext: extutil.Ptr(action_kit_api.ExecutionContext{ExecutionId: extutil.Ptr(executionId)}),
+		Target:           extutil.Ptr(action_kit_api.Target{Attributes: attributes}),
+	}, kind)
+	state := K8sEventsState{
+		TimeoutEnd:    extutil.Ptr(time.Now().Add(time.Minute * 1).Unix()),
+		LastEventTime: extutil.Ptr(time.Now().Add(-time.Minute * 1).Unix()),
+		Filter:        EventFilter{ExecutionId: extutil.Ptr(executionId)},
+	}
+	result := statusInternal(k8sClient, &state)
+
+	var messages []string
+	if result.Messages != nil {
+		for _, message := range *result.Messages {
+			messages = append(messages, message.Message)
+		}
+	}
+	return messages
+}
+
+func TestStatusReportsEffectiveTimestampAndCount(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	eventTime := time.Now().Add(-30 * time.Second).Truncate(time.Microsecond)
+	lastObservedTime := time.Now().Add(-5 * time.Second).Truncate(time.Microsecond)
+	clientset := testclient.NewSimpleClientset()
+	for _, event := range []*eventsv1.Event{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "event-time-only", Namespace: "shop"},
+			EventTime:  metav1.NewMicroTime(eventTime),
+			Note:       "event time only",
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "series", Namespace: "shop"},
+			EventTime:  metav1.NewMicroTime(time.Now().Add(-10 * time.Minute)),
+			Series: &eventsv1.EventSeries{
+				Count:            5,
+				LastObservedTime: metav1.NewMicroTime(lastObservedTime),
+			},
+			Note: "series",
+		},
+		{
+			ObjectMeta:              metav1.ObjectMeta{Name: "outdated", Namespace: "shop"},
+			DeprecatedLastTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+			DeprecatedCount:         3,
+			Note:                    "outdated",
+		},
+	} {
+		_, err := clientset.EventsV1().Events("shop").Create(context.Background(), event, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	k8sClient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	state := K8sEventsState{
+		TimeoutEnd:    extutil.Ptr(time.Now().Add(time.Minute * 1).Unix()),
+		LastEventTime: extutil.Ptr(time.Now().Add(-time.Minute * 1).Unix()),
+	}
+
+	// When
+	result := statusInternal(k8sClient, &state)
+
+	// Then
+	messages := *result.Messages
+	require.Len(t, messages, 2)
+	require.Equal(t, "event time only", messages[0].Message)
+	require.Equal(t, eventTime, *messages[0].Timestamp)
+	require.Equal(t, "1", (*messages[0].Fields)["count"])
+	require.Equal(t, "series", messages[1].Message)
+	require.Equal(t, lastObservedTime, *messages[1].Timestamp)
+	require.Equal(t, "5", (*messages[1].Fields)["count"])
+}
+
+func TestStatusDeduplicatesReportedEvents(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	clientset := testclient.NewSimpleClientset()
+	event := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "series", Namespace: "shop"},
+		EventTime:  metav1.NewMicroTime(time.Now().Add(-10 * time.Second)),
+		Series: &eventsv1.EventSeries{
+			Count:            2,
+			LastObservedTime: metav1.NewMicroTime(time.Now()),
+		},
+		Note: "Back-off restarting failed container",
+	}
+	_, err := clientset.EventsV1().Events("shop").Create(context.Background(), event, metav1.CreateOptions{})
+	require.NoError(t, err)
+	k8sClient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	state := K8sEventsState{
+		TimeoutEnd:    extutil.Ptr(time.Now().Add(time.Minute * 1).Unix()),
+		LastEventTime: extutil.Ptr(time.Now().Add(-time.Minute * 1).Unix()),
+	}
+
+	// When
+	first := statusInternal(k8sClient, &state)
+	// the last event time has a resolution of seconds, so the event is listed again
+	state.LastEventTime = extutil.Ptr(time.Now().Add(-time.Minute * 1).Unix())
+	second := statusInternal(k8sClient, &state)
+
+	// Then
+	require.Len(t, *first.Messages, 1)
+	require.Len(t, *second.Messages, 0)
+
+	// When
+	event.Series.Count = 3
+	event.Series.LastObservedTime = metav1.NewMicroTime(time.Now())
+	_, err = clientset.EventsV1().Events("shop").Update(context.Background(), event, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		events := *k8sClient.Events(time.Now().Add(-time.Minute))
+		return len(events) == 1 && events[0].Series.Count == 3
+	}, time.Second, 10*time.Millisecond)
+	third := statusInternal(k8sClient, &state)
+
+	// Then
+	require.Len(t, *third.Messages, 1)
+	require.Equal(t, "3", (*(*third.Messages)[0].Fields)["count"])
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extevents
+
+import (
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+)
+
+const (
+	eventTypeAll                = "all"
+	eventScopeCluster           = "cluster"
+	eventScopeExperimentTargets = "experimentTargets"
+)
+
+// EventFilter restricts the events reported by the K8sEventsAction. Empty fields don't filter.
+type EventFilter struct {
+	Namespaces          []string `json:"namespaces,omitempty"`
+	EventType           string   `json:"eventType,omitempty"`
+	IncludedReasons     []string `json:"includedReasons,omitempty"`
+	ExcludedReasons     []string `json:"excludedReasons,omitempty"`
+	InvolvedObjectKinds []string `json:"involvedObjectKinds,omitempty"`
+	// ExecutionId is set if the events are scoped to the targets attacked in the experiment execution.
+	ExecutionId *int `json:"executionId,omitempty"`
+}
+
+func (f EventFilter) filter(k8s *client.Client, events []eventsv1.Event) []eventsv1.Event {
+	var targets []extcommon.ExecutionTarget
+	if f.ExecutionId != nil {
+		targets = extcommon.GetExecutionTargets(*f.ExecutionId)
+	}
+
+	var result []eventsv1.Event
+	for _, event := range events {
+		if f.matches(event) && (f.ExecutionId == nil || involvesTarget(k8s, event.Regarding, targets)) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func (f EventFilter) matches(event eventsv1.Event) bool {
+	if len(f.Namespaces) > 0 && !slices.Contains(f.Namespaces, event.Namespace) {
+		return false
+	}
+	if f.EventType != "" && f.EventType != eventTypeAll && !strings.EqualFold(f.EventType, event.Type) {
+		return false
+	}
+	if len(f.IncludedReasons) > 0 && !containsFold(f.IncludedReasons, event.Reason) {
+		return false
+	}
+	if containsFold(f.ExcludedReasons, event.Reason) {
+		return false
+	}
+	if len(f.InvolvedObjectKinds) > 0 && !containsFold(f.InvolvedObjectKinds, event.Regarding.Kind) {
+		return false
+	}
+	return true
+}
+
+// involvesTarget checks whether the involved object is one of the targets or is owned by one of them, e.g. a pod
+// created by a restarted deployment or a job created by a cronjob.
+func involvesTarget(k8s *client.Client, object corev1.ObjectReference, targets []extcommon.ExecutionTarget) bool {
+	if len(targets) == 0 {
+		return false
+	}
+
+	owners := []client.OwnerReference{{Kind: object.Kind, Name: object.Name}}
+	var meta *metav1.ObjectMeta
+	if strings.EqualFold(object.Kind, "pod") {
+		if pod := k8s.PodByNamespaceAndName(object.Namespace, object.Name); pod != nil {
+			meta = &pod.ObjectMeta
+		}
+	} else if strings.EqualFold(object.Kind, "replicaset") {
+		if replicaSet := k8s.ReplicaSetByNamespaceAndName(object.Namespace, object.Name); replicaSet != nil {
+			meta = &replicaSet.ObjectMeta
+		}
+	} else if strings.EqualFold(object.Kind, "job") {
+		if job := k8s.JobByNamespaceAndName(object.Namespace, object.Name); job != nil {
+			meta = &job.ObjectMeta
+		}
+	} else if strings.EqualFold(object.Kind, "replicationcontroller") {
+		if replicationController := k8s.ReplicationControllerByNamespaceAndName(object.Namespace, object.Name); replicationController != nil {
+			meta = &replicationController.ObjectMeta
+		}
+	}
+	if meta != nil {
+		owners = append(owners, client.OwnerReferences(k8s, meta).OwnerRefs...)
+	}
+
+	for _, target := range targets {
+		// nodes aren't namespaced, but their events are usually reported in the default namespace
+		if target.Kind != "Node" && target.Namespace != object.Namespace {
+			continue
+		}
+		for _, owner := range owners {
+			if strings.EqualFold(owner.Kind, target.Kind) && owner.Name == target.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool {
+		return strings.EqualFold(v, value)
+	})
+}
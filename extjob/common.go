@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extjob
+
+const (
+	JobTargetType             = "com.steadybit.extension_kubernetes.kubernetes-job"
+	JobCompletedCheckActionId = "com.steadybit.extension_kubernetes.job_completed_check"
+	jobIcon                   = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%3Cpath%20d%3D%22M9%202a1%201%200%20000%202h6a1%201%200%20100-2H9zM6%205a3%203%200%2000-3%203v11a3%203%200%20003%203h12a3%203%200%20003-3V8a3%203%200%2000-3-3h-1v1a2%202%200%2001-2%202H9a2%202%200%2001-2-2V5H6zm10.7%206.7l-5%205a1%201%200%2001-1.4%200l-2-2a1%201%200%20111.4-1.4l1.3%201.29%204.3-4.3a1%201%200%20111.4%201.42z%22%20fill%3D%22currentColor%22%2F%3E%3C%2Fsvg%3E"
+)
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extjob
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"time"
+)
+
+type JobCompletedCheckAction struct {
+}
+
+type JobCompletedCheckState struct {
+	Timeout   time.Time
+	Namespace string
+	Job       string
+}
+
+type JobCompletedCheckConfig struct {
+	Duration int
+}
+
+func NewJobCompletedCheckAction() action_kit_sdk.Action[JobCompletedCheckState] {
+	return JobCompletedCheckAction{}
+}
+
+var _ action_kit_sdk.Action[JobCompletedCheckState] = (*JobCompletedCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[JobCompletedCheckState] = (*JobCompletedCheckAction)(nil)
+
+func (f JobCompletedCheckAction) NewEmptyState() JobCompletedCheckState {
+	return JobCompletedCheckState{}
+}
+
+func (f JobCompletedCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          JobCompletedCheckActionId,
+		Label:       "Job Completed",
+		Description: "Verify that a Kubernetes Job completes successfully within the timeout. The check fails as soon as the job failed.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(jobIcon),
+		Category:    extutil.Ptr("Kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          JobTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find job by cluster, namespace and job"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.job=\"\"",
+				},
+			}),
+		}),
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Label:        "Timeout",
+				Description:  extutil.Ptr("Maximum time to wait for the job to complete."),
+				Name:         "duration",
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("10m"),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f JobCompletedCheckAction) Prepare(_ context.Context, state *JobCompletedCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return prepareJobCompletedCheckInternal(client.K8S, state, request)
+}
+
+func prepareJobCompletedCheckInternal(k8s *client.Client, state *JobCompletedCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config JobCompletedCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+
+	namespace := request.Target.Attributes["k8s.namespace"][0]
+	job := request.Target.Attributes["k8s.job"][0]
+	if k8s.JobByNamespaceAndName(namespace, job) == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to find job %s/%s.", namespace, job), nil)
+	}
+
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = namespace
+	state.Job = job
+	return nil, nil
+}
+
+func (f JobCompletedCheckAction) Start(_ context.Context, _ *JobCompletedCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f JobCompletedCheckAction) Status(_ context.Context, state *JobCompletedCheckState) (*action_kit_api.StatusResult, error) {
+	return statusJobCompletedCheckInternal(client.K8S, state), nil
+}
+
+func statusJobCompletedCheckInternal(k8s *client.Client, state *JobCompletedCheckState) *action_kit_api.StatusResult {
+	job := k8s.JobByNamespaceAndName(state.Namespace, state.Job)
+	if job == nil {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Job %s/%s not found", state.Namespace, state.Job),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	switch JobStatus(job) {
+	case JobStatusComplete:
+		return &action_kit_api.StatusResult{Completed: true}
+	case JobStatusFailed:
+		title := fmt.Sprintf("Job %s/%s failed.", state.Namespace, state.Job)
+		if condition := failedCondition(job); condition != nil && condition.Message != "" {
+			title = fmt.Sprintf("Job %s/%s failed: %s", state.Namespace, state.Job, condition.Message)
+		}
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  title,
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	if time.Now().After(state.Timeout) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Job %s/%s did not complete in time, %d of its pods succeeded.", state.Namespace, state.Job, job.Status.Succeeded),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+	return &action_kit_api.StatusResult{Completed: false}
+}
+
+func failedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return &condition
+		}
+	}
+	return nil
+}
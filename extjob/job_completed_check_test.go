@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extjob
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestPrepareJobCompletedCheckExtractsState(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient, clientset := getTestClient(stopCh)
+	createJob(t, k8sclient.JobByNamespaceAndName, clientset.BatchV1().Jobs("shop").Create, batchv1.JobStatus{})
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 1000 * 10,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace": {"shop"},
+				"k8s.job":       {"import"},
+			},
+		}),
+	}
+	state := NewJobCompletedCheckAction().NewEmptyState()
+
+	// When
+	result, err := prepareJobCompletedCheckInternal(k8sclient, &state, request)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+	require.True(t, state.Timeout.After(time.Now()))
+	require.Equal(t, "shop", state.Namespace)
+	require.Equal(t, "import", state.Job)
+}
+
+func TestStatusJobCompletedCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        batchv1.JobStatus
+		timeout       time.Time
+		wantCompleted bool
+		wantError     *action_kit_api.ActionKitError
+	}{
+		{
+			name:          "complete",
+			status:        batchv1.JobStatus{Succeeded: 1, Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}},
+			timeout:       time.Now().Add(time.Minute),
+			wantCompleted: true,
+		},
+		{
+			name:          "still running",
+			status:        batchv1.JobStatus{Active: 1},
+			timeout:       time.Now().Add(time.Minute),
+			wantCompleted: false,
+		},
+		{
+			name:          "failed",
+			status:        batchv1.JobStatus{Failed: 3, Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue, Message: "Job has reached the specified backoff limit"}}},
+			timeout:       time.Now().Add(time.Minute),
+			wantCompleted: true,
+			wantError: &action_kit_api.ActionKitError{
+				Title:  "Job shop/import failed: Job has reached the specified backoff limit",
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		},
+		{
+			name:          "timed out",
+			status:        batchv1.JobStatus{Active: 1},
+			timeout:       time.Now().Add(-time.Minute),
+			wantCompleted: true,
+			wantError: &action_kit_api.ActionKitError{
+				Title:  "Job shop/import did not complete in time, 0 of its pods succeeded.",
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			k8sclient, clientset := getTestClient(stopCh)
+			createJob(t, k8sclient.JobByNamespaceAndName, clientset.BatchV1().Jobs("shop").Create, tt.status)
+			state := JobCompletedCheckState{Timeout: tt.timeout, Namespace: "shop", Job: "import"}
+
+			// When
+			result := statusJobCompletedCheckInternal(k8sclient, &state)
+
+			// Then
+			assert.Equal(t, tt.wantCompleted, result.Completed)
+			assert.Equal(t, tt.wantError, result.Error)
+		})
+	}
+}
+
+func TestStatusJobCompletedCheckJobNotFound(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient, _ := getTestClient(stopCh)
+	state := JobCompletedCheckState{Timeout: time.Now().Add(time.Minute), Namespace: "shop", Job: "import"}
+
+	// When
+	result := statusJobCompletedCheckInternal(k8sclient, &state)
+
+	// Then
+	assert.True(t, result.Completed)
+	assert.Equal(t, "Job shop/import not found", result.Error.Title)
+	assert.Equal(t, action_kit_api.Errored, *result.Error.Status)
+}
+
+func createJob(t *testing.T, get func(string, string) *batchv1.Job, create func(context.Context, *batchv1.Job, metav1.CreateOptions) (*batchv1.Job, error), status batchv1.JobStatus) {
+	_, err := create(context.Background(), &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "import", Namespace: "shop"},
+		Status:     status,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return get("shop", "import") != nil
+	}, time.Second, 100*time.Millisecond)
+}
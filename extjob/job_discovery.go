@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extjob
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"reflect"
+	"time"
+)
+
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSuspended = "suspended"
+	JobStatusComplete  = "complete"
+	JobStatusFailed    = "failed"
+)
+
+type jobDiscovery struct {
+	k8s *client.Client
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*jobDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*jobDiscovery)(nil)
+)
+
+func NewJobDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
+	discovery := &jobDiscovery{k8s: k8s}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s, reflect.TypeOf(corev1.Pod{}), reflect.TypeOf(corev1.Namespace{}), reflect.TypeOf(batchv1.Job{}))
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, 5*time.Second),
+	)
+}
+
+func (d *jobDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: JobTargetType,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("30s"),
+		},
+	}
+}
+
+func (d *jobDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       JobTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes Job", Other: "Kubernetes Jobs"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(jobIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.job"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+				{Attribute: "k8s.job.status"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.job",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *jobDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	jobs := d.k8s.Jobs()
+
+	filteredJobs := make([]*batchv1.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if d.k8s.IsExcludedFromDiscovery(job.ObjectMeta) {
+			continue
+		}
+		filteredJobs = append(filteredJobs, job)
+	}
+
+	nodes := d.k8s.Nodes()
+	targets := make([]discovery_kit_api.Target, len(filteredJobs))
+	for i, job := range filteredJobs {
+		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, job.Namespace, job.Name)
+		attributes := map[string][]string{
+			"k8s.namespace":      {job.Namespace},
+			"k8s.job":            {job.Name},
+			"k8s.workload-type":  {"job"},
+			"k8s.workload-owner": {job.Name},
+			"k8s.cluster-name":   {extconfig.Config.ClusterName},
+			"k8s.distribution":   {d.k8s.Distribution},
+			"k8s.job.status":     {JobStatus(job)},
+			"k8s.job.active":     {fmt.Sprintf("%d", job.Status.Active)},
+			"k8s.job.succeeded":  {fmt.Sprintf("%d", job.Status.Succeeded)},
+			"k8s.job.failed":     {fmt.Sprintf("%d", job.Status.Failed)},
+		}
+		if job.Spec.Completions != nil {
+			attributes["k8s.job.completions"] = []string{fmt.Sprintf("%d", *job.Spec.Completions)}
+		}
+		if job.Spec.Parallelism != nil {
+			attributes["k8s.job.parallelism"] = []string{fmt.Sprintf("%d", *job.Spec.Parallelism)}
+		}
+		if job.Status.StartTime != nil {
+			attributes["k8s.job.start-time"] = []string{job.Status.StartTime.UTC().Format(time.RFC3339)}
+		}
+		if job.Status.CompletionTime != nil {
+			attributes["k8s.job.completion-time"] = []string{job.Status.CompletionTime.UTC().Format(time.RFC3339)}
+		}
+		for _, ownerRef := range client.OwnerReferences(d.k8s, &job.ObjectMeta).OwnerRefs {
+			attributes[fmt.Sprintf("k8s.%v", ownerRef.Kind)] = []string{ownerRef.Name}
+			attributes["k8s.workload-type"] = []string{ownerRef.Kind}
+			attributes["k8s.workload-owner"] = []string{ownerRef.Name}
+		}
+		for key, value := range job.ObjectMeta.Labels {
+			if extconfig.Config.IncludesLabel("job", key) {
+				attributes[fmt.Sprintf("k8s.job.label.%v", key)] = []string{value}
+				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+			}
+		}
+		if job.Spec.Selector != nil {
+			for key, value := range extcommon.GetPodBasedAttributes("job", job.ObjectMeta, d.k8s.PodsByLabelSelector(job.Spec.Selector, job.Namespace), nodes) {
+				attributes[key] = value
+			}
+		}
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: JobTargetType,
+			Label:      job.Name,
+			Attributes: attributes,
+		}
+	}
+	return extcommon.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesJob), nil
+}
+
+// JobStatus summarizes the conditions and pod counts of a job.
+func JobStatus(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return JobStatusComplete
+		case batchv1.JobFailed:
+			return JobStatusFailed
+		case batchv1.JobSuspended:
+			return JobStatusSuspended
+		}
+	}
+	if job.Status.Active > 0 {
+		return JobStatusRunning
+	}
+	return JobStatusPending
+}
+
+func (d *jobDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		getJobToContainerEnrichmentRule(),
+	}
+}
+
+func getJobToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentRule {
+	return discovery_kit_api.TargetEnrichmentRule{
+		Id:      "com.steadybit.extension_kubernetes.kubernetes-job-to-container",
+		Version: extbuild.GetSemverVersionStringOrUnknown(),
+		Src: discovery_kit_api.SourceOrDestination{
+			Type: JobTargetType,
+			Selector: map[string]string{
+				"k8s.container.id.stripped": "${dest.container.id.stripped}",
+			},
+		},
+		Dest: discovery_kit_api.SourceOrDestination{
+			Type: "com.steadybit.extension_container.container",
+			Selector: map[string]string{
+				"container.id.stripped": "${src.k8s.container.id.stripped}",
+			},
+		},
+		Attributes: []discovery_kit_api.Attribute{
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.job.label.",
+			},
+			{
+				Matcher: discovery_kit_api.Regex,
+				Name:    "^k8s\\.label\\.(?!topology).*",
+			},
+		},
+	}
+}
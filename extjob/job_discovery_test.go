@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extjob
+
+import (
+	"context"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func Test_jobDiscovery(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	extconfig.Config.DiscoveryMaxPodCount = 50
+
+	_, err := clientset.CoreV1().
+		Nodes().
+		Create(context.Background(), &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.BatchV1().
+		CronJobs("default").
+		Create(context.Background(), &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly-report", Namespace: "default"},
+			Spec:       batchv1.CronJobSpec{Schedule: "0 2 * * *"},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.BatchV1().
+		Jobs("default").
+		Create(context.Background(), &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nightly-report-28000",
+				Namespace: "default",
+				Labels: map[string]string{
+					"team":         "reporting",
+					"secret-label": "secret",
+				},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "nightly-report"}},
+			},
+			Spec: batchv1.JobSpec{
+				Completions: extutil.Ptr(int32(1)),
+				Parallelism: extutil.Ptr(int32(1)),
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"job-name": "nightly-report-28000"},
+				},
+			},
+			Status: batchv1.JobStatus{
+				Active:    1,
+				StartTime: extutil.Ptr(metav1.NewTime(time.Date(2024, 5, 1, 2, 0, 0, 0, time.UTC))),
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nightly-report-28000-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{"job-name": "nightly-report-28000"},
+			},
+			Spec: v1.PodSpec{NodeName: "worker-1"},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{ContainerID: "containerd://abcdef", Name: "report"},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	d := &jobDiscovery{k8s: client}
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		targets, _ := d.DiscoverTargets(context.Background())
+		assert.Len(c, targets, 1)
+		assert.Len(c, client.Pods(), 1)
+		assert.Len(c, client.Nodes(), 1)
+		assert.NotNil(c, client.CronJobByNamespaceAndName("default", "nightly-report"))
+	}, 1*time.Second, 100*time.Millisecond)
+
+	// Then
+	targets, _ := d.DiscoverTargets(context.Background())
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/default/nightly-report-28000", target.Id)
+	assert.Equal(t, "nightly-report-28000", target.Label)
+	assert.Equal(t, JobTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"host.hostname":             {"worker-1"},
+		"host.domainname":           {"worker-1"},
+		"k8s.cluster-name":          {"development"},
+		"k8s.distribution":          {"kubernetes"},
+		"k8s.namespace":             {"default"},
+		"k8s.job":                   {"nightly-report-28000"},
+		"k8s.cronjob":               {"nightly-report"},
+		"k8s.workload-type":         {"cronjob"},
+		"k8s.workload-owner":        {"nightly-report"},
+		"k8s.job.status":            {"running"},
+		"k8s.job.active":            {"1"},
+		"k8s.job.succeeded":         {"0"},
+		"k8s.job.failed":            {"0"},
+		"k8s.job.completions":       {"1"},
+		"k8s.job.parallelism":       {"1"},
+		"k8s.job.start-time":        {"2024-05-01T02:00:00Z"},
+		"k8s.job.label.team":        {"reporting"},
+		"k8s.label.team":            {"reporting"},
+		"k8s.pod.name":              {"nightly-report-28000-abcde"},
+		"k8s.container.id":          {"containerd://abcdef"},
+		"k8s.container.id.stripped": {"abcdef"},
+	}, target.Attributes)
+}
+
+func Test_JobStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		job  batchv1.Job
+		want string
+	}{
+		{
+			name: "pending",
+			job:  batchv1.Job{},
+			want: JobStatusPending,
+		},
+		{
+			name: "running",
+			job:  batchv1.Job{Status: batchv1.JobStatus{Active: 2}},
+			want: JobStatusRunning,
+		},
+		{
+			name: "complete",
+			job: batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: v1.ConditionTrue},
+			}}},
+			want: JobStatusComplete,
+		},
+		{
+			name: "failed",
+			job: batchv1.Job{Status: batchv1.JobStatus{Active: 1, Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: v1.ConditionTrue},
+			}}},
+			want: JobStatusFailed,
+		},
+		{
+			name: "suspended",
+			job: batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobSuspended, Status: v1.ConditionTrue},
+			}}},
+			want: JobStatusSuspended,
+		},
+		{
+			name: "condition not true",
+			job: batchv1.Job{Status: batchv1.JobStatus{Active: 1, Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobSuspended, Status: v1.ConditionFalse},
+			}}},
+			want: JobStatusRunning,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, JobStatus(&tt.job))
+		})
+	}
+}
+
+func getTestClient(stopCh <-chan struct{}) (*client.Client, kubernetes.Interface) {
+	clientset := testclient.NewSimpleClientset()
+	client := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	return client, clientset
+}
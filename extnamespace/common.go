@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnamespace
+
+const (
+	NamespaceTargetType = "com.steadybit.extension_kubernetes.kubernetes-namespace"
+	namespaceIcon       = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%3Cpath%20d%3D%22M3%205a2%202%200%20012-2h3v2H5v3H3V5zm0%2011h2v3h3v2H5a2%202%200%2001-2-2v-3zm18%200v3a2%202%200%2001-2%202h-3v-2h3v-3h2zm0-8h-2V5h-3V3h3a2%202%200%20012%202v3zM10%203h4v2h-4V3zm0%2016h4v2h-4v-2zM3%2010h2v4H3v-4zm16%200h2v4h-2v-4z%22%20fill%3D%22currentColor%22%2F%3E%3C%2Fsvg%3E"
+)
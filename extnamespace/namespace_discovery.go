@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnamespace
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"time"
+)
+
+// podSecurityModes are the modes of the pod security admission, configured by the labels
+// pod-security.kubernetes.io/<mode> of the namespace.
+var podSecurityModes = []string{"enforce", "audit", "warn"}
+
+type namespaceDiscovery struct {
+	k8s *client.Client
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*namespaceDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*namespaceDiscovery)(nil)
+)
+
+func NewNamespaceDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
+	discovery := &namespaceDiscovery{k8s: k8s}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
+		reflect.TypeOf(corev1.Namespace{}),
+		reflect.TypeOf(corev1.ResourceQuota{}),
+		reflect.TypeOf(corev1.Pod{}),
+		reflect.TypeOf(appsv1.Deployment{}),
+		reflect.TypeOf(appsv1.StatefulSet{}),
+		reflect.TypeOf(appsv1.DaemonSet{}),
+	)
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, 5*time.Second),
+	)
+}
+
+func (d *namespaceDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: NamespaceTargetType,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("30s"),
+		},
+	}
+}
+
+func (d *namespaceDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       NamespaceTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Kubernetes Namespace", Other: "Kubernetes Namespaces"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(namespaceIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+				{Attribute: "k8s.namespace.pod-count"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.namespace",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *namespaceDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		getNamespaceToContainerEnrichmentRule(),
+	}
+}
+
+// getNamespaceToContainerEnrichmentRule matches by namespace and cluster name instead of container ids, as a namespace
+// may have more pods than we want to list in a single target.
+func getNamespaceToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentRule {
+	return discovery_kit_api.TargetEnrichmentRule{
+		Id:      "com.steadybit.extension_kubernetes.kubernetes-namespace-to-container",
+		Version: extbuild.GetSemverVersionStringOrUnknown(),
+		Src: discovery_kit_api.SourceOrDestination{
+			Type: NamespaceTargetType,
+			Selector: map[string]string{
+				"k8s.cluster-name": "${dest.k8s.cluster-name}",
+				"k8s.namespace":    "${dest.k8s.namespace}",
+			},
+		},
+		Dest: discovery_kit_api.SourceOrDestination{
+			Type: "com.steadybit.extension_container.container",
+			Selector: map[string]string{
+				"k8s.cluster-name": "${src.k8s.cluster-name}",
+				"k8s.namespace":    "${src.k8s.namespace}",
+			},
+		},
+		Attributes: []discovery_kit_api.Attribute{
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.namespace.label.",
+			},
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.namespace.pod-security.",
+			},
+		},
+	}
+}
+
+type namespaceCounts struct {
+	deployments  int
+	statefulSets int
+	daemonSets   int
+	pods         int
+}
+
+func (d *namespaceDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	namespaces := d.k8s.Namespaces()
+
+	filteredNamespaces := make([]*corev1.Namespace, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		if d.k8s.IsNamespaceExcludedFromDiscovery(namespace.Name) {
+			continue
+		}
+		filteredNamespaces = append(filteredNamespaces, namespace)
+	}
+
+	counts := d.countByNamespace()
+	quotas := make(map[string][]*corev1.ResourceQuota)
+	for _, quota := range d.k8s.ResourceQuotas() {
+		quotas[quota.Namespace] = append(quotas[quota.Namespace], quota)
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredNamespaces))
+	for i, namespace := range filteredNamespaces {
+		targetName := fmt.Sprintf("%s/%s", extconfig.Config.ClusterName, namespace.Name)
+		count := counts[namespace.Name]
+		if count == nil {
+			count = &namespaceCounts{}
+		}
+		attributes := map[string][]string{
+			"k8s.namespace":                    {namespace.Name},
+			"k8s.cluster-name":                 {extconfig.Config.ClusterName},
+			"k8s.distribution":                 {d.k8s.Distribution},
+			"k8s.namespace.deployment-count":   {fmt.Sprintf("%d", count.deployments)},
+			"k8s.namespace.statefulset-count":  {fmt.Sprintf("%d", count.statefulSets)},
+			"k8s.namespace.daemonset-count":    {fmt.Sprintf("%d", count.daemonSets)},
+			"k8s.namespace.pod-count":          {fmt.Sprintf("%d", count.pods)},
+			"k8s.namespace.has-resource-quota": {fmt.Sprintf("%t", len(quotas[namespace.Name]) > 0)},
+		}
+		if namespace.Status.Phase != "" {
+			attributes["k8s.namespace.phase"] = []string{string(namespace.Status.Phase)}
+		}
+		for key, value := range namespace.ObjectMeta.Labels {
+			if extconfig.Config.IncludesLabel("namespace", key) {
+				attributes[fmt.Sprintf("k8s.namespace.label.%v", key)] = []string{value}
+			}
+		}
+		for _, mode := range podSecurityModes {
+			if level, ok := namespace.ObjectMeta.Labels["pod-security.kubernetes.io/"+mode]; ok {
+				attributes["k8s.namespace.pod-security."+mode] = []string{level}
+			}
+		}
+		for key, value := range getResourceQuotaAttributes(quotas[namespace.Name]) {
+			attributes[key] = value
+		}
+
+		targets[i] = discovery_kit_api.Target{
+			Id:         targetName,
+			TargetType: NamespaceTargetType,
+			Label:      namespace.Name,
+			Attributes: attributes,
+		}
+	}
+	return extcommon.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesNamespace), nil
+}
+
+// countByNamespace counts the workloads and pods of all namespaces at once, instead of listing them for every namespace.
+func (d *namespaceDiscovery) countByNamespace() map[string]*namespaceCounts {
+	counts := make(map[string]*namespaceCounts)
+	get := func(meta metav1.ObjectMeta) *namespaceCounts {
+		if counts[meta.Namespace] == nil {
+			counts[meta.Namespace] = &namespaceCounts{}
+		}
+		return counts[meta.Namespace]
+	}
+	isCounted := func(meta metav1.ObjectMeta) bool {
+		return !d.k8s.IsExcludedFromDiscovery(meta)
+	}
+
+	for _, deployment := range d.k8s.Deployments() {
+		if isCounted(deployment.ObjectMeta) {
+			get(deployment.ObjectMeta).deployments++
+		}
+	}
+	for _, statefulSet := range d.k8s.StatefulSets() {
+		if isCounted(statefulSet.ObjectMeta) {
+			get(statefulSet.ObjectMeta).statefulSets++
+		}
+	}
+	for _, daemonSet := range d.k8s.DaemonSets() {
+		if isCounted(daemonSet.ObjectMeta) {
+			get(daemonSet.ObjectMeta).daemonSets++
+		}
+	}
+	for _, pod := range d.k8s.Pods() {
+		if isCounted(pod.ObjectMeta) {
+			get(pod.ObjectMeta).pods++
+		}
+	}
+	return counts
+}
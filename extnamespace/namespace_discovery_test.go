@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnamespace
+
+import (
+	"context"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func Test_namespaceDiscovery(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+
+	_, err := clientset.CoreV1().
+		Namespaces().
+		Create(context.Background(), &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "shop",
+				Labels: map[string]string{
+					"team":                               "checkout",
+					"secret-label":                       "secret",
+					"pod-security.kubernetes.io/enforce": "baseline",
+				},
+			},
+			Status: v1.NamespaceStatus{Phase: v1.NamespaceActive},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.CoreV1().
+		Namespaces().
+		Create(context.Background(), &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "ignored",
+				Labels: map[string]string{"steadybit.com/discovery-disabled": "true"},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientset.AppsV1().
+		Deployments("shop").
+		Create(context.Background(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	for _, name := range []string{"checkout-1", "checkout-2"} {
+		_, err = clientset.CoreV1().
+			Pods("shop").
+			Create(context.Background(), &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	_, err = clientset.CoreV1().
+		ResourceQuotas("shop").
+		Create(context.Background(), &v1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "shop"},
+			Status: v1.ResourceQuotaStatus{
+				Hard: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("2")},
+				Used: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("500m")},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	d := &namespaceDiscovery{k8s: client}
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		targets, _ := d.DiscoverTargets(context.Background())
+		assert.Len(c, targets, 1)
+		assert.Len(c, client.Pods(), 2)
+		assert.Len(c, client.ResourceQuotas(), 1)
+	}, 1*time.Second, 100*time.Millisecond)
+
+	// Then
+	targets, _ := d.DiscoverTargets(context.Background())
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/shop", target.Id)
+	assert.Equal(t, "shop", target.Label)
+	assert.Equal(t, NamespaceTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"k8s.cluster-name":         {"development"},
+		"k8s.distribution":         {"kubernetes"},
+		"k8s.namespace":            {"shop"},
+		"k8s.namespace.phase":      {"Active"},
+		"k8s.namespace.label.team": {"checkout"},
+		"k8s.namespace.label.pod-security.kubernetes.io/enforce":  {"baseline"},
+		"k8s.namespace.pod-security.enforce":                      {"baseline"},
+		"k8s.namespace.deployment-count":                          {"1"},
+		"k8s.namespace.statefulset-count":                         {"0"},
+		"k8s.namespace.daemonset-count":                           {"0"},
+		"k8s.namespace.pod-count":                                 {"2"},
+		"k8s.namespace.has-resource-quota":                        {"true"},
+		"k8s.namespace.resource-quota.name":                       {"compute"},
+		"k8s.namespace.resource-quota.requests.cpu.hard":          {"2"},
+		"k8s.namespace.resource-quota.requests.cpu.used":          {"500m"},
+		"k8s.namespace.resource-quota.requests.cpu.usage-percent": {"25"},
+	}, target.Attributes)
+}
+
+func Test_getResourceQuotaAttributesReportsQuotaWithHighestUsage(t *testing.T) {
+	// Given
+	quotas := []*v1.ResourceQuota{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "relaxed"},
+			Status: v1.ResourceQuotaStatus{
+				Hard: v1.ResourceList{v1.ResourceLimitsMemory: resource.MustParse("8Gi")},
+				Used: v1.ResourceList{v1.ResourceLimitsMemory: resource.MustParse("2Gi")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "strict"},
+			Spec: v1.ResourceQuotaSpec{
+				Hard: v1.ResourceList{v1.ResourceLimitsMemory: resource.MustParse("3Gi"), v1.ResourcePods: resource.MustParse("10")},
+			},
+			Status: v1.ResourceQuotaStatus{
+				Hard: v1.ResourceList{v1.ResourceLimitsMemory: resource.MustParse("3Gi"), v1.ResourcePods: resource.MustParse("10")},
+				Used: v1.ResourceList{v1.ResourceLimitsMemory: resource.MustParse("2Gi")},
+			},
+		},
+	}
+
+	// When
+	attributes := getResourceQuotaAttributes(quotas)
+
+	// Then
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace.resource-quota.name":                        {"relaxed", "strict"},
+		"k8s.namespace.resource-quota.limits.memory.hard":          {"3Gi"},
+		"k8s.namespace.resource-quota.limits.memory.used":          {"2Gi"},
+		"k8s.namespace.resource-quota.limits.memory.usage-percent": {"66"},
+		"k8s.namespace.resource-quota.pods.hard":                   {"10"},
+		"k8s.namespace.resource-quota.pods.used":                   {"0"},
+		"k8s.namespace.resource-quota.pods.usage-percent":          {"0"},
+	}, attributes)
+}
+
+func Test_getResourceQuotaAttributesWithLargeQuantities(t *testing.T) {
+	// Given
+	quotas := []*v1.ResourceQuota{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+			Status: v1.ResourceQuotaStatus{
+				Hard: v1.ResourceList{v1.ResourceRequestsStorage: resource.MustParse("200Ti")},
+				Used: v1.ResourceList{v1.ResourceRequestsStorage: resource.MustParse("150Ti")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute"},
+			Status: v1.ResourceQuotaStatus{
+				Hard: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("10")},
+				Used: v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("2900m")},
+			},
+		},
+	}
+
+	// When
+	attributes := getResourceQuotaAttributes(quotas)
+
+	// Then
+	assert.Equal(t, map[string][]string{
+		"k8s.namespace.resource-quota.name":                           {"compute", "storage"},
+		"k8s.namespace.resource-quota.requests.cpu.hard":              {"10"},
+		"k8s.namespace.resource-quota.requests.cpu.used":              {"2900m"},
+		"k8s.namespace.resource-quota.requests.cpu.usage-percent":     {"29"},
+		"k8s.namespace.resource-quota.requests.storage.hard":          {"200Ti"},
+		"k8s.namespace.resource-quota.requests.storage.used":          {"150Ti"},
+		"k8s.namespace.resource-quota.requests.storage.usage-percent": {"75"},
+	}, attributes)
+}
+
+func getTestClient(stopCh <-chan struct{}) (*client.Client, kubernetes.Interface) {
+	clientset := testclient.NewSimpleClientset()
+	client := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	return client, clientset
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnamespace
+
+import (
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sort"
+)
+
+type quotaUsage struct {
+	hard    resource.Quantity
+	used    resource.Quantity
+	percent int64
+}
+
+// getResourceQuotaAttributes describes the usage of the resource quotas of a namespace. If a resource is limited by
+// multiple quotas, the quota with the highest usage is reported, as it is the first one to reject new pods.
+func getResourceQuotaAttributes(quotas []*corev1.ResourceQuota) map[string][]string {
+	attributes := map[string][]string{}
+	if len(quotas) == 0 {
+		return attributes
+	}
+
+	names := make([]string, 0, len(quotas))
+	usages := make(map[corev1.ResourceName]quotaUsage)
+	for _, quota := range quotas {
+		names = append(names, quota.Name)
+		hardLimits := quota.Status.Hard
+		if len(hardLimits) == 0 {
+			// the status isn't populated until the quota controller calculated the usage for the first time
+			hardLimits = quota.Spec.Hard
+		}
+		for name, hard := range hardLimits {
+			usage := quotaUsage{hard: hard, used: quota.Status.Used[name]}
+			if hard.Sign() > 0 {
+				// milli values overflow for large quantities, e.g. storage quotas of 100Ti
+				usage.percent = int64(usage.used.AsApproximateFloat64() * 100 / hard.AsApproximateFloat64())
+			} else if !usage.used.IsZero() {
+				usage.percent = 100
+			}
+			if existing, ok := usages[name]; !ok || usage.percent > existing.percent {
+				usages[name] = usage
+			}
+		}
+	}
+
+	// the quotas are listed in random order
+	sort.Strings(names)
+	attributes["k8s.namespace.resource-quota.name"] = names
+	for name, usage := range usages {
+		attributes[fmt.Sprintf("k8s.namespace.resource-quota.%s.hard", name)] = []string{usage.hard.String()}
+		attributes[fmt.Sprintf("k8s.namespace.resource-quota.%s.used", name)] = []string{usage.used.String()}
+		attributes[fmt.Sprintf("k8s.namespace.resource-quota.%s.usage-percent", name)] = []string{fmt.Sprintf("%d", usage.percent)}
+	}
+	return attributes
+}
@@ -90,6 +90,7 @@ func drainNode() extcommon.KubectlOptsProvider {
 			LogTargetType:               "node",
 			LogTargetName:               nodeName,
 			LogActionName:               "drain node",
+			AuditTarget:                 &extcommon.ExecutionTarget{Kind: "Node", Name: nodeName},
 		}, nil
 	}
 }
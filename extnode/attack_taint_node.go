@@ -141,6 +141,7 @@ func taintNode() extcommon.KubectlOptsProvider {
 			LogTargetType:   "node",
 			LogTargetName:   nodeName,
 			LogActionName:   "taint node",
+			AuditTarget:     &extcommon.ExecutionTarget{Kind: "Node", Name: nodeName},
 		}, nil
 	}
 }
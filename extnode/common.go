@@ -4,8 +4,12 @@
 package extnode
 
 const (
-	NodeTargetType         = "com.steadybit.extension_kubernetes.kubernetes-node"
-	DrainNodeActionId      = "com.steadybit.extension_kubernetes.drain_node"
-	TaintNodeActionId      = "com.steadybit.extension_kubernetes.taint_node"
-	NodeCountCheckActionId = "com.steadybit.extension_kubernetes.node_count_check"
+	NodeTargetType                   = "com.steadybit.extension_kubernetes.kubernetes-node"
+	DrainNodeActionId                = "com.steadybit.extension_kubernetes.drain_node"
+	TaintNodeActionId                = "com.steadybit.extension_kubernetes.taint_node"
+	NodeCountCheckActionId           = "com.steadybit.extension_kubernetes.node_count_check"
+	NodeConditionCheckActionId       = "com.steadybit.extension_kubernetes.node_condition_check"
+	NodeResourceUsageMetricsActionId = "com.steadybit.extension_kubernetes.node_resource_usage_metrics"
+
+	nodeCheckIcon = "data:image/svg+xml;base64,PHN2ZyB3aWR0aD0iMjQiIGhlaWdodD0iMjQiIHZpZXdCb3g9IjAgMCAyNCAyNCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KPHBhdGggZmlsbC1ydWxlPSJldmVub2RkIiBjbGlwLXJ1bGU9ImV2ZW5vZGQiIGQ9Ik02LjQ5IDkuNjNMMi41OSA4LjE2QzIuMjMgOC4wMyAyIDcuNjkgMiA3LjMxQzIgNi45MyAyLjIzIDYuNTkgMi41OSA2LjQ2TDExLjYgMy4wNkMxMS44IDIuOTggMTIuMDMgMi45OCAxMi4yMyAzLjA2TDIxLjI0IDYuNDZDMjEuNiA2LjU5IDIxLjgzIDYuOTMgMjEuODMgNy4zMUMyMS44MyA3LjY5IDIxLjU5IDguMDMgMjEuMjQgOC4xNkwxNy40OSA5LjU4QzE2LjU1IDcuNDcgMTQuNDcgNiAxMiA2QzkuNTMgNiA3LjQxIDcuNDkgNi40OSA5LjYzWk0xNCAxMC4wMUwxMS4xNyAxMi45OEwxMC4wMSAxMS43NUM5Ljc0IDExLjQ3IDkuMyAxMS40NyA5LjAyIDExLjczQzguNzQgMTIgOC43NCAxMi40NCA5IDEyLjcyTDEwLjY2IDE0LjQ3QzEwLjc5IDE0LjYxIDEwLjk3IDE0LjY4IDExLjE2IDE0LjY4QzExLjM1IDE0LjY4IDExLjUzIDE0LjYgMTEuNjYgMTQuNDdMMTUgMTAuOTdDMTUuMjcgMTAuNjkgMTUuMjYgMTAuMjUgMTQuOTggOS45OEMxNC43IDkuNzEgMTQuMjYgOS43MiAxMy45OSAxMEwxNCAxMC4wMVpNMy4yMTk5OCAxMS4yM0MyLjc0OTk4IDExLjA1IDIuMjI5OTggMTEuMjkgMi4wNTk5OCAxMS43NkMxLjg4OTk4IDEyLjIzIDIuMTE5OTggMTIuNzUgMi41ODk5OCAxMi45M0w2LjUxOTk4IDE0LjQxQzYuMjI5OTggMTMuNzUgNi4wNTk5OCAxMy4wNCA2LjAxOTk4IDEyLjI4TDMuMjE5OTggMTEuMjJWMTEuMjNaTTIwLjYgMTYuMDFMMTEuOTEgMTkuMjlMMy4yMTk5OCAxNi4wMUMyLjc0OTk4IDE1LjgzIDIuMjI5OTggMTYuMDcgMi4wNTk5OCAxNi41NEMxLjg4OTk4IDE3LjAxIDIuMTE5OTggMTcuNTMgMi41ODk5OCAxNy43MUwxMS42IDIxLjExQzExLjggMjEuMTkgMTIuMDMgMjEuMTkgMTIuMjMgMjEuMTFMMjEuMjQgMTcuNzFDMjEuNzEgMTcuNTMgMjEuOTQgMTcuMDEgMjEuNzcgMTYuNTRDMjEuNiAxNi4wNyAyMS4wOCAxNS44MyAyMC42MSAxNi4wMUgyMC42Wk0xNy45OCAxMi4yMkwyMC42IDExLjIzQzIxLjA3IDExLjA1IDIxLjU5IDExLjI5IDIxLjc2IDExLjc2QzIxLjkzIDEyLjIzIDIxLjcgMTIuNzUgMjEuMjMgMTIuOTNMMTcuNTIgMTQuMzNDMTcuOCAxMy42OCAxNy45NSAxMi45NyAxNy45OCAxMi4yMloiIGZpbGw9IiMxRDI2MzIiLz4KPC9zdmc+Cg=="
 )
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnode
+
+import (
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	"strings"
+)
+
+const (
+	regionLabel       = "topology.kubernetes.io/region"
+	instanceTypeLabel = "node.kubernetes.io/instance-type"
+
+	capacityTypeSpot     = "spot"
+	capacityTypeOnDemand = "on-demand"
+)
+
+// well-known labels of the managed node pools of EKS, GKE, AKS, eksctl and Karpenter, in order of precedence
+var nodePoolLabels = []string{
+	"karpenter.sh/nodepool",
+	"karpenter.sh/provisioner-name",
+	"eks.amazonaws.com/nodegroup",
+	"alpha.eksctl.io/nodegroup-name",
+	"cloud.google.com/gke-nodepool",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+}
+
+// getNodeAttributes describes the capacity, scheduling, versions and placement of the node.
+func getNodeAttributes(node *corev1.Node) map[string][]string {
+	attributes := map[string][]string{
+		"k8s.node.unschedulable": {fmt.Sprintf("%t", node.Spec.Unschedulable)},
+	}
+
+	if cpu, ok := node.Status.Capacity[corev1.ResourceCPU]; ok {
+		attributes["k8s.node.capacity.cpu"] = []string{cpu.String()}
+	}
+	if memory, ok := node.Status.Capacity[corev1.ResourceMemory]; ok {
+		attributes["k8s.node.capacity.memory"] = []string{memory.String()}
+	}
+	if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+		attributes["k8s.node.allocatable.cpu"] = []string{cpu.String()}
+	}
+	if memory, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+		attributes["k8s.node.allocatable.memory"] = []string{memory.String()}
+	}
+
+	if len(node.Spec.Taints) > 0 {
+		taints := make([]string, 0, len(node.Spec.Taints))
+		for _, taint := range node.Spec.Taints {
+			taints = append(taints, taint.ToString())
+		}
+		attributes["k8s.node.taint"] = taints
+	}
+
+	nodeInfo := node.Status.NodeInfo
+	addIfNotEmpty(attributes, "k8s.node.kubelet-version", nodeInfo.KubeletVersion)
+	addIfNotEmpty(attributes, "k8s.node.container-runtime-version", nodeInfo.ContainerRuntimeVersion)
+	addIfNotEmpty(attributes, "k8s.node.os-image", nodeInfo.OSImage)
+	addIfNotEmpty(attributes, "k8s.node.kernel-version", nodeInfo.KernelVersion)
+	addIfNotEmpty(attributes, "k8s.node.operating-system", nodeInfo.OperatingSystem)
+	addIfNotEmpty(attributes, "k8s.node.architecture", nodeInfo.Architecture)
+
+	labels := node.Labels
+	addIfNotEmpty(attributes, "k8s.node.zone", firstLabel(labels, zoneLabel, "failure-domain.beta.kubernetes.io/zone"))
+	addIfNotEmpty(attributes, "k8s.node.region", firstLabel(labels, regionLabel, "failure-domain.beta.kubernetes.io/region"))
+	addIfNotEmpty(attributes, "k8s.node.instance-type", firstLabel(labels, instanceTypeLabel, "beta.kubernetes.io/instance-type"))
+	addIfNotEmpty(attributes, "k8s.node.pool", firstLabel(labels, nodePoolLabels...))
+	addIfNotEmpty(attributes, "k8s.node.capacity-type", capacityType(labels))
+	return attributes
+}
+
+// capacityType tells spot (or preemptible) nodes from on-demand nodes, based on the labels of the cloud providers.
+func capacityType(labels map[string]string) string {
+	if value, ok := labels["karpenter.sh/capacity-type"]; ok {
+		return normalizeCapacityType(value)
+	}
+	if value, ok := labels["eks.amazonaws.com/capacityType"]; ok {
+		return normalizeCapacityType(value)
+	}
+	if labels["cloud.google.com/gke-spot"] == "true" || labels["cloud.google.com/gke-preemptible"] == "true" {
+		return capacityTypeSpot
+	}
+	if value, ok := labels["kubernetes.azure.com/scalesetpriority"]; ok {
+		return normalizeCapacityType(value)
+	}
+	return ""
+}
+
+func normalizeCapacityType(value string) string {
+	switch strings.ToLower(strings.ReplaceAll(value, "_", "-")) {
+	case "spot", "preemptible":
+		return capacityTypeSpot
+	case "on-demand", "regular":
+		return capacityTypeOnDemand
+	default:
+		return strings.ToLower(value)
+	}
+}
+
+func firstLabel(labels map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := labels[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func addIfNotEmpty(attributes map[string][]string, key string, value string) {
+	if value != "" {
+		attributes[key] = []string{value}
+	}
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnode
+
+import (
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func Test_getNodeAttributes(t *testing.T) {
+	// Given
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ip-10-0-1-12",
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone":      "eu-central-1a",
+				"topology.kubernetes.io/region":    "eu-central-1",
+				"node.kubernetes.io/instance-type": "m5.xlarge",
+				"eks.amazonaws.com/nodegroup":      "workers",
+				"eks.amazonaws.com/capacityType":   "SPOT",
+			},
+		},
+		Spec: v1.NodeSpec{
+			Unschedulable: true,
+			Taints: []v1.Taint{
+				{Key: "node.kubernetes.io/unschedulable", Effect: v1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoExecute},
+			},
+		},
+		Status: v1.NodeStatus{
+			Capacity: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("3920m"),
+				v1.ResourceMemory: resource.MustParse("15Gi"),
+			},
+			NodeInfo: v1.NodeSystemInfo{
+				KubeletVersion:          "v1.29.3-eks-ae9a62a",
+				ContainerRuntimeVersion: "containerd://1.7.11",
+				OSImage:                 "Amazon Linux 2",
+				KernelVersion:           "5.10.213-201.855.amzn2.x86_64",
+				OperatingSystem:         "linux",
+				Architecture:            "amd64",
+			},
+		},
+	}
+
+	// When
+	attributes := getNodeAttributes(node)
+
+	// Then
+	assert.Equal(t, map[string][]string{
+		"k8s.node.unschedulable":             {"true"},
+		"k8s.node.capacity.cpu":              {"4"},
+		"k8s.node.capacity.memory":           {"16Gi"},
+		"k8s.node.allocatable.cpu":           {"3920m"},
+		"k8s.node.allocatable.memory":        {"15Gi"},
+		"k8s.node.taint":                     {"node.kubernetes.io/unschedulable:NoSchedule", "dedicated=gpu:NoExecute"},
+		"k8s.node.kubelet-version":           {"v1.29.3-eks-ae9a62a"},
+		"k8s.node.container-runtime-version": {"containerd://1.7.11"},
+		"k8s.node.os-image":                  {"Amazon Linux 2"},
+		"k8s.node.kernel-version":            {"5.10.213-201.855.amzn2.x86_64"},
+		"k8s.node.operating-system":          {"linux"},
+		"k8s.node.architecture":              {"amd64"},
+		"k8s.node.zone":                      {"eu-central-1a"},
+		"k8s.node.region":                    {"eu-central-1"},
+		"k8s.node.instance-type":             {"m5.xlarge"},
+		"k8s.node.pool":                      {"workers"},
+		"k8s.node.capacity-type":             {"spot"},
+	}, attributes)
+}
+
+func Test_getNodeAttributesOfNodePools(t *testing.T) {
+	tests := []struct {
+		name         string
+		labels       map[string]string
+		pool         []string
+		capacityType []string
+	}{
+		{
+			name:         "karpenter",
+			labels:       map[string]string{"karpenter.sh/nodepool": "default", "karpenter.sh/capacity-type": "on-demand", "eks.amazonaws.com/nodegroup": "system"},
+			pool:         []string{"default"},
+			capacityType: []string{"on-demand"},
+		},
+		{
+			name:         "gke spot",
+			labels:       map[string]string{"cloud.google.com/gke-nodepool": "pool-1", "cloud.google.com/gke-spot": "true"},
+			pool:         []string{"pool-1"},
+			capacityType: []string{"spot"},
+		},
+		{
+			name:         "gke on-demand",
+			labels:       map[string]string{"cloud.google.com/gke-nodepool": "pool-1"},
+			pool:         []string{"pool-1"},
+			capacityType: nil,
+		},
+		{
+			name:         "aks",
+			labels:       map[string]string{"kubernetes.azure.com/agentpool": "userpool", "kubernetes.azure.com/scalesetpriority": "spot"},
+			pool:         []string{"userpool"},
+			capacityType: []string{"spot"},
+		},
+		{
+			name:         "eks managed on-demand",
+			labels:       map[string]string{"eks.amazonaws.com/nodegroup": "workers", "eks.amazonaws.com/capacityType": "ON_DEMAND"},
+			pool:         []string{"workers"},
+			capacityType: []string{"on-demand"},
+		},
+		{
+			name: "unmanaged",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node", Labels: tt.labels}}
+
+			// When
+			attributes := getNodeAttributes(node)
+
+			// Then
+			assert.Equal(t, tt.pool, attributes["k8s.node.pool"])
+			assert.Equal(t, tt.capacityType, attributes["k8s.node.capacity-type"])
+		})
+	}
+}
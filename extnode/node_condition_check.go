@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcluster"
+	corev1 "k8s.io/api/core/v1"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	nodeConditionsReached        = "nodeConditionsReached"
+	nodeConditionsNeverViolated  = "nodeConditionsNeverViolated"
+	nodeConditionStatusIgnored   = "ignore"
+	maxReportedConditionMismatch = 5
+)
+
+var checkedNodeConditions = []struct {
+	conditionType corev1.NodeConditionType
+	parameter     string
+	defaultStatus corev1.ConditionStatus
+}{
+	{corev1.NodeReady, "conditionReady", corev1.ConditionTrue},
+	{corev1.NodeMemoryPressure, "conditionMemoryPressure", corev1.ConditionFalse},
+	{corev1.NodeDiskPressure, "conditionDiskPressure", corev1.ConditionFalse},
+	{corev1.NodePIDPressure, "conditionPIDPressure", corev1.ConditionFalse},
+	{corev1.NodeNetworkUnavailable, "conditionNetworkUnavailable", corev1.ConditionFalse},
+}
+
+type NodeConditionCheckAction struct {
+}
+
+type NodeConditionCheckState struct {
+	Timeout            time.Time
+	CheckMode          string
+	Cluster            string
+	NodeSelection      NodeSelection
+	ExpectedConditions map[string]string
+}
+
+type NodeConditionCheckConfig struct {
+	Duration                    int
+	NodeConditionCheckMode      string
+	NodeName                    string
+	NodeLabelSelector           string
+	Zone                        string
+	ConditionReady              string
+	ConditionMemoryPressure     string
+	ConditionDiskPressure       string
+	ConditionPIDPressure        string
+	ConditionNetworkUnavailable string
+}
+
+func NewNodeConditionCheckAction() action_kit_sdk.Action[NodeConditionCheckState] {
+	return NodeConditionCheckAction{}
+}
+
+var _ action_kit_sdk.Action[NodeConditionCheckState] = (*NodeConditionCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[NodeConditionCheckState] = (*NodeConditionCheckAction)(nil)
+
+func (f NodeConditionCheckAction) NewEmptyState() NodeConditionCheckState {
+	return NodeConditionCheckState{}
+}
+
+func (f NodeConditionCheckAction) Describe() action_kit_api.ActionDescription {
+	parameters := []action_kit_api.ActionParameter{
+		{
+			Name:         "duration",
+			Label:        "Timeout",
+			Description:  extutil.Ptr("How long should the check wait for the expected node conditions."),
+			Type:         action_kit_api.Duration,
+			DefaultValue: extutil.Ptr("10s"),
+			Order:        extutil.Ptr(1),
+			Required:     extutil.Ptr(true),
+		},
+		{
+			Name:         "nodeConditionCheckMode",
+			Label:        "Check type",
+			Description:  extutil.Ptr("Should the conditions be reached until the timeout or hold for the whole duration?"),
+			Type:         action_kit_api.String,
+			DefaultValue: extutil.Ptr(nodeConditionsReached),
+			Order:        extutil.Ptr(2),
+			Required:     extutil.Ptr(true),
+			Options: extutil.Ptr([]action_kit_api.ParameterOption{
+				action_kit_api.ExplicitParameterOption{
+					Label: "all nodes reach expected conditions",
+					Value: nodeConditionsReached,
+				},
+				action_kit_api.ExplicitParameterOption{
+					Label: "all nodes never violate expected conditions",
+					Value: nodeConditionsNeverViolated,
+				},
+			}),
+		},
+	}
+	for i, c := range checkedNodeConditions {
+		parameters = append(parameters, action_kit_api.ActionParameter{
+			Name:         c.parameter,
+			Label:        string(c.conditionType),
+			Description:  extutil.Ptr(fmt.Sprintf("Expected status of the node condition %s.", c.conditionType)),
+			Type:         action_kit_api.String,
+			DefaultValue: extutil.Ptr(string(c.defaultStatus)),
+			Order:        extutil.Ptr(3 + i),
+			Required:     extutil.Ptr(true),
+			Options: extutil.Ptr([]action_kit_api.ParameterOption{
+				action_kit_api.ExplicitParameterOption{
+					Label: "True",
+					Value: string(corev1.ConditionTrue),
+				},
+				action_kit_api.ExplicitParameterOption{
+					Label: "False",
+					Value: string(corev1.ConditionFalse),
+				},
+				action_kit_api.ExplicitParameterOption{
+					Label: "Ignore",
+					Value: nodeConditionStatusIgnored,
+				},
+			}),
+		})
+	}
+	parameters = append(parameters, nodeSelectionParameters(3+len(checkedNodeConditions))...)
+
+	return action_kit_api.ActionDescription{
+		Id:          NodeConditionCheckActionId,
+		Label:       "Node Conditions",
+		Description: "Verify conditions (e.g. Ready, MemoryPressure, DiskPressure) of a node or a node pool",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(nodeCheckIcon),
+		Category:    extutil.Ptr("Kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          extcluster.ClusterTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.ExactlyOne),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find cluster by name"),
+					Query:       "k8s.cluster-name=\"\"",
+				},
+			}),
+		}),
+		Parameters: parameters,
+		Prepare:    action_kit_api.MutatingEndpointReference{},
+		Start:      action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f NodeConditionCheckAction) Prepare(_ context.Context, state *NodeConditionCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return prepareNodeConditionCheckInternal(state, request)
+}
+
+func prepareNodeConditionCheckInternal(state *NodeConditionCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config NodeConditionCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+
+	nodeSelection := NodeSelection{
+		NodeName:      config.NodeName,
+		LabelSelector: config.NodeLabelSelector,
+		Zone:          config.Zone,
+	}
+	if err := nodeSelection.validate(); err != nil {
+		return nil, extension_kit.ToError("Invalid node selection.", err)
+	}
+
+	expected := map[string]string{
+		string(corev1.NodeReady):              config.ConditionReady,
+		string(corev1.NodeMemoryPressure):     config.ConditionMemoryPressure,
+		string(corev1.NodeDiskPressure):       config.ConditionDiskPressure,
+		string(corev1.NodePIDPressure):        config.ConditionPIDPressure,
+		string(corev1.NodeNetworkUnavailable): config.ConditionNetworkUnavailable,
+	}
+	for conditionType, status := range expected {
+		if status == "" || status == nodeConditionStatusIgnored {
+			delete(expected, conditionType)
+		}
+	}
+	if len(expected) == 0 {
+		return nil, extension_kit.ToError("At least one node condition must be checked.", nil)
+	}
+
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.CheckMode = config.NodeConditionCheckMode
+	state.Cluster = request.Target.Attributes["k8s.cluster-name"][0]
+	state.NodeSelection = nodeSelection
+	state.ExpectedConditions = expected
+	return nil, nil
+}
+
+func (f NodeConditionCheckAction) Start(_ context.Context, _ *NodeConditionCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f NodeConditionCheckAction) Status(_ context.Context, state *NodeConditionCheckState) (*action_kit_api.StatusResult, error) {
+	return statusNodeConditionCheckInternal(client.K8S, state), nil
+}
+
+func statusNodeConditionCheckInternal(k8s *client.Client, state *NodeConditionCheckState) *action_kit_api.StatusResult {
+	now := time.Now()
+	nodes, err := state.NodeSelection.nodes(k8s)
+	if err != nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  "Invalid node selection.",
+				Detail: extutil.Ptr(err.Error()),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	var checkError *action_kit_api.ActionKitError
+	if len(nodes) == 0 {
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("%s has no nodes matching %s.", state.Cluster, state.NodeSelection),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if mismatches := nodeConditionMismatches(nodes, state.ExpectedConditions); len(mismatches) > 0 {
+		title := strings.Join(mismatches, ", ")
+		if len(mismatches) > maxReportedConditionMismatch {
+			title = fmt.Sprintf("%s and %d more", strings.Join(mismatches[:maxReportedConditionMismatch], ", "), len(mismatches)-maxReportedConditionMismatch)
+		}
+		checkError = extutil.Ptr(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("Unexpected node conditions: %s.", title),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+
+	if state.CheckMode == nodeConditionsNeverViolated {
+		return &action_kit_api.StatusResult{
+			Completed: checkError != nil || now.After(state.Timeout),
+			Error:     checkError,
+		}
+	}
+
+	if now.After(state.Timeout) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error:     checkError,
+		}
+	} else {
+		return &action_kit_api.StatusResult{
+			Completed: checkError == nil,
+		}
+	}
+}
+
+func nodeConditionMismatches(nodes []*corev1.Node, expected map[string]string) []string {
+	var mismatches []string
+	for _, node := range nodes {
+		for conditionType, expectedStatus := range expected {
+			actualStatus := corev1.ConditionUnknown
+			for _, condition := range node.Status.Conditions {
+				if string(condition.Type) == conditionType {
+					actualStatus = condition.Status
+				}
+			}
+			if string(actualStatus) != expectedStatus {
+				mismatches = append(mismatches, fmt.Sprintf("%s %s is %s (expected %s)", node.Name, conditionType, actualStatus, expectedStatus))
+			}
+		}
+	}
+	sort.Strings(mismatches)
+	return mismatches
+}
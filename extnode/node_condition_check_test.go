@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func TestPrepareNodeConditionCheckExtractsState(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":                    1000 * 10,
+			"nodeConditionCheckMode":      nodeConditionsNeverViolated,
+			"nodeLabelSelector":           "pool=workers",
+			"zone":                        "eu-central-1a",
+			"conditionReady":              "True",
+			"conditionMemoryPressure":     "False",
+			"conditionDiskPressure":       "ignore",
+			"conditionPIDPressure":        "ignore",
+			"conditionNetworkUnavailable": "ignore",
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {"test"},
+			},
+		}),
+	}
+	state := NewNodeConditionCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareNodeConditionCheckInternal(&state, request)
+	require.NoError(t, err)
+
+	// Then
+	require.True(t, state.Timeout.After(time.Now()))
+	require.Equal(t, nodeConditionsNeverViolated, state.CheckMode)
+	require.Equal(t, "test", state.Cluster)
+	require.Equal(t, NodeSelection{LabelSelector: "pool=workers", Zone: "eu-central-1a"}, state.NodeSelection)
+	require.Equal(t, map[string]string{"Ready": "True", "MemoryPressure": "False"}, state.ExpectedConditions)
+}
+
+func TestPrepareNodeConditionCheckRejectsInvalidSelector(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":          1000,
+			"nodeLabelSelector": "pool in (",
+			"conditionReady":    "True",
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {"test"},
+			},
+		}),
+	}
+	state := NewNodeConditionCheckAction().NewEmptyState()
+
+	// When
+	_, err := prepareNodeConditionCheckInternal(&state, request)
+
+	// Then
+	require.ErrorContains(t, err, "Invalid node selection.")
+}
+
+func TestStatusNodeConditionCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          string
+		selection     NodeSelection
+		timeout       time.Duration
+		completed     bool
+		expectedError string
+	}{
+		{
+			name:      "healthy node reaches conditions",
+			mode:      nodeConditionsReached,
+			selection: NodeSelection{NodeName: "node1"},
+			timeout:   time.Minute,
+			completed: true,
+		},
+		{
+			name:      "node pool with pressure waits until timeout",
+			mode:      nodeConditionsReached,
+			selection: NodeSelection{LabelSelector: "pool=workers"},
+			timeout:   time.Minute,
+			completed: false,
+		},
+		{
+			name:          "node pool with pressure fails after timeout",
+			mode:          nodeConditionsReached,
+			selection:     NodeSelection{LabelSelector: "pool=workers"},
+			timeout:       -time.Second,
+			completed:     true,
+			expectedError: "Unexpected node conditions: node2 MemoryPressure is True (expected False).",
+		},
+		{
+			name:          "zone without nodes fails",
+			mode:          nodeConditionsReached,
+			selection:     NodeSelection{Zone: "zone-c"},
+			timeout:       -time.Second,
+			completed:     true,
+			expectedError: "test has no nodes matching zone zone-c.",
+		},
+		{
+			name:      "never violated is asserted until timeout",
+			mode:      nodeConditionsNeverViolated,
+			selection: NodeSelection{Zone: "zone-a"},
+			timeout:   time.Minute,
+			completed: false,
+		},
+		{
+			name:          "never violated fails immediately",
+			mode:          nodeConditionsNeverViolated,
+			selection:     NodeSelection{Zone: "zone-b"},
+			timeout:       time.Minute,
+			completed:     true,
+			expectedError: "Unexpected node conditions: node2 MemoryPressure is True (expected False).",
+		},
+	}
+
+	k8sclient, stopCh := createNodeConditionTestClient(t)
+	defer close(stopCh)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			state := NodeConditionCheckState{
+				Timeout:            time.Now().Add(tt.timeout),
+				CheckMode:          tt.mode,
+				Cluster:            "test",
+				NodeSelection:      tt.selection,
+				ExpectedConditions: map[string]string{"Ready": "True", "MemoryPressure": "False"},
+			}
+
+			// When
+			result := statusNodeConditionCheckInternal(k8sclient, &state)
+
+			// Then
+			assert.Equal(t, tt.completed, result.Completed)
+			if tt.expectedError == "" {
+				assert.Nil(t, result.Error)
+			} else {
+				require.NotNil(t, result.Error)
+				assert.Equal(t, tt.expectedError, result.Error.Title)
+				assert.Equal(t, action_kit_api.Failed, *result.Error.Status)
+			}
+		})
+	}
+}
+
+func createNodeConditionTestClient(t *testing.T) (*client.Client, chan struct{}) {
+	clientset := testclient.NewSimpleClientset()
+	createNode := func(name string, labels map[string]string, memoryPressure corev1.ConditionStatus) {
+		_, err := clientset.CoreV1().Nodes().Create(context.Background(), &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+					{Type: corev1.NodeMemoryPressure, Status: memoryPressure},
+				},
+			},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	createNode("node1", map[string]string{"pool": "system", zoneLabel: "zone-a"}, corev1.ConditionFalse)
+	createNode("node2", map[string]string{"pool": "workers", zoneLabel: "zone-b"}, corev1.ConditionTrue)
+
+	stopCh := make(chan struct{})
+	k8sclient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	require.Eventually(t, func() bool {
+		return len(k8sclient.Nodes()) == 2
+	}, time.Second, 100*time.Millisecond)
+	return k8sclient, stopCh
+}
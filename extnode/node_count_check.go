@@ -14,6 +14,7 @@ import (
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extcluster"
+	corev1 "k8s.io/api/core/v1"
 	"time"
 )
 
@@ -21,6 +22,9 @@ const (
 	nodeCountAtLeast     = "nodeCountAtLeast"
 	nodeCountDecreasedBy = "nodeCountDecreasedBy"
 	nodeCountIncreasedBy = "nodeCountIncreasedBy"
+
+	countReadyNodes            = "readyNodes"
+	countReadySchedulableNodes = "readySchedulableNodes"
 )
 
 type NodeCountCheckAction struct {
@@ -32,12 +36,17 @@ type NodeCountCheckState struct {
 	Cluster            string
 	NodeCount          int
 	InitialNodeCount   int
+	CountedNodes       string
+	NodeSelection      NodeSelection
 }
 
 type NodeCountCheckConfig struct {
 	Duration           int
 	NodeCountCheckMode string
 	NodeCount          int
+	CountedNodes       string
+	NodeLabelSelector  string
+	Zone               string
 }
 
 func NewNodeCountCheckAction() action_kit_sdk.Action[NodeCountCheckState] {
@@ -57,7 +66,7 @@ func (f NodeCountCheckAction) Describe() action_kit_api.ActionDescription {
 		Label:       "Node Count",
 		Description: "Verify node counts",
 		Version:     extbuild.GetSemverVersionStringOrUnknown(),
-		Icon:        extutil.Ptr("data:image/svg+xml;base64,PHN2ZyB3aWR0aD0iMjQiIGhlaWdodD0iMjQiIHZpZXdCb3g9IjAgMCAyNCAyNCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KPHBhdGggZmlsbC1ydWxlPSJldmVub2RkIiBjbGlwLXJ1bGU9ImV2ZW5vZGQiIGQ9Ik02LjQ5IDkuNjNMMi41OSA4LjE2QzIuMjMgOC4wMyAyIDcuNjkgMiA3LjMxQzIgNi45MyAyLjIzIDYuNTkgMi41OSA2LjQ2TDExLjYgMy4wNkMxMS44IDIuOTggMTIuMDMgMi45OCAxMi4yMyAzLjA2TDIxLjI0IDYuNDZDMjEuNiA2LjU5IDIxLjgzIDYuOTMgMjEuODMgNy4zMUMyMS44MyA3LjY5IDIxLjU5IDguMDMgMjEuMjQgOC4xNkwxNy40OSA5LjU4QzE2LjU1IDcuNDcgMTQuNDcgNiAxMiA2QzkuNTMgNiA3LjQxIDcuNDkgNi40OSA5LjYzWk0xNCAxMC4wMUwxMS4xNyAxMi45OEwxMC4wMSAxMS43NUM5Ljc0IDExLjQ3IDkuMyAxMS40NyA5LjAyIDExLjczQzguNzQgMTIgOC43NCAxMi40NCA5IDEyLjcyTDEwLjY2IDE0LjQ3QzEwLjc5IDE0LjYxIDEwLjk3IDE0LjY4IDExLjE2IDE0LjY4QzExLjM1IDE0LjY4IDExLjUzIDE0LjYgMTEuNjYgMTQuNDdMMTUgMTAuOTdDMTUuMjcgMTAuNjkgMTUuMjYgMTAuMjUgMTQuOTggOS45OEMxNC43IDkuNzEgMTQuMjYgOS43MiAxMy45OSAxMEwxNCAxMC4wMVpNMy4yMTk5OCAxMS4yM0MyLjc0OTk4IDExLjA1IDIuMjI5OTggMTEuMjkgMi4wNTk5OCAxMS43NkMxLjg4OTk4IDEyLjIzIDIuMTE5OTggMTIuNzUgMi41ODk5OCAxMi45M0w2LjUxOTk4IDE0LjQxQzYuMjI5OTggMTMuNzUgNi4wNTk5OCAxMy4wNCA2LjAxOTk4IDEyLjI4TDMuMjE5OTggMTEuMjJWMTEuMjNaTTIwLjYgMTYuMDFMMTEuOTEgMTkuMjlMMy4yMTk5OCAxNi4wMUMyLjc0OTk4IDE1LjgzIDIuMjI5OTggMTYuMDcgMi4wNTk5OCAxNi41NEMxLjg4OTk4IDE3LjAxIDIuMTE5OTggMTcuNTMgMi41ODk5OCAxNy43MUwxMS42IDIxLjExQzExLjggMjEuMTkgMTIuMDMgMjEuMTkgMTIuMjMgMjEuMTFMMjEuMjQgMTcuNzFDMjEuNzEgMTcuNTMgMjEuOTQgMTcuMDEgMjEuNzcgMTYuNTRDMjEuNiAxNi4wNyAyMS4wOCAxNS44MyAyMC42MSAxNi4wMUgyMC42Wk0xNy45OCAxMi4yMkwyMC42IDExLjIzQzIxLjA3IDExLjA1IDIxLjU5IDExLjI5IDIxLjc2IDExLjc2QzIxLjkzIDEyLjIzIDIxLjcgMTIuNzUgMjEuMjMgMTIuOTNMMTcuNTIgMTQuMzNDMTcuOCAxMy42OCAxNy45NSAxMi45NyAxNy45OCAxMi4yMloiIGZpbGw9IiMxRDI2MzIiLz4KPC9zdmc+Cg=="),
+		Icon:        extutil.Ptr(nodeCheckIcon),
 		Category:    extutil.Ptr("Kubernetes"),
 		Kind:        action_kit_api.Check,
 		TimeControl: action_kit_api.TimeControlInternal,
@@ -72,7 +81,7 @@ func (f NodeCountCheckAction) Describe() action_kit_api.ActionDescription {
 				},
 			}),
 		}),
-		Parameters: []action_kit_api.ActionParameter{
+		Parameters: append([]action_kit_api.ActionParameter{
 			{
 				Name:         "duration",
 				Label:        "Timeout",
@@ -114,7 +123,26 @@ func (f NodeCountCheckAction) Describe() action_kit_api.ActionDescription {
 					},
 				}),
 			},
-		},
+			{
+				Name:         "countedNodes",
+				Label:        "Counted nodes",
+				Description:  extutil.Ptr("Which nodes should be counted? Cordoned nodes are not schedulable."),
+				Type:         action_kit_api.String,
+				DefaultValue: extutil.Ptr(countReadyNodes),
+				Order:        extutil.Ptr(3),
+				Required:     extutil.Ptr(false),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "ready nodes",
+						Value: countReadyNodes,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "ready and schedulable nodes",
+						Value: countReadySchedulableNodes,
+					},
+				}),
+			},
+		}, nodePoolSelectionParameters(4)...),
 		Prepare: action_kit_api.MutatingEndpointReference{},
 		Start:   action_kit_api.MutatingEndpointReference{},
 		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
@@ -132,11 +160,26 @@ func prepareNodeCountCheckInternal(k8s *client.Client, state *NodeCountCheckStat
 	if err := extconversion.Convert(request.Config, &config); err != nil {
 		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
 	}
+	nodeSelection := NodeSelection{
+		LabelSelector: config.NodeLabelSelector,
+		Zone:          config.Zone,
+	}
+	nodes, err := nodeSelection.nodes(k8s)
+	if err != nil {
+		return nil, extension_kit.ToError("Invalid node selection.", err)
+	}
+	countedNodes := config.CountedNodes
+	if countedNodes == "" {
+		countedNodes = countReadyNodes
+	}
+
 	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
 	state.Cluster = request.Target.Attributes["k8s.cluster-name"][0]
 	state.NodeCountCheckMode = config.NodeCountCheckMode
 	state.NodeCount = config.NodeCount
-	state.InitialNodeCount = k8s.NodesReadyCount()
+	state.CountedNodes = countedNodes
+	state.NodeSelection = nodeSelection
+	state.InitialNodeCount = countNodes(nodes, countedNodes)
 	return nil, nil
 }
 
@@ -150,7 +193,17 @@ func (f NodeCountCheckAction) Status(_ context.Context, state *NodeCountCheckSta
 
 func statusNodeCountCheckInternal(k8s *client.Client, state *NodeCountCheckState) *action_kit_api.StatusResult {
 	now := time.Now()
-	readyCount := k8s.NodesReadyCount()
+	nodes, err := state.NodeSelection.nodes(k8s)
+	if err != nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  "Invalid node selection.",
+				Detail: extutil.Ptr(err.Error()),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+	readyCount := countNodes(nodes, state.CountedNodes)
 
 	var checkError *action_kit_api.ActionKitError
 	if state.NodeCountCheckMode == nodeCountAtLeast && readyCount < state.NodeCount {
@@ -180,5 +233,19 @@ func statusNodeCountCheckInternal(k8s *client.Client, state *NodeCountCheckState
 			Completed: checkError == nil,
 		}
 	}
+}
 
+func countNodes(nodes []*corev1.Node, countedNodes string) int {
+	count := 0
+	for _, node := range nodes {
+		if countedNodes == countReadySchedulableNodes && node.Spec.Unschedulable {
+			continue
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				count = count + 1
+			}
+		}
+	}
+	return count
 }
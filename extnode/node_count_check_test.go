@@ -414,3 +414,74 @@ func TestStatusCheckNodeCountIncreasedByFail(t *testing.T) {
 	require.True(t, result.Completed)
 	require.Equal(t, "test has only 1 of desired 2 nodes ready.", result.Error.Title)
 }
+
+func TestStatusCheckNodeCountFilteredByNodePoolAndSchedulable(t *testing.T) {
+	clientset := testclient.NewSimpleClientset()
+	createNode := func(name string, pool string, zone string, unschedulable bool) {
+		_, err := clientset.CoreV1().Nodes().Create(context.Background(), &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"pool": pool, zoneLabel: zone},
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: unschedulable,
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	createNode("node1", "system", "zone-a", false)
+	createNode("node2", "workers", "zone-a", true)
+	createNode("node3", "workers", "zone-b", false)
+	createNode("node4", "workers", "zone-b", false)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	require.Eventually(t, func() bool {
+		return len(k8sclient.Nodes()) == 4
+	}, time.Second, 100*time.Millisecond)
+
+	tests := []struct {
+		name          string
+		countedNodes  string
+		selection     NodeSelection
+		nodeCount     int
+		expectedError *string
+	}{
+		{name: "whole cluster", countedNodes: countReadyNodes, nodeCount: 4},
+		{name: "node pool", countedNodes: countReadyNodes, selection: NodeSelection{LabelSelector: "pool=workers"}, nodeCount: 3},
+		{name: "node pool schedulable", countedNodes: countReadySchedulableNodes, selection: NodeSelection{LabelSelector: "pool=workers"}, nodeCount: 2},
+		{name: "node pool schedulable ignores cordoned", countedNodes: countReadySchedulableNodes, selection: NodeSelection{LabelSelector: "pool=workers"}, nodeCount: 3, expectedError: extutil.Ptr("test has not enough ready nodes.")},
+		{name: "zone", countedNodes: countReadyNodes, selection: NodeSelection{Zone: "zone-a"}, nodeCount: 2},
+		{name: "node pool in zone schedulable", countedNodes: countReadySchedulableNodes, selection: NodeSelection{LabelSelector: "pool=workers", Zone: "zone-a"}, nodeCount: 1, expectedError: extutil.Ptr("test has not enough ready nodes.")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			state := NodeCountCheckState{
+				Timeout:            time.Now().Add(time.Minute * -1),
+				NodeCountCheckMode: nodeCountAtLeast,
+				Cluster:            "test",
+				NodeCount:          tt.nodeCount,
+				CountedNodes:       tt.countedNodes,
+				NodeSelection:      tt.selection,
+			}
+
+			// When
+			result := statusNodeCountCheckInternal(k8sclient, &state)
+
+			// Then
+			require.True(t, result.Completed)
+			if tt.expectedError == nil {
+				require.Nil(t, result.Error)
+			} else {
+				require.Equal(t, *tt.expectedError, result.Error.Title)
+			}
+		})
+	}
+}
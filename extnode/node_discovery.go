@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
-	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
@@ -18,7 +17,6 @@ import (
 	"github.com/steadybit/extension-kubernetes/extdeployment"
 	"github.com/steadybit/extension-kubernetes/extpod"
 	"github.com/steadybit/extension-kubernetes/extstatefulset"
-	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	"reflect"
 	"strings"
@@ -204,18 +202,15 @@ func (d *nodeDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.
 	nodes := d.k8s.Nodes()
 
 	filteredNodes := make([]*corev1.Node, 0, len(nodes))
-	if extconfig.Config.DisableDiscoveryExcludes {
-		filteredNodes = nodes
-	} else {
-		for _, node := range nodes {
-			if client.IsExcludedFromDiscovery(node.ObjectMeta) {
-				continue
-			}
-			filteredNodes = append(filteredNodes, node)
+	for _, node := range nodes {
+		if d.k8s.IsExcludedFromDiscovery(node.ObjectMeta) {
+			continue
 		}
+		filteredNodes = append(filteredNodes, node)
 	}
 
 	targets := make([]discovery_kit_api.Target, len(filteredNodes))
+	owners := client.NewOwnerReferenceCache(d.k8s)
 	for i, node := range filteredNodes {
 		attributes := map[string][]string{
 			"k8s.node.name":    {node.Name},
@@ -226,12 +221,18 @@ func (d *nodeDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.
 		}
 
 		for key, value := range node.ObjectMeta.Labels {
-			if !slices.Contains(extconfig.Config.LabelFilter, key) {
+			if extconfig.Config.IncludesLabel("node", key) {
 				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
 			}
 		}
+		for key, value := range extcommon.GetAnnotationAttributes("node", node.ObjectMeta) {
+			attributes[key] = value
+		}
+		for key, value := range getNodeAttributes(node) {
+			attributes[key] = value
+		}
 
-		pods := d.k8s.Pods()
+		pods := d.k8s.PodsByNodeName(node.Name)
 		if len(pods) > 0 {
 			var podNames []string
 			var containerIds []string
@@ -242,7 +243,7 @@ func (d *nodeDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.
 			replicaSets := make(map[string]bool)
 			namespaces := make(map[string]bool)
 			for _, pod := range pods {
-				if pod.Spec.NodeName == node.Name && !client.IsExcludedFromDiscovery(pod.ObjectMeta) {
+				if !d.k8s.IsExcludedFromDiscovery(pod.ObjectMeta) {
 					podNames = append(podNames, pod.Name)
 					for _, container := range pod.Status.ContainerStatuses {
 						if container.ContainerID == "" {
@@ -252,8 +253,7 @@ func (d *nodeDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.
 						containerIdsWithoutPrefix = append(containerIdsWithoutPrefix, strings.SplitAfter(container.ContainerID, "://")[1])
 					}
 					namespaces[pod.Namespace] = true
-					ownerReferences := client.OwnerReferences(d.k8s, &pod.ObjectMeta)
-					for _, ownerReference := range ownerReferences.OwnerRefs {
+					for _, ownerReference := range owners.OwnerReferences(&pod.ObjectMeta) {
 						if ownerReference.Kind == "replicaset" {
 							replicaSets[ownerReference.Name] = true
 						}
@@ -302,7 +302,7 @@ func (d *nodeDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.
 			Attributes: attributes,
 		}
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesNode), nil
+	return extcommon.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesNode), nil
 }
 
 func keys(m map[string]bool) []string {
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"sync"
+	"testing"
+)
+
+const (
+	benchmarkNodes           = 5_000
+	benchmarkPods            = 100_000
+	benchmarkNamespaces      = 100
+	benchmarkPodsPerWorkload = 20
+)
+
+var (
+	benchmarkClientOnce sync.Once
+	benchmarkClient     *client.Client
+)
+
+// BenchmarkNodeDiscovery discovers the nodes of a synthetic cluster with 5,000 nodes and 100,000 pods.
+//
+//	go test ./extnode -run '^$' -bench BenchmarkNodeDiscovery -benchmem
+func BenchmarkNodeDiscovery(b *testing.B) {
+	k8s := getBenchmarkClient(b)
+	d := &nodeDiscovery{k8s: k8s}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		targets, err := d.DiscoverTargets(context.Background())
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(targets) != benchmarkNodes {
+			b.Fatalf("expected %d targets, got %d", benchmarkNodes, len(targets))
+		}
+	}
+}
+
+// BenchmarkServicesMatchingToPodLabels looks up the services of all pods of the synthetic cluster.
+func BenchmarkServicesMatchingToPodLabels(b *testing.B) {
+	k8s := getBenchmarkClient(b)
+	pods := k8s.Pods()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, pod := range pods {
+			if len(k8s.ServicesMatchingToPodLabels(pod.Namespace, pod.Labels)) != 1 {
+				b.Fatalf("expected one service for pod %s/%s", pod.Namespace, pod.Name)
+			}
+		}
+	}
+}
+
+// BenchmarkPodOwnerReferences resolves the owners (replicaset and deployment) of all pods of the synthetic cluster, once
+// walking the owners of every pod and once with the owner cache used by the node discovery.
+func BenchmarkPodOwnerReferences(b *testing.B) {
+	k8s := getBenchmarkClient(b)
+	pods := k8s.Pods()
+
+	b.Run("per pod", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, pod := range pods {
+				if len(client.OwnerReferences(k8s, &pod.ObjectMeta).OwnerRefs) != 2 {
+					b.Fatalf("expected two owners of pod %s/%s", pod.Namespace, pod.Name)
+				}
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			owners := client.NewOwnerReferenceCache(k8s)
+			for _, pod := range pods {
+				if len(owners.OwnerReferences(&pod.ObjectMeta)) != 2 {
+					b.Fatalf("expected two owners of pod %s/%s", pod.Namespace, pod.Name)
+				}
+			}
+		}
+	})
+}
+
+// getBenchmarkClient creates the synthetic cluster once, as syncing 100,000 pods into the informers takes a while.
+func getBenchmarkClient(b *testing.B) *client.Client {
+	benchmarkClientOnce.Do(func() {
+		extconfig.Config.ClusterName = "benchmark"
+		objects := make([]runtime.Object, 0, benchmarkNodes+benchmarkPods+benchmarkPods/benchmarkPodsPerWorkload*3)
+		for n := 0; n < benchmarkNodes; n++ {
+			objects = append(objects, &v1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("node-%d", n),
+				Labels: map[string]string{"topology.kubernetes.io/zone": fmt.Sprintf("zone-%d", n%3)},
+			}})
+		}
+		for w := 0; w < benchmarkPods/benchmarkPodsPerWorkload; w++ {
+			namespace := fmt.Sprintf("namespace-%d", w%benchmarkNamespaces)
+			name := fmt.Sprintf("workload-%d", w)
+			replicaSetUid := types.UID(fmt.Sprintf("replicaset-%d", w))
+			objects = append(objects,
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}},
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+					Name:            name,
+					Namespace:       namespace,
+					UID:             replicaSetUid,
+					OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: name}},
+				}},
+				&v1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+					Spec:       v1.ServiceSpec{Selector: map[string]string{"app": name}},
+				},
+			)
+			for p := 0; p < benchmarkPodsPerWorkload; p++ {
+				objects = append(objects, &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            fmt.Sprintf("%s-%d", name, p),
+						Namespace:       namespace,
+						Labels:          map[string]string{"app": name},
+						OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: name, UID: replicaSetUid}},
+					},
+					Spec: v1.PodSpec{NodeName: fmt.Sprintf("node-%d", (w*benchmarkPodsPerWorkload+p)%benchmarkNodes)},
+					Status: v1.PodStatus{
+						ContainerStatuses: []v1.ContainerStatus{{Name: "main", ContainerID: fmt.Sprintf("containerd://%s-%d", name, p)}},
+					},
+				})
+			}
+		}
+		// the informers of the client are stopped with the benchmark binary
+		benchmarkClient = client.CreateClient(testclient.NewSimpleClientset(objects...), make(chan struct{}), "", client.MockAllPermitted())
+	})
+	if len(benchmarkClient.Pods()) != benchmarkPods {
+		b.Fatalf("expected %d pods in the cache, got %d", benchmarkPods, len(benchmarkClient.Pods()))
+	}
+	return benchmarkClient
+}
@@ -155,6 +155,7 @@ func Test_nodeDiscovery(t *testing.T) {
 		"k8s.distribution":          {"kubernetes"},
 		"k8s.namespace":             {"default"},
 		"k8s.node.name":             {"node-123"},
+		"k8s.node.unschedulable":    {"false"},
 		"k8s.pod.name":              {"shop-pod-11"},
 	}, target.Attributes)
 }
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnode
+
+import (
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"strings"
+)
+
+const zoneLabel = "topology.kubernetes.io/zone"
+
+// NodeSelection narrows the nodes of a cluster down to a single node or a node pool. Empty fields don't filter.
+type NodeSelection struct {
+	NodeName      string
+	LabelSelector string
+	Zone          string
+}
+
+func nodeSelectionParameters(order int) []action_kit_api.ActionParameter {
+	return append([]action_kit_api.ActionParameter{
+		{
+			Name:        "nodeName",
+			Label:       "Node name",
+			Description: extutil.Ptr("Only consider the node with this name."),
+			Type:        action_kit_api.String,
+			Order:       extutil.Ptr(order),
+			Required:    extutil.Ptr(false),
+			Advanced:    extutil.Ptr(true),
+		},
+	}, nodePoolSelectionParameters(order+1)...)
+}
+
+func nodePoolSelectionParameters(order int) []action_kit_api.ActionParameter {
+	return []action_kit_api.ActionParameter{
+		{
+			Name:        "nodeLabelSelector",
+			Label:       "Node label selector",
+			Description: extutil.Ptr("Only consider nodes matching this label selector, e.g. 'eks.amazonaws.com/nodegroup=workers'."),
+			Type:        action_kit_api.String,
+			Order:       extutil.Ptr(order),
+			Required:    extutil.Ptr(false),
+			Advanced:    extutil.Ptr(true),
+		},
+		{
+			Name:        "zone",
+			Label:       "Zone",
+			Description: extutil.Ptr("Only consider nodes in this zone (label 'topology.kubernetes.io/zone')."),
+			Type:        action_kit_api.String,
+			Order:       extutil.Ptr(order + 1),
+			Required:    extutil.Ptr(false),
+			Advanced:    extutil.Ptr(true),
+		},
+	}
+}
+
+func (s NodeSelection) validate() error {
+	_, err := s.selector()
+	return err
+}
+
+func (s NodeSelection) selector() (labels.Selector, error) {
+	selector := labels.Everything()
+	if s.LabelSelector != "" {
+		parsed, err := labels.Parse(s.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node label selector '%s': %w", s.LabelSelector, err)
+		}
+		selector = parsed
+	}
+	if s.Zone != "" {
+		requirement, err := labels.NewRequirement(zoneLabel, selection.Equals, []string{s.Zone})
+		if err != nil {
+			return nil, fmt.Errorf("invalid zone '%s': %w", s.Zone, err)
+		}
+		selector = selector.Add(*requirement)
+	}
+	return selector, nil
+}
+
+func (s NodeSelection) nodes(k8s *client.Client) ([]*corev1.Node, error) {
+	selector, err := s.selector()
+	if err != nil {
+		return nil, err
+	}
+	if s.NodeName != "" {
+		node := k8s.NodeByName(s.NodeName)
+		if node == nil || !selector.Matches(labels.Set(node.Labels)) {
+			return []*corev1.Node{}, nil
+		}
+		return []*corev1.Node{node}, nil
+	}
+	return k8s.NodesBySelector(selector), nil
+}
+
+func (s NodeSelection) String() string {
+	var filters []string
+	if s.NodeName != "" {
+		filters = append(filters, fmt.Sprintf("node name %s", s.NodeName))
+	}
+	if s.LabelSelector != "" {
+		filters = append(filters, fmt.Sprintf("label selector '%s'", s.LabelSelector))
+	}
+	if s.Zone != "" {
+		filters = append(filters, fmt.Sprintf("zone %s", s.Zone))
+	}
+	if len(filters) == 0 {
+		return "all nodes"
+	}
+	return strings.Join(filters, ", ")
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnode
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"time"
+)
+
+type NodeResourceUsageMetricsAction struct {
+}
+
+type NodeResourceUsageMetricsState struct {
+	End           time.Time
+	Node          string
+	Thresholds    extcommon.ResourceUsageThresholds
+	LastTimestamp time.Time
+}
+
+type NodeResourceUsageMetricsConfig struct {
+	Duration                 int
+	CpuMillicoresThreshold   int64
+	MemoryMegabytesThreshold int64
+}
+
+func NewNodeResourceUsageMetricsAction() action_kit_sdk.Action[NodeResourceUsageMetricsState] {
+	return NodeResourceUsageMetricsAction{}
+}
+
+var _ action_kit_sdk.Action[NodeResourceUsageMetricsState] = (*NodeResourceUsageMetricsAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[NodeResourceUsageMetricsState] = (*NodeResourceUsageMetricsAction)(nil)
+
+func (f NodeResourceUsageMetricsAction) NewEmptyState() NodeResourceUsageMetricsState {
+	return NodeResourceUsageMetricsState{}
+}
+
+func (f NodeResourceUsageMetricsAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          NodeResourceUsageMetricsActionId,
+		Label:       "Node Resource Usage",
+		Description: "Collects the CPU and memory usage of nodes from the metrics-server and optionally fails if a threshold is exceeded",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(extcommon.ResourceUsageIcon),
+		Category:    extutil.Ptr("Kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          NodeTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find nodes by cluster and name"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.node.name=\"\"",
+				},
+			}),
+		}),
+		Parameters: extcommon.ResourceUsageParameters(),
+		Widgets:    extutil.Ptr(extcommon.ResourceUsageWidgets("k8s.node.name")),
+		Prepare:    action_kit_api.MutatingEndpointReference{},
+		Start:      action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
+	}
+}
+
+func (f NodeResourceUsageMetricsAction) Prepare(_ context.Context, state *NodeResourceUsageMetricsState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config NodeResourceUsageMetricsConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.End = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Node = request.Target.Attributes["k8s.node.name"][0]
+	state.Thresholds = extcommon.ResourceUsageThresholds{
+		CpuMillicores:   config.CpuMillicoresThreshold,
+		MemoryMegabytes: config.MemoryMegabytesThreshold,
+	}
+	return nil, nil
+}
+
+func (f NodeResourceUsageMetricsAction) Start(_ context.Context, _ *NodeResourceUsageMetricsState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f NodeResourceUsageMetricsAction) Status(_ context.Context, state *NodeResourceUsageMetricsState) (*action_kit_api.StatusResult, error) {
+	return statusNodeResourceUsageMetricsInternal(client.K8S, state), nil
+}
+
+func statusNodeResourceUsageMetricsInternal(k8s *client.Client, state *NodeResourceUsageMetricsState) *action_kit_api.StatusResult {
+	completed := time.Now().After(state.End)
+
+	nodeMetrics, err := k8s.NodeMetricsByName(state.Node)
+	if k8sErrors.IsNotFound(err) {
+		// the metrics-server reports new nodes only after the first scrape
+		return &action_kit_api.StatusResult{Completed: completed}
+	} else if err != nil {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Failed to fetch resource usage of node %s.", state.Node),
+				Detail: extutil.Ptr(err.Error()),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	if !nodeMetrics.Timestamp.Time.After(state.LastTimestamp) {
+		return &action_kit_api.StatusResult{Completed: completed}
+	}
+	state.LastTimestamp = nodeMetrics.Timestamp.Time
+
+	metrics := extcommon.ResourceUsageMetrics(map[string]string{
+		"k8s.cluster-name": extconfig.Config.ClusterName,
+		"k8s.node.name":    state.Node,
+	}, nodeMetrics.Usage, nodeMetrics.Timestamp.Time)
+	checkError := extcommon.ResourceUsageThresholdError(fmt.Sprintf("node %s", state.Node), nodeMetrics.Usage, state.Thresholds)
+
+	return &action_kit_api.StatusResult{
+		Completed: completed || checkError != nil,
+		Error:     checkError,
+		Metrics:   extutil.Ptr(metrics),
+	}
+}
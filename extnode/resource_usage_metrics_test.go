@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extnode
+
+import (
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+	"testing"
+	"time"
+)
+
+func TestStatusNodeResourceUsageMetrics(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	timestamp := time.Now().Truncate(time.Second)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sclient := client.CreateClient(testclient.NewSimpleClientset(), stopCh, "", client.MockAllPermitted())
+	metricsClientset := metricsfake.NewSimpleClientset()
+	metricsClientset.PrependReactor("get", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1.NodeMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Timestamp:  metav1.NewTime(timestamp),
+			Usage: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1500m"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+		}, nil
+	})
+	k8sclient.SetMetricsClient(metricsClientset)
+	state := NodeResourceUsageMetricsState{
+		End:        time.Now().Add(-time.Second),
+		Node:       "worker-1",
+		Thresholds: extcommon.ResourceUsageThresholds{CpuMillicores: 1000},
+	}
+
+	// When
+	result := statusNodeResourceUsageMetricsInternal(k8sclient, &state)
+
+	// Then
+	assert.True(t, result.Completed)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "CPU usage of node worker-1 is 1500m and exceeds the threshold of 1000m.", result.Error.Title)
+	require.NotNil(t, result.Metrics)
+	metrics := *result.Metrics
+	require.Len(t, metrics, 2)
+	assert.Equal(t, float64(1500), metrics[0].Value)
+	assert.Equal(t, float64(2*1024*1024*1024), metrics[1].Value)
+	assert.Equal(t, map[string]string{
+		"k8s.cluster-name": "development",
+		"k8s.node.name":    "worker-1",
+	}, metrics[1].Metric)
+}
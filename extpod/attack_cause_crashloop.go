@@ -14,6 +14,7 @@ import (
 	"github.com/steadybit/extension-kit/extconversion"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"os/exec"
 	"strings"
 )
@@ -22,9 +23,10 @@ type CrashLoopAction struct {
 }
 
 type CrashLoopState struct {
-	Namespace string `json:"namespace"`
-	Pod       string `json:"pod"`
-	Container string `json:"container,omitempty"`
+	Namespace string                 `json:"namespace"`
+	Pod       string                 `json:"pod"`
+	Container string                 `json:"container,omitempty"`
+	Audit     *extcommon.AttackAudit `json:"audit,omitempty"`
 }
 
 type CrashLoopConfig struct {
@@ -37,6 +39,7 @@ func NewCrashLoopAction() action_kit_sdk.Action[CrashLoopState] {
 
 var _ action_kit_sdk.Action[CrashLoopState] = (*CrashLoopAction)(nil)
 var _ action_kit_sdk.ActionWithStatus[CrashLoopState] = (*CrashLoopAction)(nil)
+var _ action_kit_sdk.ActionWithStop[CrashLoopState] = (*CrashLoopAction)(nil)
 
 func (f CrashLoopAction) NewEmptyState() CrashLoopState {
 	return CrashLoopState{}
@@ -84,6 +87,7 @@ func (f CrashLoopAction) Describe() action_kit_api.ActionDescription {
 		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
 			CallInterval: extutil.Ptr("2s"), //Containers are killed in the status endpoint
 		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
 	}
 }
 
@@ -119,10 +123,13 @@ func (f CrashLoopAction) Prepare(_ context.Context, state *CrashLoopState, reque
 	state.Namespace = namespace
 	state.Pod = podName
 	state.Container = config.Container
+	state.Audit = extcommon.NewAttackAudit(request, extcommon.ExecutionTarget{Kind: "Pod", Namespace: namespace, Name: podName}, "cause crash loop")
+	extcommon.RememberExecutionTarget(request, "Pod")
 	return nil, nil
 }
 
 func (f CrashLoopAction) Start(_ context.Context, state *CrashLoopState) (*action_kit_api.StartResult, error) {
+	state.Audit.Started(client.K8S)
 	_, err := statusInternal(state)
 	return nil, err
 }
@@ -131,6 +138,11 @@ func (f CrashLoopAction) Status(_ context.Context, state *CrashLoopState) (*acti
 	return statusInternal(state)
 }
 
+func (f CrashLoopAction) Stop(_ context.Context, state *CrashLoopState) (*action_kit_api.StopResult, error) {
+	state.Audit.Stop(client.K8S)
+	return nil, nil
+}
+
 func statusInternal(state *CrashLoopState) (*action_kit_api.StatusResult, error) {
 	pod := client.K8S.PodByNamespaceAndName(state.Namespace, state.Pod)
 	if pod == nil {
@@ -5,6 +5,7 @@ import (
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -42,6 +43,10 @@ func Test_Prepare(t *testing.T) {
 			wantState: CrashLoopState{
 				Namespace: "shop",
 				Pod:       "checkout-xyz1234",
+				Audit: &extcommon.AttackAudit{
+					Target: extcommon.ExecutionTarget{Namespace: "shop", Kind: "Pod", Name: "checkout-xyz1234"},
+					Action: "cause crash loop",
+				},
 			},
 		},
 		{
@@ -53,6 +58,10 @@ func Test_Prepare(t *testing.T) {
 				Namespace: "shop",
 				Pod:       "checkout-xyz1234",
 				Container: "example",
+				Audit: &extcommon.AttackAudit{
+					Target: extcommon.ExecutionTarget{Namespace: "shop", Kind: "Pod", Name: "checkout-xyz1234"},
+					Action: "cause crash loop",
+				},
 			},
 		},
 	}
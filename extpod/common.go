@@ -1,7 +1,9 @@
 package extpod
 
 const (
-	PodTargetType     = "com.steadybit.extension_kubernetes.kubernetes-pod"
-	DeletePodActionId = "com.steadybit.extension_kubernetes.delete_pod"
-	CrashLoopActionId = "com.steadybit.extension_kubernetes.crash_loop_pod"
+	PodTargetType                   = "com.steadybit.extension_kubernetes.kubernetes-pod"
+	DeletePodActionId               = "com.steadybit.extension_kubernetes.delete_pod"
+	CrashLoopActionId               = "com.steadybit.extension_kubernetes.crash_loop_pod"
+	PodResourceUsageMetricsActionId = "com.steadybit.extension_kubernetes.pod_resource_usage_metrics"
+	ContainerLogsActionId           = "com.steadybit.extension_kubernetes.pod_container_logs"
 )
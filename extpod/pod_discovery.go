@@ -7,14 +7,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
-	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extcommon"
 	"github.com/steadybit/extension-kubernetes/extconfig"
-	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	"reflect"
 	"strings"
@@ -31,7 +29,7 @@ var (
 
 func NewPodDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
 	discovery := &podDiscovery{k8s: k8s}
-	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s, reflect.TypeOf(corev1.Pod{}))
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s, reflect.TypeOf(corev1.Pod{}), reflect.TypeOf(corev1.Namespace{}))
 	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
 		discovery_kit_sdk.WithRefreshTargetsNow(),
 		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, 5*time.Second),
@@ -75,15 +73,11 @@ func (p *podDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.T
 	pods := p.k8s.Pods()
 
 	filteredPods := make([]*corev1.Pod, 0, len(pods))
-	if extconfig.Config.DisableDiscoveryExcludes {
-		filteredPods = pods
-	} else {
-		for _, pod := range pods {
-			if client.IsExcludedFromDiscovery(pod.ObjectMeta) {
-				continue
-			}
-			filteredPods = append(filteredPods, pod)
+	for _, pod := range pods {
+		if p.k8s.IsExcludedFromDiscovery(pod.ObjectMeta) {
+			continue
 		}
+		filteredPods = append(filteredPods, pod)
 	}
 
 	nodes := p.k8s.Nodes()
@@ -100,10 +94,13 @@ func (p *podDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.T
 		}
 
 		for key, value := range pod.ObjectMeta.Labels {
-			if !slices.Contains(extconfig.Config.LabelFilter, key) {
+			if extconfig.Config.IncludesLabel("pod", key) {
 				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
 			}
 		}
+		for key, value := range extcommon.GetAnnotationAttributes("pod", pod.ObjectMeta) {
+			attributes[key] = value
+		}
 
 		var containerIds []string
 		var containerIdsWithoutPrefix []string
@@ -128,6 +125,10 @@ func (p *podDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.T
 			attributes["k8s.workload-owner"] = []string{ownerRef.Name}
 		}
 
+		for key, value := range getPodStatusAttributes(pod) {
+			attributes[key] = value
+		}
+
 		services := p.k8s.ServicesMatchingToPodLabels(pod.Namespace, pod.ObjectMeta.Labels)
 		if len(services) > 0 {
 			var serviceNames = make([]string, 0, len(services))
@@ -144,5 +145,70 @@ func (p *podDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.T
 			Attributes: attributes,
 		}
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesPod), nil
+	return extcommon.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesPod), nil
+}
+
+// getPodStatusAttributes describes the scheduling and lifecycle of the pod, e.g. to select only Guaranteed pods.
+func getPodStatusAttributes(pod *corev1.Pod) map[string][]string {
+	attributes := map[string][]string{
+		"k8s.pod.ready": {fmt.Sprintf("%t", isPodReady(pod))},
+	}
+	if pod.Status.Phase != "" {
+		attributes["k8s.pod.phase"] = []string{string(pod.Status.Phase)}
+	}
+	if pod.Status.QOSClass != "" {
+		attributes["k8s.pod.qos-class"] = []string{string(pod.Status.QOSClass)}
+	}
+	var podIPs []string
+	for _, podIP := range pod.Status.PodIPs {
+		podIPs = append(podIPs, podIP.IP)
+	}
+	if len(podIPs) == 0 && pod.Status.PodIP != "" {
+		podIPs = []string{pod.Status.PodIP}
+	}
+	if len(podIPs) > 0 {
+		attributes["k8s.pod.ip"] = podIPs
+	}
+	if pod.Spec.PriorityClassName != "" {
+		attributes["k8s.pod.priority-class"] = []string{pod.Spec.PriorityClassName}
+	}
+	if pod.Spec.ServiceAccountName != "" {
+		attributes["k8s.pod.service-account"] = []string{pod.Spec.ServiceAccountName}
+	}
+	if pod.Status.StartTime != nil {
+		attributes["k8s.pod.start-time"] = []string{pod.Status.StartTime.UTC().Format(time.RFC3339)}
+	}
+
+	restartCount := int32(0)
+	for _, container := range pod.Status.ContainerStatuses {
+		restartCount += container.RestartCount
+	}
+	attributes["k8s.pod.restart-count"] = []string{fmt.Sprintf("%d", restartCount)}
+
+	var initContainers []string
+	var sidecarContainers []string
+	for _, container := range pod.Spec.InitContainers {
+		// native sidecars are init containers which keep running next to the containers of the pod
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			sidecarContainers = append(sidecarContainers, container.Name)
+		} else {
+			initContainers = append(initContainers, container.Name)
+		}
+	}
+	if len(initContainers) > 0 {
+		attributes["k8s.pod.init-container"] = initContainers
+	}
+	if len(sidecarContainers) > 0 {
+		attributes["k8s.pod.sidecar-container"] = sidecarContainers
+	}
+	return attributes
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
 }
@@ -5,6 +5,7 @@ package extpod
 
 import (
 	"context"
+	"github.com/steadybit/extension-kit/extutil"
 	"github.com/steadybit/extension-kubernetes/client"
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	"github.com/stretchr/testify/assert"
@@ -47,16 +48,36 @@ func Test_getDiscoveredPods(t *testing.T) {
 				},
 			},
 			Status: v1.PodStatus{
+				Phase:     v1.PodRunning,
+				QOSClass:  v1.PodQOSGuaranteed,
+				PodIP:     "10.0.0.12",
+				PodIPs:    []v1.PodIP{{IP: "10.0.0.12"}, {IP: "fd00::12"}},
+				StartTime: &metav1.Time{Time: time.Date(2024, 5, 17, 8, 30, 0, 0, time.UTC)},
+				Conditions: []v1.PodCondition{
+					{Type: v1.PodScheduled, Status: v1.ConditionTrue},
+					{Type: v1.PodReady, Status: v1.ConditionTrue},
+				},
 				ContainerStatuses: []v1.ContainerStatus{
 					{
-						ContainerID: "crio://abcdef",
-						Name:        "MrFancyPants",
-						Image:       "nginx",
+						ContainerID:  "crio://abcdef",
+						Name:         "MrFancyPants",
+						Image:        "nginx",
+						RestartCount: 2,
+					},
+					{
+						Name:         "envoy",
+						RestartCount: 1,
 					},
 				},
 			},
 			Spec: v1.PodSpec{
-				NodeName: "worker-1",
+				NodeName:           "worker-1",
+				PriorityClassName:  "business-critical",
+				ServiceAccountName: "shop",
+				InitContainers: []v1.Container{
+					{Name: "migrate-db"},
+					{Name: "envoy", RestartPolicy: extutil.Ptr(v1.ContainerRestartPolicyAlways)},
+				},
 			},
 		}, metav1.CreateOptions{})
 	require.NoError(t, err)
@@ -122,6 +143,16 @@ func Test_getDiscoveredPods(t *testing.T) {
 		"k8s.namespace":             {"default"},
 		"k8s.node.name":             {"worker-1"},
 		"k8s.pod.name":              {"shop-pod"},
+		"k8s.pod.phase":             {"Running"},
+		"k8s.pod.qos-class":         {"Guaranteed"},
+		"k8s.pod.ip":                {"10.0.0.12", "fd00::12"},
+		"k8s.pod.priority-class":    {"business-critical"},
+		"k8s.pod.service-account":   {"shop"},
+		"k8s.pod.start-time":        {"2024-05-17T08:30:00Z"},
+		"k8s.pod.ready":             {"true"},
+		"k8s.pod.restart-count":     {"3"},
+		"k8s.pod.init-container":    {"migrate-db"},
+		"k8s.pod.sidecar-container": {"envoy"},
 	}, target.Attributes)
 }
 
@@ -193,12 +224,14 @@ func Test_getDiscoveredPods_ignore_empty_container_ids(t *testing.T) {
 	assert.Equal(t, "shop-pod", target.Label)
 	assert.Equal(t, PodTargetType, target.TargetType)
 	assert.Equal(t, map[string][]string{
-		"host.domainname":  {"worker-1.internal"},
-		"host.hostname":    {"worker-1"},
-		"k8s.cluster-name": {"development"},
-		"k8s.namespace":    {"default"},
-		"k8s.node.name":    {"worker-1"},
-		"k8s.pod.name":     {"shop-pod"},
+		"host.domainname":       {"worker-1.internal"},
+		"host.hostname":         {"worker-1"},
+		"k8s.cluster-name":      {"development"},
+		"k8s.namespace":         {"default"},
+		"k8s.node.name":         {"worker-1"},
+		"k8s.pod.name":          {"shop-pod"},
+		"k8s.pod.ready":         {"false"},
+		"k8s.pod.restart-count": {"0"},
 	}, target.Attributes)
 }
 
@@ -289,6 +322,87 @@ func Test_getDiscoveredPodsShouldIgnoreLabeledPods(t *testing.T) {
 
 }
 
+func Test_getDiscoveredPodsWithAnnotations(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DiscoveryAnnotations = []string{"example.com/owner", "oncall.example.com/*"}
+	defer func() { extconfig.Config.DiscoveryAnnotations = nil }()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+
+	_, err := clientset.CoreV1().
+		Pods("default").
+		Create(context.Background(), &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop-pod",
+				Namespace: "default",
+				Annotations: map[string]string{
+					"example.com/owner":                                "team-shop",
+					"oncall.example.com/rotation":                      "shop-primary",
+					"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "worker-1",
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	d := &podDiscovery{k8s: client}
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		ed, _ := d.DiscoverTargets(context.Background())
+		assert.Len(c, ed, 1)
+	}, 1*time.Second, 100*time.Millisecond)
+	targets, _ := d.DiscoverTargets(context.Background())
+
+	// Then
+	require.Len(t, targets, 1)
+	attributes := targets[0].Attributes
+	assert.Equal(t, []string{"team-shop"}, attributes["k8s.annotation.example.com/owner"])
+	assert.Equal(t, []string{"team-shop"}, attributes["k8s.pod.annotation.example.com/owner"])
+	assert.Equal(t, []string{"shop-primary"}, attributes["k8s.annotation.oncall.example.com/rotation"])
+	assert.Equal(t, []string{"shop-primary"}, attributes["k8s.pod.annotation.oncall.example.com/rotation"])
+	assert.NotContains(t, attributes, "k8s.annotation.kubectl.kubernetes.io/last-applied-configuration")
+	assert.NotContains(t, attributes, "k8s.pod.annotation.kubectl.kubernetes.io/last-applied-configuration")
+}
+
+func Test_getDiscoveredPodsExcludedByNamespace(t *testing.T) {
+	// Given
+	extconfig.Config.ClusterName = "development"
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client, clientset := getTestClient(stopCh)
+
+	for _, namespace := range []*v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kube-system", Labels: map[string]string{"steadybit.com/discovery-disabled": "true"}}},
+	} {
+		_, err := clientset.CoreV1().Namespaces().Create(context.Background(), namespace, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	for _, pod := range []*v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}},
+	} {
+		_, err := clientset.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	d := &podDiscovery{k8s: client}
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, client.Pods(), 2)
+		assert.Len(c, client.Namespaces(), 2)
+	}, 1*time.Second, 100*time.Millisecond)
+	targets, _ := d.DiscoverTargets(context.Background())
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, "checkout", targets[0].Label)
+}
+
 func getTestClient(stopCh <-chan struct{}) (*client.Client, kubernetes.Interface) {
 	clientset := testclient.NewSimpleClientset()
 	client := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extpod
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"time"
+)
+
+type PodResourceUsageMetricsAction struct {
+}
+
+type PodResourceUsageMetricsState struct {
+	End           time.Time
+	Namespace     string
+	Pod           string
+	Thresholds    extcommon.ResourceUsageThresholds
+	LastTimestamp time.Time
+}
+
+type PodResourceUsageMetricsConfig struct {
+	Duration                 int
+	CpuMillicoresThreshold   int64
+	MemoryMegabytesThreshold int64
+}
+
+func NewPodResourceUsageMetricsAction() action_kit_sdk.Action[PodResourceUsageMetricsState] {
+	return PodResourceUsageMetricsAction{}
+}
+
+var _ action_kit_sdk.Action[PodResourceUsageMetricsState] = (*PodResourceUsageMetricsAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[PodResourceUsageMetricsState] = (*PodResourceUsageMetricsAction)(nil)
+
+func (f PodResourceUsageMetricsAction) NewEmptyState() PodResourceUsageMetricsState {
+	return PodResourceUsageMetricsState{}
+}
+
+func (f PodResourceUsageMetricsAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          PodResourceUsageMetricsActionId,
+		Label:       "Pod Resource Usage",
+		Description: "Collects the CPU and memory usage of pods from the metrics-server and optionally fails if a threshold is exceeded",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(extcommon.ResourceUsageIcon),
+		Category:    extutil.Ptr("Kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          PodTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find pods by cluster, namespace and deployment"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.deployment=\"\"",
+				},
+			}),
+		}),
+		Parameters: extcommon.ResourceUsageParameters(),
+		Widgets:    extutil.Ptr(extcommon.ResourceUsageWidgets("k8s.pod.name", extcommon.LineChartWidgetTooltipContent{From: "k8s.namespace", Title: "Namespace"})),
+		Prepare:    action_kit_api.MutatingEndpointReference{},
+		Start:      action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
+	}
+}
+
+func (f PodResourceUsageMetricsAction) Prepare(_ context.Context, state *PodResourceUsageMetricsState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config PodResourceUsageMetricsConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.End = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Pod = request.Target.Attributes["k8s.pod.name"][0]
+	state.Thresholds = extcommon.ResourceUsageThresholds{
+		CpuMillicores:   config.CpuMillicoresThreshold,
+		MemoryMegabytes: config.MemoryMegabytesThreshold,
+	}
+	return nil, nil
+}
+
+func (f PodResourceUsageMetricsAction) Start(_ context.Context, _ *PodResourceUsageMetricsState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f PodResourceUsageMetricsAction) Status(_ context.Context, state *PodResourceUsageMetricsState) (*action_kit_api.StatusResult, error) {
+	return statusPodResourceUsageMetricsInternal(client.K8S, state), nil
+}
+
+func statusPodResourceUsageMetricsInternal(k8s *client.Client, state *PodResourceUsageMetricsState) *action_kit_api.StatusResult {
+	completed := time.Now().After(state.End)
+
+	podMetrics, err := k8s.PodMetricsByNamespaceAndName(state.Namespace, state.Pod)
+	if k8sErrors.IsNotFound(err) {
+		// the metrics-server reports new pods only after the first scrape
+		return &action_kit_api.StatusResult{Completed: completed}
+	} else if err != nil {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Failed to fetch resource usage of pod %s/%s.", state.Namespace, state.Pod),
+				Detail: extutil.Ptr(err.Error()),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	if !podMetrics.Timestamp.Time.After(state.LastTimestamp) {
+		return &action_kit_api.StatusResult{Completed: completed}
+	}
+	state.LastTimestamp = podMetrics.Timestamp.Time
+
+	usage := extcommon.SumContainerUsage(podMetrics.Containers)
+	metrics := extcommon.ResourceUsageMetrics(map[string]string{
+		"k8s.cluster-name": extconfig.Config.ClusterName,
+		"k8s.namespace":    state.Namespace,
+		"k8s.pod.name":     state.Pod,
+	}, usage, podMetrics.Timestamp.Time)
+	checkError := extcommon.ResourceUsageThresholdError(fmt.Sprintf("pod %s/%s", state.Namespace, state.Pod), usage, state.Thresholds)
+
+	return &action_kit_api.StatusResult{
+		Completed: completed || checkError != nil,
+		Error:     checkError,
+		Metrics:   extutil.Ptr(metrics),
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extpod
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+	"testing"
+	"time"
+)
+
+func TestPreparePodResourceUsageMetricsExtractsState(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":                 1000 * 60,
+			"cpuMillicoresThreshold":   500,
+			"memoryMegabytesThreshold": 256,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace": {"shop"},
+				"k8s.pod.name":  {"checkout-1"},
+			},
+		}),
+	}
+	action := NewPodResourceUsageMetricsAction()
+	state := action.NewEmptyState()
+
+	// When
+	result, err := action.Prepare(context.Background(), &state, request)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result)
+	assert.True(t, state.End.After(time.Now()))
+	assert.Equal(t, "shop", state.Namespace)
+	assert.Equal(t, "checkout-1", state.Pod)
+	assert.Equal(t, extcommon.ResourceUsageThresholds{CpuMillicores: 500, MemoryMegabytes: 256}, state.Thresholds)
+}
+
+func TestDescribePodResourceUsageMetricsChartsUsagePerPod(t *testing.T) {
+	// When
+	description := NewPodResourceUsageMetricsAction().Describe()
+
+	// Then
+	require.NotNil(t, description.Widgets)
+	var charted []string
+	for _, widget := range *description.Widgets {
+		chart, ok := widget.(extcommon.LineChartWidget)
+		require.True(t, ok)
+		assert.Equal(t, extcommon.LineChartWidgetType, chart.Type)
+		assert.Equal(t, "k8s.pod.name", chart.Identity.From)
+		charted = append(charted, chart.Identity.MetricName)
+	}
+	assert.ElementsMatch(t, []string{extcommon.ResourceUsageCpuMetric, extcommon.ResourceUsageMemoryMetric}, charted)
+}
+
+func TestStatusPodResourceUsageMetrics(t *testing.T) {
+	tests := []struct {
+		name          string
+		thresholds    extcommon.ResourceUsageThresholds
+		completed     bool
+		expectedError string
+	}{
+		{
+			name:      "without thresholds",
+			completed: false,
+		},
+		{
+			name:       "below thresholds",
+			thresholds: extcommon.ResourceUsageThresholds{CpuMillicores: 500, MemoryMegabytes: 512},
+			completed:  false,
+		},
+		{
+			name:          "cpu threshold exceeded",
+			thresholds:    extcommon.ResourceUsageThresholds{CpuMillicores: 200},
+			completed:     true,
+			expectedError: "CPU usage of pod shop/checkout-1 is 300m and exceeds the threshold of 200m.",
+		},
+		{
+			name:          "memory threshold exceeded",
+			thresholds:    extcommon.ResourceUsageThresholds{MemoryMegabytes: 256},
+			completed:     true,
+			expectedError: "Memory usage of pod shop/checkout-1 is 384Mi and exceeds the threshold of 256Mi.",
+		},
+	}
+	extconfig.Config.ClusterName = "development"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			timestamp := time.Now().Truncate(time.Second)
+			k8sclient, stopCh := createResourceUsageTestClient(&metricsv1beta1.PodMetrics{
+				ObjectMeta: metav1.ObjectMeta{Name: "checkout-1", Namespace: "shop"},
+				Timestamp:  metav1.NewTime(timestamp),
+				Containers: []metricsv1beta1.ContainerMetrics{
+					{
+						Name: "app",
+						Usage: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("250m"),
+							corev1.ResourceMemory: resource.MustParse("256Mi"),
+						},
+					},
+					{
+						Name: "sidecar",
+						Usage: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("50m"),
+							corev1.ResourceMemory: resource.MustParse("128Mi"),
+						},
+					},
+				},
+			})
+			defer close(stopCh)
+			state := PodResourceUsageMetricsState{
+				End:        time.Now().Add(time.Minute),
+				Namespace:  "shop",
+				Pod:        "checkout-1",
+				Thresholds: tt.thresholds,
+			}
+
+			// When
+			result := statusPodResourceUsageMetricsInternal(k8sclient, &state)
+
+			// Then
+			assert.Equal(t, tt.completed, result.Completed)
+			if tt.expectedError == "" {
+				assert.Nil(t, result.Error)
+			} else {
+				require.NotNil(t, result.Error)
+				assert.Equal(t, tt.expectedError, result.Error.Title)
+			}
+			require.NotNil(t, result.Metrics)
+			metrics := *result.Metrics
+			require.Len(t, metrics, 2)
+			assert.Equal(t, extcommon.ResourceUsageCpuMetric, *metrics[0].Name)
+			assert.Equal(t, float64(300), metrics[0].Value)
+			assert.Equal(t, extcommon.ResourceUsageMemoryMetric, *metrics[1].Name)
+			assert.Equal(t, float64(384*1024*1024), metrics[1].Value)
+			assert.Equal(t, map[string]string{
+				"k8s.cluster-name": "development",
+				"k8s.namespace":    "shop",
+				"k8s.pod.name":     "checkout-1",
+			}, metrics[0].Metric)
+			assert.Equal(t, timestamp, metrics[0].Timestamp)
+
+			// When - the metrics-server didn't scrape again
+			result = statusPodResourceUsageMetricsInternal(k8sclient, &state)
+
+			// Then
+			assert.Nil(t, result.Metrics)
+		})
+	}
+}
+
+func TestStatusPodResourceUsageMetricsIgnoresMissingPodMetrics(t *testing.T) {
+	// Given
+	k8sclient, stopCh := createResourceUsageTestClient(nil)
+	defer close(stopCh)
+	state := PodResourceUsageMetricsState{
+		End:       time.Now().Add(time.Minute),
+		Namespace: "shop",
+		Pod:       "checkout-1",
+	}
+
+	// When
+	result := statusPodResourceUsageMetricsInternal(k8sclient, &state)
+
+	// Then
+	assert.False(t, result.Completed)
+	assert.Nil(t, result.Error)
+	assert.Nil(t, result.Metrics)
+}
+
+func createResourceUsageTestClient(podMetrics *metricsv1beta1.PodMetrics) (*client.Client, chan struct{}) {
+	stopCh := make(chan struct{})
+	k8sclient := client.CreateClient(testclient.NewSimpleClientset(), stopCh, "", client.MockAllPermitted())
+
+	// the object tracker of the fake clientset doesn't map PodMetrics to the "pods" resource of the metrics API
+	metricsClientset := metricsfake.NewSimpleClientset()
+	metricsClientset.PrependReactor("get", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if podMetrics == nil {
+			return true, nil, k8sErrors.NewNotFound(schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}, action.(k8stesting.GetAction).GetName())
+		}
+		return true, podMetrics, nil
+	})
+	k8sclient.SetMetricsClient(metricsClientset)
+	return k8sclient, stopCh
+}
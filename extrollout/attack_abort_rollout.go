@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extrollout
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+)
+
+func NewAbortRolloutAction() action_kit_sdk.Action[extcommon.KubectlActionState] {
+	return &extcommon.KubectlAction{
+		Description:  getAbortRolloutDescription(),
+		OptsProvider: abortRollout(),
+	}
+}
+
+func getAbortRolloutDescription() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          AbortRolloutActionId,
+		Label:       "Abort Rollout",
+		Description: "Abort an Argo Rollout, so that it is scaled back to the stable version. The rollout is retried after the action.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(rolloutIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: RolloutTargetType,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find rollout by cluster, namespace and rollout"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.rollout=\"\"",
+				},
+			}),
+		}),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Label:        "Duration",
+				Description:  extutil.Ptr("The duration of the action. The rollout will be retried after the action."),
+				Name:         "duration",
+				Type:         action_kit_api.Duration,
+				DefaultValue: extutil.Ptr("180s"),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status:  &action_kit_api.MutatingEndpointReferenceWithCallInterval{},
+		Stop:    &action_kit_api.MutatingEndpointReference{},
+	}
+}
+
+// abortRollout patches status.abort like `kubectl argo rollouts abort` and `kubectl argo rollouts retry rollout` do.
+func abortRollout() extcommon.KubectlOptsProvider {
+	return func(ctx context.Context, request action_kit_api.PrepareActionRequestBody) (*extcommon.KubectlOpts, error) {
+		namespace := request.Target.Attributes["k8s.namespace"][0]
+		rollout := request.Target.Attributes["k8s.rollout"][0]
+
+		rolloutDefinition := client.K8S.RolloutByNamespaceAndName(namespace, rollout)
+		if rolloutDefinition == nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to find rollout %s/%s.", namespace, rollout), nil)
+		}
+		if rolloutDefinition.Status.Abort {
+			return nil, extension_kit.ToError(fmt.Sprintf("Rollout %s/%s is already aborted.", namespace, rollout), nil)
+		}
+
+		command := []string{"kubectl",
+			"patch",
+			fmt.Sprintf("rollout/%s", rollout),
+			fmt.Sprintf("--namespace=%s", namespace),
+			"--subresource=status",
+			"--type=merge",
+			"--patch={\"status\":{\"abort\":true}}",
+		}
+
+		rollbackCommand := []string{"kubectl",
+			"patch",
+			fmt.Sprintf("rollout/%s", rollout),
+			fmt.Sprintf("--namespace=%s", namespace),
+			"--subresource=status",
+			"--type=merge",
+			"--patch={\"status\":{\"abort\":false}}",
+		}
+
+		return &extcommon.KubectlOpts{
+			Command:         command,
+			RollbackCommand: &rollbackCommand,
+			LogTargetType:   "rollout",
+			LogTargetName:   fmt.Sprintf("%s/%s", namespace, rollout),
+			LogActionName:   "abort rollout",
+			AuditTarget:     &extcommon.ExecutionTarget{Kind: "Rollout", Namespace: namespace, Name: rollout},
+		}, nil
+	}
+}
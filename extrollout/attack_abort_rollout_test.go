@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extrollout
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAbortRolloutPreparesCommands(t *testing.T) {
+	// Given
+	request := abortRolloutRequest()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client.K8S, _ = getTestClient(stopCh, rollout(map[string]interface{}{"canary": map[string]interface{}{}}, nil))
+
+	action := NewAbortRolloutAction()
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, request)
+	require.NoError(t, err)
+
+	// Then
+	require.Equal(t, []string{"kubectl", "patch", "rollout/checkout", "--namespace=shop", "--subresource=status", "--type=merge", "--patch={\"status\":{\"abort\":true}}"}, state.Opts.Command)
+	require.Equal(t, []string{"kubectl", "patch", "rollout/checkout", "--namespace=shop", "--subresource=status", "--type=merge", "--patch={\"status\":{\"abort\":false}}"}, *state.Opts.RollbackCommand)
+}
+
+func TestAbortRolloutFailsIfAlreadyAborted(t *testing.T) {
+	// Given
+	request := abortRolloutRequest()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	client.K8S, _ = getTestClient(stopCh, rollout(map[string]interface{}{"canary": map[string]interface{}{}}, map[string]interface{}{"phase": "Degraded", "abort": true}))
+
+	action := NewAbortRolloutAction()
+	state := action.NewEmptyState()
+
+	// When
+	_, err := action.Prepare(context.Background(), &state, request)
+
+	// Then
+	require.ErrorContains(t, err, "Rollout shop/checkout is already aborted.")
+}
+
+func abortRolloutRequest() action_kit_api.PrepareActionRequestBody {
+	return action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration": 100000,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.namespace": {"shop"},
+				"k8s.rollout":   {"checkout"},
+			},
+		}),
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extrollout
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"os/exec"
+	"time"
+)
+
+const rolloutPhaseHealthy = "Healthy"
+
+type RestartRolloutAction struct {
+}
+
+type RestartRolloutState struct {
+	Cluster   string                 `json:"cluster"`
+	Namespace string                 `json:"namespace"`
+	Rollout   string                 `json:"rollout"`
+	Wait      bool                   `json:"wait"`
+	RestartAt time.Time              `json:"restartAt"`
+	Audit     *extcommon.AttackAudit `json:"audit,omitempty"`
+}
+
+type RestartRolloutConfig struct {
+	Wait bool
+}
+
+func NewRestartRolloutAction() action_kit_sdk.Action[RestartRolloutState] {
+	return RestartRolloutAction{}
+}
+
+var _ action_kit_sdk.Action[RestartRolloutState] = (*RestartRolloutAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[RestartRolloutState] = (*RestartRolloutAction)(nil)
+var _ action_kit_sdk.ActionWithStop[RestartRolloutState] = (*RestartRolloutAction)(nil)
+
+func (f RestartRolloutAction) NewEmptyState() RestartRolloutState {
+	return RestartRolloutState{}
+}
+
+func (f RestartRolloutAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          RestartRolloutActionId,
+		Label:       "Restart Rollout",
+		Description: "Restart the pods of an Argo Rollout, like `kubectl argo rollouts restart` does.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(rolloutIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: RolloutTargetType,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find rollout by cluster, namespace and rollout"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.rollout=\"\"",
+				},
+			}),
+		}),
+		TimeControl: action_kit_api.TimeControlInternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Label:        "wait for restart completion",
+				Name:         "wait",
+				Type:         action_kit_api.Boolean,
+				Advanced:     extutil.Ptr(true),
+				DefaultValue: extutil.Ptr("false"),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status:  extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{}),
+		Stop:    extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (f RestartRolloutAction) Prepare(_ context.Context, state *RestartRolloutState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return prepareRestartRolloutInternal(client.K8S, state, request)
+}
+
+func prepareRestartRolloutInternal(k8s *client.Client, state *RestartRolloutState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config RestartRolloutConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	state.Cluster = request.Target.Attributes["k8s.cluster-name"][0]
+	state.Namespace = request.Target.Attributes["k8s.namespace"][0]
+	state.Rollout = request.Target.Attributes["k8s.rollout"][0]
+	state.Wait = config.Wait
+	if k8s.RolloutByNamespaceAndName(state.Namespace, state.Rollout) == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to find rollout %s/%s.", state.Namespace, state.Rollout), nil)
+	}
+	state.Audit = extcommon.NewAttackAudit(request, extcommon.ExecutionTarget{Kind: "Rollout", Namespace: state.Namespace, Name: state.Rollout}, "restart rollout")
+	extcommon.RememberExecutionTarget(request, "Rollout")
+	return nil, nil
+}
+
+func (f RestartRolloutAction) Start(_ context.Context, state *RestartRolloutState) (*action_kit_api.StartResult, error) {
+	log.Info().Msgf("Starting restart rollout attack for %+v", state)
+
+	state.RestartAt = time.Now().UTC().Truncate(time.Second)
+	command := restartRolloutCommand(state)
+	cmd := exec.Command(command[0], command[1:]...)
+	cmdOut, cmdErr := cmd.CombinedOutput()
+	if cmdErr != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to execute restart rollout: %s", cmdOut), cmdErr)
+	}
+	state.Audit.Started(client.K8S)
+
+	return nil, nil
+}
+
+// restartRolloutCommand sets spec.restartAt, the Argo Rollouts controller then replaces all pods created before.
+func restartRolloutCommand(state *RestartRolloutState) []string {
+	return []string{"kubectl",
+		"patch",
+		fmt.Sprintf("rollout/%s", state.Rollout),
+		fmt.Sprintf("--namespace=%s", state.Namespace),
+		"--type=merge",
+		fmt.Sprintf("--patch={\"spec\":{\"restartAt\":\"%s\"}}", state.RestartAt.Format(time.RFC3339)),
+	}
+}
+
+func (f RestartRolloutAction) Status(_ context.Context, state *RestartRolloutState) (*action_kit_api.StatusResult, error) {
+	result := statusRestartRolloutInternal(client.K8S, state)
+	if result.Completed {
+		state.Audit.Stop(client.K8S)
+	}
+	return result, nil
+}
+
+func statusRestartRolloutInternal(k8s *client.Client, state *RestartRolloutState) *action_kit_api.StatusResult {
+	if !state.Wait {
+		return &action_kit_api.StatusResult{Completed: true}
+	}
+
+	rollout := k8s.RolloutByNamespaceAndName(state.Namespace, state.Rollout)
+	if rollout == nil {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Rollout %s/%s not found", state.Namespace, state.Rollout),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+	if rollout.Status.Abort {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Rollout %s/%s was aborted during the restart.", state.Namespace, state.Rollout),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			}),
+		}
+	}
+
+	restarted := rollout.Status.RestartedAt != nil && !rollout.Status.RestartedAt.Time.Before(state.RestartAt)
+	return &action_kit_api.StatusResult{
+		Completed: restarted && rollout.Status.Phase == rolloutPhaseHealthy,
+	}
+}
+
+func (f RestartRolloutAction) Stop(_ context.Context, state *RestartRolloutState) (*action_kit_api.StopResult, error) {
+	// the restart can't be stopped, but the audit trail must not report the attack as in progress anymore
+	state.Audit.Stop(client.K8S)
+	return nil, nil
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extrollout
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRestartRolloutPrepareExtractsState(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, _ := getTestClient(stopCh, rollout(map[string]interface{}{"canary": map[string]interface{}{}}, nil))
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"wait": true,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {"test"},
+				"k8s.namespace":    {"shop"},
+				"k8s.rollout":      {"checkout"},
+			},
+		}),
+	}
+	state := NewRestartRolloutAction().NewEmptyState()
+
+	// When
+	_, err := prepareRestartRolloutInternal(k8sClient, &state, request)
+	require.NoError(t, err)
+
+	// Then
+	require.Equal(t, "test", state.Cluster)
+	require.Equal(t, "shop", state.Namespace)
+	require.Equal(t, "checkout", state.Rollout)
+	require.True(t, state.Wait)
+}
+
+func TestRestartRolloutCommand(t *testing.T) {
+	// Given
+	state := RestartRolloutState{
+		Namespace: "shop",
+		Rollout:   "checkout",
+		RestartAt: time.Date(2024, 5, 17, 8, 30, 0, 0, time.UTC),
+	}
+
+	// When
+	command := restartRolloutCommand(&state)
+
+	// Then
+	require.Equal(t, []string{"kubectl", "patch", "rollout/checkout", "--namespace=shop", "--type=merge", "--patch={\"spec\":{\"restartAt\":\"2024-05-17T08:30:00Z\"}}"}, command)
+}
+
+func TestRestartRolloutStatusWaitsForRestart(t *testing.T) {
+	restartAt := time.Date(2024, 5, 17, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		status    map[string]interface{}
+		completed bool
+		failed    bool
+	}{
+		{
+			name:      "not restarted yet",
+			status:    map[string]interface{}{"phase": "Healthy", "restartedAt": "2024-05-16T08:30:00Z"},
+			completed: false,
+		},
+		{
+			name:      "restarted but progressing",
+			status:    map[string]interface{}{"phase": "Progressing", "restartedAt": "2024-05-17T08:30:00Z"},
+			completed: false,
+		},
+		{
+			name:      "restarted",
+			status:    map[string]interface{}{"phase": "Healthy", "restartedAt": "2024-05-17T08:30:05Z"},
+			completed: true,
+		},
+		{
+			name:      "aborted",
+			status:    map[string]interface{}{"phase": "Degraded", "abort": true},
+			completed: true,
+			failed:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			k8sClient, _ := getTestClient(stopCh, rollout(map[string]interface{}{"canary": map[string]interface{}{}}, tt.status))
+			state := RestartRolloutState{Namespace: "shop", Rollout: "checkout", Wait: true, RestartAt: restartAt}
+
+			// When
+			result := statusRestartRolloutInternal(k8sClient, &state)
+
+			// Then
+			assert.Equal(t, tt.completed, result.Completed)
+			if tt.failed {
+				require.NotNil(t, result.Error)
+				assert.Equal(t, action_kit_api.Failed, *result.Error.Status)
+			} else {
+				assert.Nil(t, result.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extrollout
+
+const (
+	RolloutTargetType      = "com.steadybit.extension_kubernetes.kubernetes-rollout"
+	PodCountCheckActionId  = "com.steadybit.extension_kubernetes.rollout_pod_count_check"
+	RestartRolloutActionId = "com.steadybit.extension_kubernetes.restart_rollout"
+	AbortRolloutActionId   = "com.steadybit.extension_kubernetes.abort_rollout"
+	rolloutIcon            = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%3Cpath%20d%3D%22M12%202.5l8.5%204.75v9.5L12%2021.5l-8.5-4.75v-9.5L12%202.5z%22%20stroke%3D%22currentColor%22%20stroke-width%3D%221.5%22%20stroke-linejoin%3D%22round%22%2F%3E%3Cpath%20d%3D%22M8%2012h6.5M12%209l3%203-3%203%22%20stroke%3D%22currentColor%22%20stroke-width%3D%221.5%22%20stroke-linecap%3D%22round%22%20stroke-linejoin%3D%22round%22%2F%3E%3C%2Fsvg%3E"
+)
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extrollout
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"time"
+)
+
+type PodCountCheckAction struct {
+}
+
+type PodCountCheckState struct {
+	Timeout           time.Time
+	PodCountCheckMode string
+	Namespace         string
+	Rollout           string
+	InitialCount      int
+	PodCountThreshold int
+}
+
+type PodCountCheckConfig struct {
+	Duration                 int
+	PodCountCheckMode        string
+	PodCountThreshold        int
+	PodCountThresholdPercent int
+}
+
+func NewPodCountCheckAction() action_kit_sdk.Action[PodCountCheckState] {
+	return PodCountCheckAction{}
+}
+
+var _ action_kit_sdk.Action[PodCountCheckState] = (*PodCountCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[PodCountCheckState] = (*PodCountCheckAction)(nil)
+
+func (f PodCountCheckAction) NewEmptyState() PodCountCheckState {
+	return PodCountCheckState{}
+}
+
+func (f PodCountCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          PodCountCheckActionId,
+		Label:       "Rollout Pod Count",
+		Description: "Verify pod counts of an Argo Rollout",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(rolloutIcon),
+		Category:    extutil.Ptr("Kubernetes"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:          RolloutTargetType,
+			QuantityRestriction: extutil.Ptr(action_kit_api.All),
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "default",
+					Description: extutil.Ptr("Find rollout by cluster, namespace and rollout"),
+					Query:       "k8s.cluster-name=\"\" AND k8s.namespace=\"\" AND k8s.rollout=\"\"",
+				},
+			}),
+		}),
+		Parameters: extcommon.PodCountCheckParameters(),
+		Prepare:    action_kit_api.MutatingEndpointReference{},
+		Start:      action_kit_api.MutatingEndpointReference{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (f PodCountCheckAction) Prepare(_ context.Context, state *PodCountCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return preparePodCountCheckInternal(client.K8S, state, request)
+}
+
+func preparePodCountCheckInternal(k8s *client.Client, state *PodCountCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var config PodCountCheckConfig
+	if err := extconversion.Convert(request.Config, &config); err != nil {
+		return nil, extension_kit.ToError("Failed to unmarshal the config.", err)
+	}
+	threshold := extcommon.SelectPodCountThreshold(config.PodCountCheckMode, config.PodCountThreshold, config.PodCountThresholdPercent)
+	if err := extcommon.ValidatePodCountThreshold(config.PodCountCheckMode, threshold); err != nil {
+		return nil, err
+	}
+
+	namespace := request.Target.Attributes["k8s.namespace"][0]
+	rollout := request.Target.Attributes["k8s.rollout"][0]
+	r := k8s.RolloutByNamespaceAndName(namespace, rollout)
+	if r == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to find rollout %s/%s.", namespace, rollout), nil)
+	}
+
+	state.Timeout = time.Now().Add(time.Millisecond * time.Duration(config.Duration))
+	state.PodCountCheckMode = config.PodCountCheckMode
+	state.Namespace = namespace
+	state.Rollout = rollout
+	state.InitialCount = int(r.Status.ReadyReplicas)
+	state.PodCountThreshold = threshold
+	return nil, nil
+}
+
+func (f PodCountCheckAction) Start(_ context.Context, _ *PodCountCheckState) (*action_kit_api.StartResult, error) {
+	return nil, nil
+}
+
+func (f PodCountCheckAction) Status(_ context.Context, state *PodCountCheckState) (*action_kit_api.StatusResult, error) {
+	return statusPodCountCheckInternal(client.K8S, state), nil
+}
+
+func statusPodCountCheckInternal(k8s *client.Client, state *PodCountCheckState) *action_kit_api.StatusResult {
+	rollout := k8s.RolloutByNamespaceAndName(state.Namespace, state.Rollout)
+	if rollout == nil {
+		return &action_kit_api.StatusResult{
+			Error: extutil.Ptr(action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Rollout %s not found", state.Rollout),
+				Status: extutil.Ptr(action_kit_api.Errored),
+			}),
+		}
+	}
+
+	var desiredCount *int
+	if rollout.Spec.Replicas != nil {
+		desiredCount = extutil.Ptr(int(*rollout.Spec.Replicas))
+	}
+
+	return extcommon.PodCountCheckStatus(extcommon.PodCountCheckInput{
+		Kind:         "Rollout",
+		Name:         state.Rollout,
+		Mode:         state.PodCountCheckMode,
+		Threshold:    state.PodCountThreshold,
+		InitialCount: state.InitialCount,
+		ReadyCount:   int(rollout.Status.ReadyReplicas),
+		DesiredCount: desiredCount,
+		Timeout:      state.Timeout,
+	})
+}
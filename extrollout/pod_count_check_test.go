@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extrollout
+
+import (
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPrepareCheckExtractsState(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, _ := getTestClient(stopCh, rollout(map[string]interface{}{"canary": map[string]interface{}{}}, nil))
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":          1000 * 10,
+			"podCountCheckMode": "podCountIncreased",
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {"test"},
+				"k8s.namespace":    {"shop"},
+				"k8s.rollout":      {"checkout"},
+			},
+		}),
+	}
+	state := NewPodCountCheckAction().NewEmptyState()
+
+	// When
+	result, err := preparePodCountCheckInternal(k8sClient, &state, request)
+
+	// Then
+	require.Nil(t, err)
+	require.Nil(t, result)
+	require.True(t, state.Timeout.After(time.Now()))
+	require.Equal(t, "podCountIncreased", state.PodCountCheckMode)
+	require.Equal(t, "shop", state.Namespace)
+	require.Equal(t, "checkout", state.Rollout)
+	require.Equal(t, 3, state.InitialCount)
+}
+
+func TestStatusCheckRolloutNotFound(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, _ := getTestClient(stopCh)
+	state := PodCountCheckState{
+		Timeout:           time.Now().Add(time.Minute),
+		PodCountCheckMode: extcommon.PodCountMin1,
+		Namespace:         "shop",
+		Rollout:           "checkout",
+	}
+
+	// When
+	result := statusPodCountCheckInternal(k8sClient, &state)
+
+	// Then
+	require.Equal(t, "Rollout checkout not found", result.Error.Title)
+	require.Equal(t, action_kit_api.Errored, *result.Error.Status)
+}
+
+func TestStatusCheckRolloutWithDesiredCount(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	k8sClient, _ := getTestClient(stopCh, rollout(map[string]interface{}{"canary": map[string]interface{}{}}, map[string]interface{}{"readyReplicas": int64(2)}))
+	state := PodCountCheckState{
+		Timeout:           time.Now().Add(-time.Second),
+		PodCountCheckMode: extcommon.PodCountEqualsDesiredCount,
+		Namespace:         "shop",
+		Rollout:           "checkout",
+	}
+
+	// When
+	result := statusPodCountCheckInternal(k8sClient, &state)
+
+	// Then
+	require.True(t, result.Completed)
+	assert.Equal(t, action_kit_api.Failed, *result.Error.Status)
+	assert.Contains(t, result.Error.Title, "checkout has only 2 of desired 3 pods ready.")
+}
@@ -0,0 +1,260 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extrollout
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"reflect"
+	"time"
+)
+
+const (
+	RolloutStrategyCanary    = "canary"
+	RolloutStrategyBlueGreen = "blueGreen"
+)
+
+type rolloutDiscovery struct {
+	k8s     *client.Client
+	targets *extcommon.TargetCache
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*rolloutDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*rolloutDiscovery)(nil)
+)
+
+func NewRolloutDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
+	discovery := &rolloutDiscovery{k8s: k8s, targets: extcommon.NewTargetCache("rollout")}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
+		reflect.TypeOf(corev1.Pod{}),
+		reflect.TypeOf(corev1.Namespace{}),
+		reflect.TypeOf(unstructured.Unstructured{}),
+		reflect.TypeOf(appsv1.Deployment{}),
+		reflect.TypeOf(autoscalingv2.HorizontalPodAutoscaler{}),
+		reflect.TypeOf(corev1.Service{}),
+		reflect.TypeOf(policyv1.PodDisruptionBudget{}),
+	)
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, 5*time.Second),
+	)
+}
+
+func (d *rolloutDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: RolloutTargetType,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("30s"),
+		},
+	}
+}
+
+func (d *rolloutDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       RolloutTargetType,
+		Label:    discovery_kit_api.PluralLabel{One: "Argo Rollout", Other: "Argo Rollouts"},
+		Category: extutil.Ptr("Kubernetes"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(rolloutIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "k8s.rollout"},
+				{Attribute: "k8s.namespace"},
+				{Attribute: "k8s.cluster-name"},
+				{Attribute: "k8s.rollout.phase"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "k8s.rollout",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *rolloutDiscovery) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	rollouts := d.k8s.Rollouts()
+
+	filteredRollouts := make([]*client.Rollout, 0, len(rollouts))
+	for _, rollout := range rollouts {
+		if d.k8s.IsExcludedFromDiscovery(rollout.ObjectMeta) {
+			continue
+		}
+		filteredRollouts = append(filteredRollouts, rollout)
+	}
+
+	targets := make([]discovery_kit_api.Target, len(filteredRollouts))
+
+	nodes := d.k8s.Nodes()
+	for i, rollout := range filteredRollouts {
+		template, selector := podTemplate(d.k8s, rollout)
+		var pods []*corev1.Pod
+		if selector != nil {
+			pods = d.k8s.PodsByLabelSelector(selector, rollout.Namespace)
+		}
+		services := d.k8s.ServicesMatchingToPodLabels(rollout.Namespace, template.Labels)
+		routes := d.k8s.RoutesMatchingToServices(rollout.Namespace, services)
+		var pdbs []*policyv1.PodDisruptionBudget
+		if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+			pdbs = d.k8s.PodDisruptionBudgetsMatchingToPodLabels(rollout.Namespace, template.Labels)
+		}
+		var hpa *autoscalingv2.HorizontalPodAutoscaler
+		if d.k8s.Permissions().CanReadHorizontalPodAutoscalers() {
+			hpa = d.k8s.HorizontalPodAutoscalerByNamespaceAndTarget(rollout.Namespace, "Rollout", rollout.Name)
+		}
+
+		var dependencies extcommon.Dependencies
+		extcommon.AddDependencies(&dependencies, rollout)
+		if ref := rollout.Spec.WorkloadRef; ref != nil && ref.Kind == "Deployment" {
+			if deployment := d.k8s.DeploymentByNamespaceAndName(rollout.Namespace, ref.Name); deployment != nil {
+				extcommon.AddDependencies(&dependencies, deployment)
+			}
+		}
+		extcommon.AddDependencies(&dependencies, pods...)
+		extcommon.AddNodeDependencies(&dependencies, pods, nodes)
+		extcommon.AddDependencies(&dependencies, services...)
+		extcommon.AddDependencies(&dependencies, routes...)
+		extcommon.AddDependencies(&dependencies, pdbs...)
+		if hpa != nil {
+			extcommon.AddDependencies(&dependencies, hpa)
+		}
+
+		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, rollout.Namespace, rollout.Name)
+		targets[i] = d.targets.Target(targetName, dependencies, func() discovery_kit_api.Target {
+			attributes := map[string][]string{
+				"k8s.namespace":                 {rollout.Namespace},
+				"k8s.rollout":                   {rollout.Name},
+				"k8s.workload-type":             {"rollout"},
+				"k8s.workload-owner":            {rollout.Name},
+				"k8s.cluster-name":              {extconfig.Config.ClusterName},
+				"k8s.distribution":              {d.k8s.Distribution},
+				"k8s.rollout.min-ready-seconds": {fmt.Sprintf("%d", rollout.Spec.MinReadySeconds)},
+				"k8s.rollout.aborted":           {fmt.Sprintf("%t", rollout.Status.Abort)},
+			}
+			if rollout.Spec.Replicas != nil {
+				attributes["k8s.specification.replicas"] = []string{fmt.Sprintf("%d", *rollout.Spec.Replicas)}
+			}
+			if strategy := RolloutStrategy(rollout); strategy != "" {
+				attributes["k8s.rollout.strategy"] = []string{strategy}
+			}
+			if rollout.Status.Phase != "" {
+				attributes["k8s.rollout.phase"] = []string{rollout.Status.Phase}
+			}
+			for key, value := range rollout.ObjectMeta.Labels {
+				if extconfig.Config.IncludesLabel("rollout", key) {
+					attributes[fmt.Sprintf("k8s.rollout.label.%v", key)] = []string{value}
+					attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+				}
+			}
+
+			if selector != nil {
+				for key, value := range extcommon.GetPodBasedAttributes("rollout", rollout.ObjectMeta, pods, nodes) {
+					attributes[key] = value
+				}
+			}
+			for key, value := range extcommon.GetServiceNames(services) {
+				attributes[key] = value
+			}
+			for key, value := range extcommon.GetRouteAttributes(routes) {
+				attributes[key] = value
+			}
+
+			if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+				for key, value := range extcommon.GetPodDisruptionBudgetAttributes(pdbs, rollout.Spec.Replicas) {
+					attributes[key] = value
+				}
+			}
+
+			for key, value := range extcommon.GetKubeScoreForRollout(rollout.ObjectMeta, rollout.Spec.Replicas, selector, template, services, hpa) {
+				attributes[key] = value
+			}
+
+			return discovery_kit_api.Target{
+				Id:         targetName,
+				TargetType: RolloutTargetType,
+				Label:      rollout.Name,
+				Attributes: attributes,
+			}
+		})
+	}
+	d.targets.Prune()
+	return extcommon.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesRollout), nil
+}
+
+// RolloutStrategy returns canary or blueGreen, depending on the configured strategy of the rollout.
+func RolloutStrategy(rollout *client.Rollout) string {
+	if rollout.Spec.Strategy.Canary != nil {
+		return RolloutStrategyCanary
+	}
+	if rollout.Spec.Strategy.BlueGreen != nil {
+		return RolloutStrategyBlueGreen
+	}
+	return ""
+}
+
+// podTemplate returns the pod template and selector of the rollout. A rollout referencing a deployment by
+// spec.workloadRef uses the template of the deployment, the selector of the rollout is optional in that case.
+func podTemplate(k8s *client.Client, rollout *client.Rollout) (corev1.PodTemplateSpec, *metav1.LabelSelector) {
+	template := rollout.Spec.Template
+	selector := rollout.Spec.Selector
+	if ref := rollout.Spec.WorkloadRef; ref != nil && ref.Kind == "Deployment" {
+		if deployment := k8s.DeploymentByNamespaceAndName(rollout.Namespace, ref.Name); deployment != nil {
+			template = deployment.Spec.Template
+			if selector == nil {
+				selector = deployment.Spec.Selector
+			}
+		}
+	}
+	return template, selector
+}
+
+func (d *rolloutDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		getRolloutToContainerEnrichmentRule(),
+	}
+}
+
+func getRolloutToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmentRule {
+	return discovery_kit_api.TargetEnrichmentRule{
+		Id:      "com.steadybit.extension_kubernetes.kubernetes-rollout-to-container",
+		Version: extbuild.GetSemverVersionStringOrUnknown(),
+		Src: discovery_kit_api.SourceOrDestination{
+			Type: RolloutTargetType,
+			Selector: map[string]string{
+				"k8s.container.id.stripped": "${dest.container.id.stripped}",
+			},
+		},
+		Dest: discovery_kit_api.SourceOrDestination{
+			Type: "com.steadybit.extension_container.container",
+			Selector: map[string]string{
+				"container.id.stripped": "${src.k8s.container.id.stripped}",
+			},
+		},
+		Attributes: []discovery_kit_api.Attribute{
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.rollout.label.",
+			},
+			{
+				Matcher: discovery_kit_api.Regex,
+				Name:    "^k8s\\.label\\.(?!topology).*",
+			},
+		},
+	}
+}
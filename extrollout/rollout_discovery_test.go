@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extrollout
+
+import (
+	"context"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func Test_rolloutDiscovery(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.LabelFilter = []string{"secret-label"}
+	extconfig.Config.DiscoveryMaxPodCount = 50
+	k8sClient, clientset := getTestClient(stopCh, rollout(map[string]interface{}{"canary": map[string]interface{}{}}, nil))
+	createPod(t, clientset, "checkout-5f8d9-x1y2z", map[string]string{"app": "checkout"})
+	createPod(t, clientset, "other", map[string]string{"app": "other"})
+
+	d := &rolloutDiscovery{k8s: k8sClient}
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, k8sClient.Pods(), 2)
+	}, 1*time.Second, 100*time.Millisecond)
+	targets, _ := d.DiscoverTargets(context.Background())
+
+	// Then
+	require.Len(t, targets, 1)
+	target := targets[0]
+	assert.Equal(t, "development/shop/checkout", target.Id)
+	assert.Equal(t, "checkout", target.Label)
+	assert.Equal(t, RolloutTargetType, target.TargetType)
+	assert.Equal(t, map[string][]string{
+		"host.hostname":                                        {"unknown"},
+		"host.domainname":                                      {"unknown"},
+		"k8s.cluster-name":                                     {"development"},
+		"k8s.distribution":                                     {"kubernetes"},
+		"k8s.namespace":                                        {"shop"},
+		"k8s.rollout":                                          {"checkout"},
+		"k8s.workload-type":                                    {"rollout"},
+		"k8s.workload-owner":                                   {"checkout"},
+		"k8s.rollout.min-ready-seconds":                        {"10"},
+		"k8s.rollout.aborted":                                  {"false"},
+		"k8s.rollout.strategy":                                 {"canary"},
+		"k8s.rollout.phase":                                    {"Healthy"},
+		"k8s.specification.replicas":                           {"3"},
+		"k8s.rollout.label.team":                               {"payments"},
+		"k8s.label.team":                                       {"payments"},
+		"k8s.pod.name":                                         {"checkout-5f8d9-x1y2z"},
+		"k8s.container.id":                                     {"containerd://abcdef"},
+		"k8s.container.id.stripped":                            {"abcdef"},
+		"k8s.specification.has-host-podantiaffinity":           {"false"},
+		"k8s.specification.has-pod-disruption-budget":          {"false"},
+		"k8s.container.image.with-latest-tag":                  {"checkout"},
+		"k8s.container.spec.limit.cpu.not-set":                 {"checkout"},
+		"k8s.container.spec.limit.memory.not-set":              {"checkout"},
+		"k8s.container.spec.request.cpu.not-set":               {"checkout"},
+		"k8s.container.spec.request.memory.not-set":            {"checkout"},
+		"k8s.container.spec.limit.ephemeral-storage.not-set":   {"checkout"},
+		"k8s.container.spec.request.ephemeral-storage.not-set": {"checkout"},
+	}, target.Attributes)
+}
+
+func Test_rolloutDiscoveryUsesTemplateOfReferencedDeployment(t *testing.T) {
+	// Given
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	extconfig.Config.ClusterName = "development"
+	extconfig.Config.DiscoveryMaxPodCount = 50
+	r := rollout(map[string]interface{}{"blueGreen": map[string]interface{}{}}, nil)
+	unstructured.RemoveNestedField(r.Object, "spec", "template")
+	unstructured.RemoveNestedField(r.Object, "spec", "selector")
+	require.NoError(t, unstructured.SetNestedMap(r.Object, map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "checkout"}, "spec", "workloadRef"))
+	k8sClient, clientset := getTestClient(stopCh, r)
+	_, err := clientset.AppsV1().Deployments("shop").Create(context.Background(), deployment(), metav1.CreateOptions{})
+	require.NoError(t, err)
+	createPod(t, clientset, "checkout-5f8d9-x1y2z", map[string]string{"app": "checkout"})
+
+	d := &rolloutDiscovery{k8s: k8sClient}
+	// When
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Len(c, k8sClient.Pods(), 1)
+		assert.NotNil(c, k8sClient.DeploymentByNamespaceAndName("shop", "checkout"))
+	}, 1*time.Second, 100*time.Millisecond)
+	targets, _ := d.DiscoverTargets(context.Background())
+
+	// Then
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"blueGreen"}, targets[0].Attributes["k8s.rollout.strategy"])
+	assert.Equal(t, []string{"checkout-5f8d9-x1y2z"}, targets[0].Attributes["k8s.pod.name"])
+	assert.Equal(t, []string{"checkout"}, targets[0].Attributes["k8s.container.spec.limit.cpu.not-set"])
+	assert.NotContains(t, targets[0].Attributes, "k8s.specification.has-rolling-update-strategy")
+}
+
+func rollout(strategy map[string]interface{}, status map[string]interface{}) *unstructured.Unstructured {
+	if status == nil {
+		status = map[string]interface{}{"phase": "Healthy", "readyReplicas": int64(3)}
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata": map[string]interface{}{
+			"name":      "checkout",
+			"namespace": "shop",
+			"labels":    map[string]interface{}{"team": "payments", "secret-label": "secret"},
+		},
+		"spec": map[string]interface{}{
+			"replicas":        int64(3),
+			"minReadySeconds": int64(10),
+			"selector":        map[string]interface{}{"matchLabels": map[string]interface{}{"app": "checkout"}},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "checkout"}},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "checkout", "image": "checkout:latest"},
+					},
+				},
+			},
+			"strategy": strategy,
+		},
+		"status": status,
+	}}
+}
+
+func deployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: extutil.Ptr(int32(0)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "checkout"}},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "checkout", Image: "checkout:1.0.0"}},
+				},
+			},
+		},
+	}
+}
+
+func createPod(t *testing.T, clientset kubernetes.Interface, name string, labels map[string]string) {
+	_, err := clientset.CoreV1().
+		Pods("shop").
+		Create(context.Background(), &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "shop",
+				Labels:    labels,
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{ContainerID: "containerd://abcdef", Name: "checkout"},
+				},
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func getTestClient(stopCh <-chan struct{}, objects ...runtime.Object) (*client.Client, kubernetes.Interface) {
+	extconfig.Config.DiscoveryCustomResources = nil
+	clientset := testclient.NewSimpleClientset()
+	k8sClient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	gvr := client.RolloutCustomResource.GroupVersionResource()
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{gvr: "RolloutList"}, objects...)
+	k8sClient.WatchCustomResources(dynamicClient, stopCh, []extconfig.CustomResource{client.RolloutCustomResource})
+	return k8sClient, clientset
+}
@@ -112,6 +112,7 @@ func scaleStatefulSet() extcommon.KubectlOptsProvider {
 			LogTargetType:   "statefulSet",
 			LogTargetName:   fmt.Sprintf("%s/%s", namespace, statefulSet),
 			LogActionName:   "scale statefulSet",
+			AuditTarget:     &extcommon.ExecutionTarget{Kind: "StatefulSet", Namespace: namespace, Name: statefulSet},
 		}, nil
 	}
 }
@@ -6,4 +6,7 @@ package extstatefulset
 const (
 	StatefulSetTargetType    = "com.steadybit.extension_kubernetes.kubernetes-statefulset"
 	ScaleStatefulSetActionId = "com.steadybit.extension_kubernetes.scale_statefulset"
+	PodCountCheckActionId    = "com.steadybit.extension_kubernetes.statefulset_pod_count_check"
+	PdbCheckActionId         = "com.steadybit.extension_kubernetes.statefulset_pdb_check"
+	ContainerLogsActionId    = "com.steadybit.extension_kubernetes.statefulset_container_logs"
 )
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2024 Steadybit GmbH
+
+package extstatefulset
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/steadybit/extension-kubernetes/client"
+	"github.com/steadybit/extension-kubernetes/extcommon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"testing"
+	"time"
+)
+
+func TestPrepareStatefulSetPodCountCheckExtractsState(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"duration":                 1000 * 10,
+			"podCountCheckMode":        extcommon.PodCountAtLeastPercentOfDesired,
+			"podCountThresholdPercent": 50,
+		},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {"test"},
+				"k8s.namespace":    {"shop"},
+				"k8s.statefulset":  {"db"},
+			},
+		}),
+	}
+	k8sclient, _ := createStatefulSetClient(t, 3, 2)
+	state := NewPodCountCheckAction().NewEmptyState()
+
+	// When
+	result, err := preparePodCountCheckInternal(k8sclient, &state, request)
+
+	// Then
+	require.Nil(t, err)
+	require.Nil(t, result)
+	require.True(t, state.Timeout.After(time.Now()))
+	require.Equal(t, extcommon.PodCountAtLeastPercentOfDesired, state.PodCountCheckMode)
+	require.Equal(t, "shop", state.Namespace)
+	require.Equal(t, "db", state.StatefulSet)
+	require.Equal(t, 2, state.InitialCount)
+	require.Equal(t, 50, state.PodCountThreshold)
+}
+
+func TestStatusStatefulSetPodCountCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          string
+		threshold     int
+		readyCount    int32
+		completed     bool
+		expectedError string
+	}{
+		{
+			name:       "equals desired count",
+			mode:       extcommon.PodCountEqualsDesiredCount,
+			readyCount: 3,
+			completed:  true,
+		},
+		{
+			name:          "equals desired count fails",
+			mode:          extcommon.PodCountEqualsDesiredCount,
+			readyCount:    2,
+			completed:     true,
+			expectedError: "db has only 2 of desired 3 pods ready.",
+		},
+		{
+			name:       "at least percent of desired count",
+			mode:       extcommon.PodCountAtLeastPercentOfDesired,
+			threshold:  60,
+			readyCount: 2,
+			completed:  true,
+		},
+		{
+			name:          "at least percent of desired count fails",
+			mode:          extcommon.PodCountAtLeastPercentOfDesired,
+			threshold:     70,
+			readyCount:    2,
+			completed:     true,
+			expectedError: "db has only 2 of desired 3 pods ready, required are at least 70%.",
+		},
+		{
+			name:          "never below percent of desired count fails",
+			mode:          extcommon.PodCountNeverBelowPercentOfDesired,
+			threshold:     100,
+			readyCount:    2,
+			completed:     true,
+			expectedError: "db dropped to 2 of desired 3 pods ready, required are at least 100%.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			k8sclient, stopCh := createStatefulSetClient(t, 3, tt.readyCount)
+			defer close(stopCh)
+			state := PodCountCheckState{
+				Timeout:           time.Now().Add(-time.Second),
+				PodCountCheckMode: tt.mode,
+				Namespace:         "shop",
+				StatefulSet:       "db",
+				PodCountThreshold: tt.threshold,
+			}
+
+			// When
+			result := statusPodCountCheckInternal(k8sclient, &state)
+
+			// Then
+			assert.Equal(t, tt.completed, result.Completed)
+			if tt.expectedError == "" {
+				assert.Nil(t, result.Error)
+			} else {
+				require.NotNil(t, result.Error)
+				assert.Equal(t, tt.expectedError, result.Error.Title)
+				assert.Equal(t, action_kit_api.Failed, *result.Error.Status)
+			}
+		})
+	}
+}
+
+func TestStatusStatefulSetPodCountCheckNotFound(t *testing.T) {
+	// Given
+	k8sclient, stopCh := createStatefulSetClient(t, 3, 3)
+	defer close(stopCh)
+	state := PodCountCheckState{
+		Timeout:           time.Now(),
+		PodCountCheckMode: extcommon.PodCountMin1,
+		Namespace:         "shop",
+		StatefulSet:       "unknown",
+	}
+
+	// When
+	result := statusPodCountCheckInternal(k8sclient, &state)
+
+	// Then
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "StatefulSet unknown not found", result.Error.Title)
+	assert.Equal(t, action_kit_api.Errored, *result.Error.Status)
+}
+
+func createStatefulSetClient(t *testing.T, replicas int32, readyReplicas int32) (*client.Client, chan struct{}) {
+	clientset := testclient.NewSimpleClientset()
+	_, err := clientset.
+		AppsV1().
+		StatefulSets("shop").
+		Create(context.Background(), &appsv1.StatefulSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "StatefulSet",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db",
+				Namespace: "shop",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: extutil.Ptr(replicas),
+			},
+			Status: appsv1.StatefulSetStatus{
+				ReadyReplicas: readyReplicas,
+			},
+		}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	k8sclient := client.CreateClient(clientset, stopCh, "", client.MockAllPermitted())
+	assert.Eventually(t, func() bool {
+		return k8sclient.StatefulSetByNamespaceAndName("shop", "db") != nil
+	}, time.Second, 100*time.Millisecond)
+	return k8sclient, stopCh
+}
@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
-	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
@@ -16,13 +15,14 @@ import (
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/utils/strings/slices"
+	policyv1 "k8s.io/api/policy/v1"
 	"reflect"
 	"time"
 )
 
 type statefulSetDiscovery struct {
-	k8s *client.Client
+	k8s     *client.Client
+	targets *extcommon.TargetCache
 }
 
 var (
@@ -31,8 +31,13 @@ var (
 )
 
 func NewStatefulSetDiscovery(k8s *client.Client) discovery_kit_sdk.TargetDiscovery {
-	discovery := &statefulSetDiscovery{k8s: k8s}
-	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s, reflect.TypeOf(corev1.Pod{}), reflect.TypeOf(appsv1.StatefulSet{}))
+	discovery := &statefulSetDiscovery{k8s: k8s, targets: extcommon.NewTargetCache("statefulset")}
+	chRefresh := extcommon.TriggerOnKubernetesResourceChange(k8s,
+		reflect.TypeOf(corev1.Pod{}),
+		reflect.TypeOf(corev1.Namespace{}),
+		reflect.TypeOf(appsv1.StatefulSet{}),
+		reflect.TypeOf(policyv1.PodDisruptionBudget{}),
+	)
 	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
 		discovery_kit_sdk.WithRefreshTargetsNow(),
 		discovery_kit_sdk.WithRefreshTargetsTrigger(context.Background(), chRefresh, 5*time.Second),
@@ -75,58 +80,83 @@ func (d *statefulSetDiscovery) DiscoverTargets(_ context.Context) ([]discovery_k
 	statefulsets := d.k8s.StatefulSets()
 
 	filteredStatefulSets := make([]*appsv1.StatefulSet, 0, len(statefulsets))
-	if extconfig.Config.DisableDiscoveryExcludes {
-		filteredStatefulSets = statefulsets
-	} else {
-		for _, sts := range statefulsets {
-			if client.IsExcludedFromDiscovery(sts.ObjectMeta) {
-				continue
-			}
-			filteredStatefulSets = append(filteredStatefulSets, sts)
+	for _, sts := range statefulsets {
+		if d.k8s.IsExcludedFromDiscovery(sts.ObjectMeta) {
+			continue
 		}
+		filteredStatefulSets = append(filteredStatefulSets, sts)
 	}
 
 	nodes := d.k8s.Nodes()
 	targets := make([]discovery_kit_api.Target, len(filteredStatefulSets))
 	for i, sts := range filteredStatefulSets {
-		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, sts.Namespace, sts.Name)
-		attributes := map[string][]string{
-			"k8s.namespace":      {sts.Namespace},
-			"k8s.statefulset":    {sts.Name},
-			"k8s.workload-type":  {"statefulset"},
-			"k8s.workload-owner": {sts.Name},
-			"k8s.cluster-name":   {extconfig.Config.ClusterName},
-			"k8s.distribution":   {d.k8s.Distribution},
+		pods := d.k8s.PodsByLabelSelector(sts.Spec.Selector, sts.Namespace)
+		services := d.k8s.ServicesMatchingToPodLabels(sts.Namespace, sts.Spec.Template.Labels)
+		routes := d.k8s.RoutesMatchingToServices(sts.Namespace, services)
+		var pdbs []*policyv1.PodDisruptionBudget
+		if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+			pdbs = d.k8s.PodDisruptionBudgetsMatchingToPodLabels(sts.Namespace, sts.Spec.Template.Labels)
 		}
 
-		if sts.Spec.Replicas != nil {
-			attributes["k8s.specification.replicas"] = []string{fmt.Sprintf("%d", *sts.Spec.Replicas)}
-		}
+		var dependencies extcommon.Dependencies
+		extcommon.AddDependencies(&dependencies, sts)
+		extcommon.AddDependencies(&dependencies, pods...)
+		extcommon.AddNodeDependencies(&dependencies, pods, nodes)
+		extcommon.AddDependencies(&dependencies, services...)
+		extcommon.AddDependencies(&dependencies, routes...)
+		extcommon.AddDependencies(&dependencies, pdbs...)
 
-		for key, value := range sts.ObjectMeta.Labels {
-			if !slices.Contains(extconfig.Config.LabelFilter, key) {
-				attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+		targetName := fmt.Sprintf("%s/%s/%s", extconfig.Config.ClusterName, sts.Namespace, sts.Name)
+		targets[i] = d.targets.Target(targetName, dependencies, func() discovery_kit_api.Target {
+			attributes := map[string][]string{
+				"k8s.namespace":      {sts.Namespace},
+				"k8s.statefulset":    {sts.Name},
+				"k8s.workload-type":  {"statefulset"},
+				"k8s.workload-owner": {sts.Name},
+				"k8s.cluster-name":   {extconfig.Config.ClusterName},
+				"k8s.distribution":   {d.k8s.Distribution},
 			}
-		}
-		for key, value := range extcommon.GetPodBasedAttributes("statefulset", sts.ObjectMeta, d.k8s.PodsByLabelSelector(sts.Spec.Selector, sts.Namespace), nodes) {
 
-			attributes[key] = value
-		}
-		for key, value := range extcommon.GetServiceNames(d.k8s.ServicesMatchingToPodLabels(sts.Namespace, sts.Spec.Template.Labels)) {
-			attributes[key] = value
-		}
-		for key, value := range extcommon.GetKubeScoreForStatefulSet(sts, d.k8s.ServicesMatchingToPodLabels(sts.Namespace, sts.Spec.Template.Labels)) {
-			attributes[key] = value
-		}
+			if sts.Spec.Replicas != nil {
+				attributes["k8s.specification.replicas"] = []string{fmt.Sprintf("%d", *sts.Spec.Replicas)}
+			}
 
-		targets[i] = discovery_kit_api.Target{
-			Id:         targetName,
-			TargetType: StatefulSetTargetType,
-			Label:      sts.Name,
-			Attributes: attributes,
-		}
+			for key, value := range sts.ObjectMeta.Labels {
+				if extconfig.Config.IncludesLabel("statefulset", key) {
+					attributes[fmt.Sprintf("k8s.label.%v", key)] = []string{value}
+				}
+			}
+			for key, value := range extcommon.GetAnnotationAttributes("statefulset", sts.ObjectMeta) {
+				attributes[key] = value
+			}
+			for key, value := range extcommon.GetPodBasedAttributes("statefulset", sts.ObjectMeta, pods, nodes) {
+				attributes[key] = value
+			}
+			for key, value := range extcommon.GetServiceNames(services) {
+				attributes[key] = value
+			}
+			for key, value := range extcommon.GetRouteAttributes(routes) {
+				attributes[key] = value
+			}
+			if d.k8s.Permissions().CanReadPodDisruptionBudgets() {
+				for key, value := range extcommon.GetPodDisruptionBudgetAttributes(pdbs, sts.Spec.Replicas) {
+					attributes[key] = value
+				}
+			}
+			for key, value := range extcommon.GetKubeScoreForStatefulSet(sts, services) {
+				attributes[key] = value
+			}
+
+			return discovery_kit_api.Target{
+				Id:         targetName,
+				TargetType: StatefulSetTargetType,
+				Label:      sts.Name,
+				Attributes: attributes,
+			}
+		})
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesStatefulSet), nil
+	d.targets.Prune()
+	return extcommon.ApplyAttributeExcludes(targets, extconfig.Config.DiscoveryAttributesExcludesStatefulSet), nil
 }
 
 func (d *statefulSetDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
@@ -156,6 +186,10 @@ func getStatefulSetToContainerEnrichmentRule() discovery_kit_api.TargetEnrichmen
 				Matcher: discovery_kit_api.Regex,
 				Name:    "^k8s\\.label\\.(?!topology).*",
 			},
+			{
+				Matcher: discovery_kit_api.StartsWith,
+				Name:    "k8s.statefulset.annotation.",
+			},
 		},
 	}
 }
@@ -43,20 +43,21 @@ func Test_statefulSetDiscovery(t *testing.T) {
 			nodes:       []*v1.Node{testNode("worker-1"), testNode("worker-2")},
 			statefulSet: testStatefulSet(nil),
 			expectedAttributesExactly: map[string][]string{
-				"host.hostname":                              {"worker-1", "worker-2"},
-				"host.domainname":                            {"worker-1.internal", "worker-2.internal"},
-				"k8s.namespace":                              {"default"},
-				"k8s.statefulset":                            {"shop"},
-				"k8s.workload-type":                          {"statefulset"},
-				"k8s.workload-owner":                         {"shop"},
-				"k8s.label.best-city":                        {"Kevelaer"},
-				"k8s.specification.replicas":                 {"3"},
-				"k8s.cluster-name":                           {"development"},
-				"k8s.pod.name":                               {"shop-pod-aaaaa", "shop-pod-bbbbb"},
-				"k8s.container.id":                           {"crio://abcdef-aaaaa", "crio://abcdef-bbbbb"},
-				"k8s.container.id.stripped":                  {"abcdef-aaaaa", "abcdef-bbbbb"},
-				"k8s.distribution":                           {"kubernetes"},
-				"k8s.specification.has-host-podantiaffinity": {"false"},
+				"host.hostname":                               {"worker-1", "worker-2"},
+				"host.domainname":                             {"worker-1.internal", "worker-2.internal"},
+				"k8s.namespace":                               {"default"},
+				"k8s.statefulset":                             {"shop"},
+				"k8s.workload-type":                           {"statefulset"},
+				"k8s.workload-owner":                          {"shop"},
+				"k8s.label.best-city":                         {"Kevelaer"},
+				"k8s.specification.replicas":                  {"3"},
+				"k8s.cluster-name":                            {"development"},
+				"k8s.pod.name":                                {"shop-pod-aaaaa", "shop-pod-bbbbb"},
+				"k8s.container.id":                            {"crio://abcdef-aaaaa", "crio://abcdef-bbbbb"},
+				"k8s.container.id.stripped":                   {"abcdef-aaaaa", "abcdef-bbbbb"},
+				"k8s.distribution":                            {"kubernetes"},
+				"k8s.specification.has-host-podantiaffinity":  {"false"},
+				"k8s.specification.has-pod-disruption-budget": {"false"},
 			},
 		},
 		{
@@ -16,7 +16,6 @@ require (
 	github.com/steadybit/action-kit/go/action_kit_test v1.2.9
 	github.com/steadybit/advice-kit/go/advice_kit_api v0.0.1-beta.7
 	github.com/steadybit/discovery-kit/go/discovery_kit_api v1.5.2
-	github.com/steadybit/discovery-kit/go/discovery_kit_commons v0.1.0
 	github.com/steadybit/discovery-kit/go/discovery_kit_sdk v1.0.6
 	github.com/steadybit/discovery-kit/go/discovery_kit_test v1.1.2
 	github.com/steadybit/extension-kit v1.8.14
@@ -29,6 +28,7 @@ require (
 	k8s.io/apimachinery v0.29.3
 	k8s.io/client-go v0.29.3
 	k8s.io/klog/v2 v2.120.1
+	k8s.io/metrics v0.29.3
 	k8s.io/utils v0.0.0-20240310230437-4693a0247e57
 )
 
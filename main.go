@@ -23,11 +23,17 @@ import (
 	"github.com/steadybit/extension-kubernetes/extcommon"
 	"github.com/steadybit/extension-kubernetes/extconfig"
 	"github.com/steadybit/extension-kubernetes/extcontainer"
+	"github.com/steadybit/extension-kubernetes/extcronjob"
+	"github.com/steadybit/extension-kubernetes/extcustomresource"
 	"github.com/steadybit/extension-kubernetes/extdaemonset"
 	"github.com/steadybit/extension-kubernetes/extdeployment"
+	"github.com/steadybit/extension-kubernetes/extdeploymentconfig"
 	"github.com/steadybit/extension-kubernetes/extevents"
+	"github.com/steadybit/extension-kubernetes/extjob"
+	"github.com/steadybit/extension-kubernetes/extnamespace"
 	"github.com/steadybit/extension-kubernetes/extnode"
 	"github.com/steadybit/extension-kubernetes/extpod"
+	"github.com/steadybit/extension-kubernetes/extrollout"
 	"github.com/steadybit/extension-kubernetes/extstatefulset"
 	_ "go.uber.org/automaxprocs" // Importing automaxprocs automatically adjusts GOMAXPROCS.
 	_ "net/http/pprof"           //allow pprof
@@ -60,6 +66,15 @@ func main() {
 		if client.K8S.Permissions().IsScaleDeploymentPermitted() {
 			action_kit_sdk.RegisterAction(extdeployment.NewScaleDeploymentAction())
 		}
+		if client.K8S.Permissions().CanReadHorizontalPodAutoscalers() {
+			action_kit_sdk.RegisterAction(extdeployment.NewHpaScalingCheckAction())
+		}
+		if client.K8S.Permissions().CanReadPodDisruptionBudgets() {
+			action_kit_sdk.RegisterAction(extdeployment.NewPdbCheckAction())
+		}
+		if client.K8S.Permissions().CanReadPodLogs() {
+			action_kit_sdk.RegisterAction(extdeployment.NewContainerLogsAction())
+		}
 	}
 
 	if !extconfig.Config.DiscoveryDisabledPod {
@@ -70,6 +85,12 @@ func main() {
 		if client.K8S.Permissions().IsCrashLoopPodPermitted() {
 			action_kit_sdk.RegisterAction(extpod.NewCrashLoopAction())
 		}
+		if client.K8S.Permissions().CanReadResourceMetrics() {
+			action_kit_sdk.RegisterAction(extpod.NewPodResourceUsageMetricsAction())
+		}
+		if client.K8S.Permissions().CanReadPodLogs() {
+			action_kit_sdk.RegisterAction(extpod.NewContainerLogsAction())
+		}
 	}
 
 	if !extconfig.Config.DiscoveryDisabledStatefulSet {
@@ -77,15 +98,27 @@ func main() {
 		if client.K8S.Permissions().IsScaleStatefulSetPermitted() {
 			action_kit_sdk.RegisterAction(extstatefulset.NewScaleStatefulSetAction())
 		}
+		action_kit_sdk.RegisterAction(extstatefulset.NewPodCountCheckAction())
+		if client.K8S.Permissions().CanReadPodDisruptionBudgets() {
+			action_kit_sdk.RegisterAction(extstatefulset.NewPdbCheckAction())
+		}
+		if client.K8S.Permissions().CanReadPodLogs() {
+			action_kit_sdk.RegisterAction(extstatefulset.NewContainerLogsAction())
+		}
 	}
 
 	if !extconfig.Config.DiscoveryDisabledDaemonSet {
 		discovery_kit_sdk.Register(extdaemonset.NewDaemonSetDiscovery(client.K8S))
+		action_kit_sdk.RegisterAction(extdaemonset.NewPodCountCheckAction())
+		if client.K8S.Permissions().CanReadPodLogs() {
+			action_kit_sdk.RegisterAction(extdaemonset.NewContainerLogsAction())
+		}
 	}
 
 	if !extconfig.Config.DiscoveryDisabledNode {
 		discovery_kit_sdk.Register(extnode.NewNodeDiscovery(client.K8S))
 		action_kit_sdk.RegisterAction(extnode.NewNodeCountCheckAction())
+		action_kit_sdk.RegisterAction(extnode.NewNodeConditionCheckAction())
 
 		if client.K8S.Permissions().IsDrainNodePermitted() {
 			action_kit_sdk.RegisterAction(extnode.NewDrainNodeAction())
@@ -93,6 +126,62 @@ func main() {
 		if client.K8S.Permissions().IsTaintNodePermitted() {
 			action_kit_sdk.RegisterAction(extnode.NewTaintNodeAction())
 		}
+		if client.K8S.Permissions().CanReadResourceMetrics() {
+			action_kit_sdk.RegisterAction(extnode.NewNodeResourceUsageMetricsAction())
+		}
+	}
+
+	if !extconfig.Config.DiscoveryDisabledNamespace && client.K8S.Permissions().CanReadNamespaces() {
+		discovery_kit_sdk.Register(extnamespace.NewNamespaceDiscovery(client.K8S))
+	}
+
+	if !extconfig.Config.DiscoveryDisabledJob && client.K8S.Permissions().CanReadJobs() {
+		discovery_kit_sdk.Register(extjob.NewJobDiscovery(client.K8S))
+		action_kit_sdk.RegisterAction(extjob.NewJobCompletedCheckAction())
+	}
+
+	if !extconfig.Config.DiscoveryDisabledCronJob && client.K8S.Permissions().CanReadCronJobs() {
+		discovery_kit_sdk.Register(extcronjob.NewCronJobDiscovery(client.K8S))
+		if client.K8S.Permissions().IsSuspendCronJobPermitted() {
+			action_kit_sdk.RegisterAction(extcronjob.NewSuspendCronJobAction())
+		}
+	}
+
+	rolloutDiscoveryEnabled := !extconfig.Config.DiscoveryDisabledRollout && client.K8S.CustomResourceDefinition(client.RolloutCustomResource.Kind) != nil
+	if rolloutDiscoveryEnabled {
+		discovery_kit_sdk.Register(extrollout.NewRolloutDiscovery(client.K8S))
+		action_kit_sdk.RegisterAction(extrollout.NewPodCountCheckAction())
+		if client.K8S.Permissions().IsRestartRolloutPermitted() {
+			action_kit_sdk.RegisterAction(extrollout.NewRestartRolloutAction())
+		}
+		if client.K8S.Permissions().IsAbortRolloutPermitted() {
+			action_kit_sdk.RegisterAction(extrollout.NewAbortRolloutAction())
+		}
+	}
+
+	deploymentConfigDiscoveryEnabled := client.K8S.Distribution == client.DistributionOpenShift && !extconfig.Config.DiscoveryDisabledDeploymentConfig && client.K8S.CustomResourceDefinition(client.DeploymentConfigCustomResource.Kind) != nil
+	if deploymentConfigDiscoveryEnabled {
+		discovery_kit_sdk.Register(extdeploymentconfig.NewDeploymentConfigDiscovery(client.K8S))
+		if client.K8S.Permissions().IsScaleDeploymentConfigPermitted() {
+			action_kit_sdk.RegisterAction(extdeploymentconfig.NewScaleDeploymentConfigAction())
+		}
+		if client.K8S.Permissions().IsRolloutDeploymentConfigPermitted() {
+			action_kit_sdk.RegisterAction(extdeploymentconfig.NewRolloutLatestDeploymentConfigAction())
+		}
+	}
+
+	for _, customResource := range extconfig.Config.DiscoveryCustomResources {
+		if rolloutDiscoveryEnabled && customResource.AttributeName() == client.RolloutCustomResource.AttributeName() {
+			// rollouts are discovered by the rollout discovery
+			continue
+		}
+		if deploymentConfigDiscoveryEnabled && customResource.AttributeName() == client.DeploymentConfigCustomResource.AttributeName() {
+			// deployment configs are discovered by the deployment config discovery
+			continue
+		}
+		if client.K8S.CustomResourceDefinition(customResource.Kind) != nil {
+			discovery_kit_sdk.Register(extcustomresource.NewCustomResourceDiscovery(client.K8S, customResource))
+		}
 	}
 
 	if !extconfig.Config.DiscoveryDisabledContainer {
@@ -111,6 +200,8 @@ func main() {
 
 	extadvice.RegisterAdviceHandlers()
 
+	exthttp.RegisterHttpHandler("/debug/subscriptions", exthttp.GetterAsHandler(client.K8S.SubscriptionStats))
+
 	action_kit_sdk.InstallSignalHandler()
 	action_kit_sdk.RegisterCoverageEndpoints()
 
@@ -208,6 +299,12 @@ func getAdviceRefs() []advice_kit_api.DescribingEndpointReference {
 				Path:   "/advice/k8s-single-replica",
 			})
 		}
+		if adviceId == "*" || adviceId == extadvice.PodDisruptionBudgetID {
+			refs = append(refs, advice_kit_api.DescribingEndpointReference{
+				Method: "GET",
+				Path:   "/advice/k8s-pod-disruption-budget",
+			})
+		}
 		if adviceId == "*" || adviceId == extadvice.HostPodantiaffinityID {
 			refs = append(refs, advice_kit_api.DescribingEndpointReference{
 				Method: "GET",